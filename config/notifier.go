@@ -0,0 +1,35 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+	"todo-api/notifier"
+)
+
+// InitNotifier は、通知の送信方法を NOTIFIER_DRIVER に応じて初期化する。
+// smtp の場合は SMTP サーバからメールを送り、それ以外の場合はログに出力する。
+func InitNotifier() notifier.Notifier {
+	if os.Getenv("NOTIFIER_DRIVER") == "smtp" {
+		config := notifier.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}
+		if config.Host == "" || config.From == "" {
+			log.Fatalf("SMTP_HOST and SMTP_FROM are required when NOTIFIER_DRIVER is smtp")
+		}
+		if config.Port == "" {
+			config.Port = "25"
+		}
+		// 1通の送信の時間の上限は SMTP_TIMEOUT (例: 1m) で変更できる
+		if timeout, err := time.ParseDuration(os.Getenv("SMTP_TIMEOUT")); err == nil && timeout > 0 {
+			config.Timeout = timeout
+		}
+		return notifier.NewSMTPNotifier(config)
+	}
+
+	return notifier.NewLogNotifier()
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"log"
+	"os"
+	"todo-api/storage"
+)
+
+// InitStorage は、添付ファイルの保存先を STORAGE_DRIVER に応じて初期化する。
+// s3 の場合は S3 互換ストレージ、それ以外の場合はローカルファイルシステム(STORAGE_LOCAL_DIR)に保存する。
+func InitStorage() storage.Storage {
+	if os.Getenv("STORAGE_DRIVER") == "s3" {
+		config := storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		}
+		if config.Endpoint == "" || config.Bucket == "" {
+			log.Fatalf("S3_ENDPOINT and S3_BUCKET are required when STORAGE_DRIVER is s3")
+		}
+		if config.Region == "" {
+			config.Region = "us-east-1"
+		}
+		return storage.NewS3Storage(config)
+	}
+
+	dir := os.Getenv("STORAGE_LOCAL_DIR")
+	if dir == "" {
+		dir = "./data/attachments"
+	}
+	return storage.NewLocalStorage(dir)
+}
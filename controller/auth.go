@@ -1,20 +1,23 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
-	"time"
 	"todo-api/controller/request"
 	"todo-api/controller/response"
+	myErrors "todo-api/errors"
 	"todo-api/usecase"
 
-	"github.com/dgrijalva/jwt-go"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
 type AuthController interface {
 	Login(ctx echo.Context) error
+	RefreshToken(ctx echo.Context) error
+	Logout(ctx echo.Context) error
 }
 
 type authController struct {
@@ -32,36 +35,67 @@ func NewAuthController(validate *validator.Validate, authUseCase usecase.AuthUse
 func (c *authController) Login(ctx echo.Context) error {
 	var requestBody request.LoginRequestBody
 	if err := ctx.Bind(&requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+		return myErrors.ErrBadRequest.WithMessage("invalid request")
 	}
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
 	}
 
 	loginUser, err := c.authUseCase.Login(
+		ctx.Request().Context(),
 		requestBody.Username,
 		requestBody.Password,
 	)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "could not create auth"})
+		if errors.Is(err, myErrors.ErrInvalidCredentials) {
+			return myErrors.ErrInvalidCredentials.WithMessage("invalid username or password")
+		}
+
+		slog.Info(fmt.Sprintf("error Login: %v", err))
+		return myErrors.ErrInternal.WithMessage("could not create auth")
+	}
+
+	authToken, err := c.authUseCase.CreateToken(ctx.Request().Context(), loginUser)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error CreateToken: %v", err))
+		return myErrors.ErrInternal.WithMessage("Error while generating token")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":  loginUser.ID,
-		"exp": time.Now().Add(time.Hour * 24).Unix(),
-	})
+	return ctx.JSON(http.StatusOK, response.NewLoginResponseBody(authToken))
+}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "JWT Secret not found"})
+func (c *authController) RefreshToken(ctx echo.Context) error {
+	var requestBody request.RefreshTokenRequestBody
+	if err := ctx.Bind(&requestBody); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("invalid request")
 	}
 
-	tokenString, err := token.SignedString([]byte(jwtSecret))
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	authToken, err := c.authUseCase.RefreshToken(ctx.Request().Context(), requestBody.RefreshToken)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Error while generating token"})
+		if errors.Is(err, myErrors.ErrInvalidToken) {
+			return myErrors.ErrInvalidToken.WithMessage("Invalid refresh token")
+		}
+
+		slog.Info(fmt.Sprintf("error RefreshToken: %v", err))
+		return myErrors.ErrInternal.WithMessage("Error while generating token")
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewRefreshTokenResponseBody(authToken))
+}
+
+func (c *authController) Logout(ctx echo.Context) error {
+	sessionId := ctx.Get("session_id").(string)
+	if err := c.authUseCase.Logout(ctx.Request().Context(), sessionId); err != nil {
+		slog.Info(fmt.Sprintf("error Logout: %v", err))
+		return myErrors.ErrInternal.WithMessage("could not logout")
 	}
 
-	return ctx.JSON(http.StatusOK, response.NewLoginResponseBody(tokenString))
+	return ctx.NoContent(http.StatusNoContent)
 }
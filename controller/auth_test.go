@@ -6,14 +6,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"todo-api/controller/request"
 	"todo-api/controller/response"
+	myErrors "todo-api/errors"
 	mock_usecase "todo-api/mock/usecase"
 	"todo-api/model"
+	"todo-api/usecase"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -24,7 +24,7 @@ func TestAuthController_Login(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockAuthUseCase(ctrl)
-	validate := validator.New()
+	validate := NewValidator()
 	authController := NewAuthController(validate, mockUseCase)
 
 	e := echo.New()
@@ -43,11 +43,14 @@ func TestAuthController_Login(t *testing.T) {
 				Password: "password",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().Login("testuser", "password").Return(&model.User{ID: 1}, nil)
-				os.Setenv("JWT_SECRET", "secret")
+				mockUseCase.EXPECT().Login(gomock.Any(), "testuser", "password").Return(&model.User{ID: 1}, nil)
+				mockUseCase.EXPECT().CreateToken(gomock.Any(), &model.User{ID: 1}).Return(&usecase.AuthToken{
+					AccessToken:  "<token>",
+					RefreshToken: "<refresh_token>",
+				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   &response.LoginResponseBody{Token: "<token>"},
+			expectedBody:   &response.LoginResponseBody{Token: "<token>", RefreshToken: "<refresh_token>"},
 		},
 		{
 			name: "Validation Error",
@@ -69,6 +72,18 @@ func TestAuthController_Login(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   nil,
 		},
+		{
+			name: "Invalid Credentials",
+			requestBody: request.LoginRequestBody{
+				Username: "testuser",
+				Password: "wrong",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().Login(gomock.Any(), "testuser", "wrong").Return(nil, myErrors.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   nil,
+		},
 		{
 			name: "Auth UseCase Error",
 			requestBody: request.LoginRequestBody{
@@ -76,20 +91,20 @@ func TestAuthController_Login(t *testing.T) {
 				Password: "password",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().Login("testuser", "password").Return(nil, errors.New("some error"))
+				mockUseCase.EXPECT().Login(gomock.Any(), "testuser", "password").Return(nil, errors.New("some error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
 		},
 		{
-			name: "JWT Secret Not Found",
+			name: "CreateToken Error",
 			requestBody: request.LoginRequestBody{
 				Username: "testuser",
 				Password: "password",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().Login("testuser", "password").Return(&model.User{ID: 1}, nil)
-				os.Setenv("JWT_SECRET", "")
+				mockUseCase.EXPECT().Login(gomock.Any(), "testuser", "password").Return(&model.User{ID: 1}, nil)
+				mockUseCase.EXPECT().CreateToken(gomock.Any(), &model.User{ID: 1}).Return(nil, errors.New("JWT Secret not found"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
@@ -107,15 +122,143 @@ func TestAuthController_Login(t *testing.T) {
 			ctx := e.NewContext(req, rec)
 
 			// Assert the response
-			if assert.NoError(t, authController.Login(ctx)) {
-				assert.Equal(t, tt.expectedStatus, rec.Code)
-				if tt.expectedBody != nil {
-					var actualBody response.LoginResponseBody
-					if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actualBody)) {
-						assert.NotNil(t, tt.expectedBody.Token, actualBody.Token)
-					}
+			if err := authController.Login(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				var actualBody response.LoginResponseBody
+				if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actualBody)) {
+					assert.Equal(t, tt.expectedBody.Token, actualBody.Token)
+					assert.Equal(t, tt.expectedBody.RefreshToken, actualBody.RefreshToken)
 				}
 			}
 		})
 	}
 }
+
+func TestAuthController_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockAuthUseCase(ctrl)
+	validate := NewValidator()
+	authController := NewAuthController(validate, mockUseCase)
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    request.RefreshTokenRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: request.RefreshTokenRequestBody{RefreshToken: "old"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().RefreshToken(gomock.Any(), "old").Return(&usecase.AuthToken{
+					AccessToken:  "<token>",
+					RefreshToken: "new",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   &response.RefreshTokenResponseBody{Token: "<token>", RefreshToken: "new"},
+		},
+		{
+			name:           "Validation Error",
+			requestBody:    request.RefreshTokenRequestBody{RefreshToken: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Invalid Refresh Token",
+			requestBody: request.RefreshTokenRequestBody{RefreshToken: "old"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().RefreshToken(gomock.Any(), "old").Return(nil, myErrors.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   problem(http.StatusUnauthorized, "invalid_token", "Invalid refresh token"),
+		},
+		{
+			name:        "Auth UseCase Error",
+			requestBody: request.RefreshTokenRequestBody{RefreshToken: "old"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().RefreshToken(gomock.Any(), "old").Return(nil, errors.New("some error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Error while generating token"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			if err := authController.RefreshToken(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tt.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockAuthUseCase(ctrl)
+	validate := NewValidator()
+	authController := NewAuthController(validate, mockUseCase)
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		mockFunc       func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockUseCase.EXPECT().Logout(gomock.Any(), "session").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Auth UseCase Error",
+			mockFunc: func() {
+				mockUseCase.EXPECT().Logout(gomock.Any(), "session").Return(errors.New("some error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.Set("session_id", "session")
+
+			if err := authController.Logout(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// 会社のMAX取得制限数
+	MAX_COMPANY_LIMIT = 100
+
+	// 会社のデフォルトの取得制限数
+	DEFAULT_COMPANY_LIMIT = 20
+
+	// 会社のデフォルトのオフセット値
+	DEFAULT_COMPANY_OFFSET = 0
+)
+
+type CompanyController interface {
+	GetCompaniesByAdmin(ctx echo.Context) error
+	GetCompanies(ctx echo.Context) error
+	CreateCompanyByAdmin(ctx echo.Context) error
+	UpdateCompanyByAdmin(ctx echo.Context) error
+	DeleteCompanyByAdmin(ctx echo.Context) error
+}
+
+type companyController struct {
+	validate       *validator.Validate
+	companyUseCase usecase.CompanyUseCase
+}
+
+func NewCompanyController(validate *validator.Validate, companyUseCase usecase.CompanyUseCase) CompanyController {
+	return &companyController{
+		validate:       validate,
+		companyUseCase: companyUseCase,
+	}
+}
+
+func (c *companyController) GetCompaniesByAdmin(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		limit = DEFAULT_COMPANY_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_COMPANY_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_COMPANY_LIMIT))
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		offset = DEFAULT_COMPANY_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	companies, err := c.companyUseCase.GetCompanies(ctx.Request().Context(), limit, offset)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetCompanies: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetCompaniesResponseBody(companies))
+}
+
+func (c *companyController) GetCompanies(ctx echo.Context) error {
+	user := ctx.Get("user").(*model.User)
+	companies, err := c.companyUseCase.GetCompaniesByUserId(ctx.Request().Context(), user.ID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetCompaniesByUserId: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetCompaniesResponseBody(companies))
+}
+
+func (c *companyController) CreateCompanyByAdmin(ctx echo.Context) error {
+	requestBody := &request.CreateCompanyRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	company := request.NewCompanyFromCreateCompanyRequestBody(requestBody)
+	company, err := c.companyUseCase.CreateCompany(ctx.Request().Context(), company)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error CreateCompanyByAdmin: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create company")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateCompanyResponseBody(company))
+}
+
+func (c *companyController) UpdateCompanyByAdmin(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	requestBody := &request.UpdateCompanyRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	company := request.NewCompanyFromUpdateCompanyRequestBody(uint(companyId), requestBody)
+	company, err = c.companyUseCase.UpdateCompany(ctx.Request().Context(), uint(companyId), company)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateCompanyByAdmin: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateCompanyResponseBody(company))
+}
+
+func (c *companyController) DeleteCompanyByAdmin(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	err = c.companyUseCase.DeleteCompany(ctx.Request().Context(), uint(companyId))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteCompanyByAdmin: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,392 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompanyController_GetCompaniesByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUseCase(ctrl)
+	validate := NewValidator()
+	companyController := NewCompanyController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		limit          string
+		offset         string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			limit:  "10",
+			offset: "0",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompanies(gomock.Any(), 10, 0).Return([]*model.Company{{ID: 1, Name: "company 1"}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetCompaniesResponseBody([]*model.Company{{ID: 1, Name: "company 1"}}),
+		},
+		{
+			name:   "Default limit",
+			limit:  "",
+			offset: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompanies(gomock.Any(), DEFAULT_COMPANY_LIMIT, DEFAULT_COMPANY_OFFSET).Return([]*model.Company{}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetCompaniesResponseBody([]*model.Company{}),
+		},
+		{
+			name:           "Limit exceeds max",
+			limit:          "101",
+			offset:         "0",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 100"),
+		},
+		{
+			name:   "InternalServerError",
+			limit:  "10",
+			offset: "0",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompanies(gomock.Any(), 10, 0).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/companies?limit="+tc.limit+"&offset="+tc.offset, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			tc.mockFunc()
+
+			if err := companyController.GetCompaniesByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyController_GetCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUseCase(ctrl)
+	validate := NewValidator()
+	companyController := NewCompanyController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		userID         uint
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			userID: 2,
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompaniesByUserId(gomock.Any(), uint(2)).Return([]*model.Company{{ID: 1, Name: "company 1"}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetCompaniesResponseBody([]*model.Company{{ID: 1, Name: "company 1"}}),
+		},
+		{
+			name:   "InternalServerError",
+			userID: 2,
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompaniesByUserId(gomock.Any(), uint(2)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.Set("user", &model.User{ID: tc.userID})
+
+			tc.mockFunc()
+
+			if err := companyController.GetCompanies(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyController_CreateCompanyByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUseCase(ctrl)
+	validate := NewValidator()
+	companyController := NewCompanyController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.CreateCompanyRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: &request.CreateCompanyRequestBody{Name: "company"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompany(gomock.Any(), &model.Company{Name: "company", TrashRetentionDays: model.DEFAULT_TRASH_RETENTION_DAYS, AttachmentQuotaBytes: model.DEFAULT_ATTACHMENT_QUOTA_BYTES, TimeZone: model.DEFAULT_COMPANY_TIME_ZONE}).Return(&model.Company{ID: 1, Name: "company"}, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateCompanyResponseBody(&model.Company{ID: 1, Name: "company"}),
+		},
+		{
+			name:           "Validation Error",
+			requestBody:    &request.CreateCompanyRequestBody{Name: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   validationProblem(validationField("name", "required", "name is a required field")),
+		},
+		{
+			name:        "InternalServerError",
+			requestBody: &request.CreateCompanyRequestBody{Name: "company"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompany(gomock.Any(), &model.Company{Name: "company", TrashRetentionDays: model.DEFAULT_TRASH_RETENTION_DAYS, AttachmentQuotaBytes: model.DEFAULT_ATTACHMENT_QUOTA_BYTES, TimeZone: model.DEFAULT_COMPANY_TIME_ZONE}).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create company"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/companies", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			tc.mockFunc()
+
+			if err := companyController.CreateCompanyByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyController_UpdateCompanyByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUseCase(ctrl)
+	validate := NewValidator()
+	companyController := NewCompanyController(validate, mockUseCase)
+
+	timeZone := "Asia/Tokyo"
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		requestBody    *request.UpdateCompanyRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			companyID:   "1",
+			requestBody: &request.UpdateCompanyRequestBody{Name: "renamed"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompany(gomock.Any(), uint(1), &model.Company{ID: 1, Name: "renamed", TrashRetentionDays: model.DEFAULT_TRASH_RETENTION_DAYS, AttachmentQuotaBytes: model.DEFAULT_ATTACHMENT_QUOTA_BYTES}).Return(&model.Company{ID: 1, Name: "renamed"}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateCompanyResponseBody(&model.Company{ID: 1, Name: "renamed"}),
+		},
+		{
+			name:        "Success - with time zone",
+			companyID:   "1",
+			requestBody: &request.UpdateCompanyRequestBody{Name: "renamed", TimeZone: &timeZone},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompany(gomock.Any(), uint(1), &model.Company{ID: 1, Name: "renamed", TrashRetentionDays: model.DEFAULT_TRASH_RETENTION_DAYS, AttachmentQuotaBytes: model.DEFAULT_ATTACHMENT_QUOTA_BYTES, TimeZone: "Asia/Tokyo"}).Return(&model.Company{ID: 1, Name: "renamed", TimeZone: "Asia/Tokyo"}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateCompanyResponseBody(&model.Company{ID: 1, Name: "renamed", TimeZone: "Asia/Tokyo"}),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			requestBody:    &request.UpdateCompanyRequestBody{Name: "renamed"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:        "Not found",
+			companyID:   "1",
+			requestBody: &request.UpdateCompanyRequestBody{Name: "renamed"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompany(gomock.Any(), uint(1), gomock.Any()).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:        "InternalServerError",
+			companyID:   "1",
+			requestBody: &request.UpdateCompanyRequestBody{Name: "renamed"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompany(gomock.Any(), uint(1), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/companies/"+tc.companyID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := companyController.UpdateCompanyByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyController_DeleteCompanyByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUseCase(ctrl)
+	validate := NewValidator()
+	companyController := NewCompanyController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompany(gomock.Any(), uint(1)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompany(gomock.Any(), uint(1)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "InternalServerError",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompany(gomock.Any(), uint(1)).Return(errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/companies/"+tc.companyID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := companyController.DeleteCompanyByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
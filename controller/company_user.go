@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type CompanyUserController interface {
+	GetCompanyUsers(ctx echo.Context) error
+	CreateCompanyUser(ctx echo.Context) error
+	UpdateCompanyUser(ctx echo.Context) error
+	DeleteCompanyUser(ctx echo.Context) error
+}
+
+type companyUserController struct {
+	validate           *validator.Validate
+	companyUserUseCase usecase.CompanyUserUseCase
+}
+
+func NewCompanyUserController(validate *validator.Validate, companyUserUseCase usecase.CompanyUserUseCase) CompanyUserController {
+	return &companyUserController{
+		validate:           validate,
+		companyUserUseCase: companyUserUseCase,
+	}
+}
+
+func (c *companyUserController) GetCompanyUsers(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	companyUsers, err := c.companyUserUseCase.GetCompanyUsers(ctx.Request().Context(), uint(companyId))
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetCompanyUsers: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetCompanyUsersResponseBody(companyUsers))
+}
+
+func (c *companyUserController) CreateCompanyUser(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	requestBody := &request.CreateCompanyUserRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	companyUser, err := c.companyUserUseCase.CreateCompanyUser(ctx.Request().Context(), uint(companyId), requestBody.UserID, requestBody.Role)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound.WithMessage("user not found")
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("user is already a member")
+		}
+
+		slog.Info(fmt.Sprintf("error CreateCompanyUser: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to add member")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateCompanyUserResponseBody(companyUser))
+}
+
+func (c *companyUserController) UpdateCompanyUser(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	userId, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("user_id is bad request")
+	}
+
+	requestBody := &request.UpdateCompanyUserRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	companyUser, err := c.companyUserUseCase.UpdateCompanyUser(ctx.Request().Context(), uint(companyId), uint(userId), requestBody.Role)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return err
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateCompanyUser: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateCompanyUserResponseBody(companyUser))
+}
+
+func (c *companyUserController) DeleteCompanyUser(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	userId, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("user_id is bad request")
+	}
+
+	// 担当タスクの付け替え先。指定しない場合は担当者なしになる
+	var reassigneeId *uint
+	if reassignToStr := ctx.QueryParam("reassign_to"); reassignToStr != "" {
+		reassignTo, err := strconv.ParseUint(reassignToStr, 10, 64)
+		if err != nil {
+			return myErrors.ErrBadRequest.WithMessage("reassign_to is bad request")
+		}
+		id := uint(reassignTo)
+		reassigneeId = &id
+	}
+
+	err = c.companyUserUseCase.DeleteCompanyUser(ctx.Request().Context(), uint(companyId), uint(userId), reassigneeId)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return err
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteCompanyUser: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,433 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompanyUserController_GetCompanyUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUserUseCase(ctrl)
+	validate := NewValidator()
+	companyUserController := NewCompanyUserController(validate, mockUseCase)
+
+	companyUsers := []*model.CompanyUser{
+		{
+			CompanyID: 1,
+			UserID:    2,
+			User:      &model.User{ID: 2, Username: "user 2", Email: "user2@example.com"},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompanyUsers(gomock.Any(), uint(1)).Return(companyUsers, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetCompanyUsersResponseBody(companyUsers),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "InternalServerError",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetCompanyUsers(gomock.Any(), uint(1)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/members", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := companyUserController.GetCompanyUsers(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyUserController_CreateCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUserUseCase(ctrl)
+	validate := NewValidator()
+	companyUserController := NewCompanyUserController(validate, mockUseCase)
+
+	companyUser := &model.CompanyUser{
+		CompanyID: 1,
+		UserID:    2,
+		User:      &model.User{ID: 2, Username: "user 2", Email: "user2@example.com"},
+	}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		requestBody    *request.CreateCompanyUserRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			companyID:   "1",
+			requestBody: &request.CreateCompanyUserRequestBody{UserID: 2},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompanyUser(gomock.Any(), uint(1), uint(2), "").Return(companyUser, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateCompanyUserResponseBody(companyUser),
+		},
+		{
+			name:           "Validation Error",
+			companyID:      "1",
+			requestBody:    &request.CreateCompanyUserRequestBody{},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   validationProblem(validationField("user_id", "required", "user_id is a required field")),
+		},
+		{
+			name:        "Success with role",
+			companyID:   "1",
+			requestBody: &request.CreateCompanyUserRequestBody{UserID: 2, Role: "viewer"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompanyUser(gomock.Any(), uint(1), uint(2), "viewer").Return(companyUser, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateCompanyUserResponseBody(companyUser),
+		},
+		{
+			name:           "Invalid role",
+			companyID:      "1",
+			requestBody:    &request.CreateCompanyUserRequestBody{UserID: 2, Role: "admin"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   validationProblem(validationField("role", "oneof", "role must be one of [owner manager member viewer]", "owner", "manager", "member", "viewer")),
+		},
+		{
+			name:        "User not found",
+			companyID:   "1",
+			requestBody: &request.CreateCompanyUserRequestBody{UserID: 2},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompanyUser(gomock.Any(), uint(1), uint(2), "").Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "user not found"),
+		},
+		{
+			name:        "Already a member",
+			companyID:   "1",
+			requestBody: &request.CreateCompanyUserRequestBody{UserID: 2},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompanyUser(gomock.Any(), uint(1), uint(2), "").Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "user is already a member"),
+		},
+		{
+			name:        "InternalServerError",
+			companyID:   "1",
+			requestBody: &request.CreateCompanyUserRequestBody{UserID: 2},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateCompanyUser(gomock.Any(), uint(1), uint(2), "").Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to add member"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/"+tc.companyID+"/members", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := companyUserController.CreateCompanyUser(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyUserController_UpdateCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUserUseCase(ctrl)
+	validate := NewValidator()
+	companyUserController := NewCompanyUserController(validate, mockUseCase)
+
+	companyUser := &model.CompanyUser{CompanyID: 1, UserID: 2, Role: "manager"}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		userID         string
+		requestBody    *request.UpdateCompanyUserRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			companyID:   "1",
+			userID:      "2",
+			requestBody: &request.UpdateCompanyUserRequestBody{Role: "manager"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompanyUser(gomock.Any(), uint(1), uint(2), "manager").Return(companyUser, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateCompanyUserResponseBody(companyUser),
+		},
+		{
+			name:           "Invalid user ID",
+			companyID:      "1",
+			userID:         "invalid",
+			requestBody:    &request.UpdateCompanyUserRequestBody{Role: "manager"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "user_id is bad request"),
+		},
+		{
+			name:           "Validation Error",
+			companyID:      "1",
+			userID:         "2",
+			requestBody:    &request.UpdateCompanyUserRequestBody{Role: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   validationProblem(validationField("role", "required", "role is a required field")),
+		},
+		{
+			name:        "Last owner",
+			companyID:   "1",
+			userID:      "2",
+			requestBody: &request.UpdateCompanyUserRequestBody{Role: "manager"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompanyUser(gomock.Any(), uint(1), uint(2), "manager").Return(nil, myErrors.ErrInvalidArgument).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_argument", "invalid argument"),
+		},
+		{
+			name:        "Not found",
+			companyID:   "1",
+			userID:      "2",
+			requestBody: &request.UpdateCompanyUserRequestBody{Role: "manager"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompanyUser(gomock.Any(), uint(1), uint(2), "manager").Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:        "InternalServerError",
+			companyID:   "1",
+			userID:      "2",
+			requestBody: &request.UpdateCompanyUserRequestBody{Role: "manager"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateCompanyUser(gomock.Any(), uint(1), uint(2), "manager").Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/companies/"+tc.companyID+"/members/"+tc.userID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "user_id")
+			ctx.SetParamValues(tc.companyID, tc.userID)
+
+			tc.mockFunc()
+
+			if err := companyUserController.UpdateCompanyUser(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompanyUserController_DeleteCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockCompanyUserUseCase(ctrl)
+	validate := NewValidator()
+	companyUserController := NewCompanyUserController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		userID         string
+		reassignTo     string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			userID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompanyUser(gomock.Any(), uint(1), uint(2), nil).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:       "Success with reassign_to",
+			companyID:  "1",
+			userID:     "2",
+			reassignTo: "3",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompanyUser(gomock.Any(), uint(1), uint(2), &[]uint{3}[0]).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid user ID",
+			companyID:      "1",
+			userID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "user_id is bad request"),
+		},
+		{
+			name:           "Invalid reassign_to",
+			companyID:      "1",
+			userID:         "2",
+			reassignTo:     "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "reassign_to is bad request"),
+		},
+		{
+			name:       "Reassignee is not a member",
+			companyID:  "1",
+			userID:     "2",
+			reassignTo: "3",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompanyUser(gomock.Any(), uint(1), uint(2), &[]uint{3}[0]).Return(myErrors.ErrInvalidArgument).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_argument", "invalid argument"),
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			userID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompanyUser(gomock.Any(), uint(1), uint(2), nil).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "InternalServerError",
+			companyID: "1",
+			userID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteCompanyUser(gomock.Any(), uint(1), uint(2), nil).Return(errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/"+tc.companyID+"/members/"+tc.userID+"?reassign_to="+tc.reassignTo, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "user_id")
+			ctx.SetParamValues(tc.companyID, tc.userID)
+
+			tc.mockFunc()
+
+			if err := companyUserController.DeleteCompanyUser(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// エラーのレスポンス (RFC 7807) のメディアタイプ
+const MIME_APPLICATION_PROBLEM_JSON = "application/problem+json"
+
+// errorKindStatuses は、エラーの分類に対応するステータスコード
+var errorKindStatuses = map[myErrors.Kind]int{
+	myErrors.KindInternal:             http.StatusInternalServerError,
+	myErrors.KindBadRequest:           http.StatusBadRequest,
+	myErrors.KindValidation:           http.StatusBadRequest,
+	myErrors.KindUnauthorized:         http.StatusUnauthorized,
+	myErrors.KindForbidden:            http.StatusForbidden,
+	myErrors.KindNotFound:             http.StatusNotFound,
+	myErrors.KindConflict:             http.StatusConflict,
+	myErrors.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	myErrors.KindPreconditionRequired: http.StatusPreconditionRequired,
+	myErrors.KindPayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	myErrors.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	myErrors.KindUnavailable:          http.StatusServiceUnavailable,
+}
+
+// HTTPErrorHandler は、ハンドラとミドルウェアが返したエラーを application/problem+json のレスポンスにする。
+// echo のエラー (ルーティングやリクエストの読み取りの失敗など) はそのステータスコードを使い、
+// それ以外の想定しないエラーは詳細を返さずに内部エラーとする。
+func HTTPErrorHandler(err error, ctx echo.Context) {
+	// ストリーミングなどで既にレスポンスを返し始めている場合は何もしない
+	if ctx.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	code := myErrors.ErrInternal.Code
+	detail := myErrors.ErrInternal.Message
+	var fields []*myErrors.FieldError
+	var appErr *myErrors.Error
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &appErr):
+		status = errorKindStatuses[appErr.Kind]
+		code = appErr.Code
+		detail = appErr.Message
+		fields = appErr.Fields
+	case errors.As(err, &httpErr):
+		status = httpErr.Code
+		code = strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+		detail = http.StatusText(status)
+		if message, ok := httpErr.Message.(string); ok && status < http.StatusInternalServerError {
+			detail = message
+		}
+	default:
+		slog.Info(fmt.Sprintf("error %s %s: %v", ctx.Request().Method, ctx.Path(), err))
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentType, MIME_APPLICATION_PROBLEM_JSON)
+	if ctx.Request().Method == http.MethodHead {
+		err = ctx.NoContent(status)
+	} else {
+		err = ctx.JSON(status, response.NewProblemResponseBody(status, code, detail, fields))
+	}
+	if err != nil {
+		slog.Info(fmt.Sprintf("error HTTPErrorHandler: %v", err))
+	}
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	myErrors "todo-api/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// problem は、エラーのレスポンスボディの期待値を返す
+func problem(status int, code, detail string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":   "about:blank",
+		"title":  http.StatusText(status),
+		"status": status,
+		"code":   code,
+		"detail": detail,
+	}
+}
+
+// validationProblem は、入力値の検証に失敗したときのレスポンスボディの期待値を返す
+func validationProblem(fields ...map[string]interface{}) map[string]interface{} {
+	body := problem(http.StatusBadRequest, "validation_failed", "request is invalid")
+	body["errors"] = fields
+	return body
+}
+
+// validationField は、検証に失敗した項目の期待値を返す
+func validationField(field, rule, message string, params ...string) map[string]interface{} {
+	expected := map[string]interface{}{
+		"field":   field,
+		"rule":    rule,
+		"message": message,
+	}
+	if len(params) > 0 {
+		expected["params"] = params
+	}
+	return expected
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Application error",
+			method:         http.MethodGet,
+			err:            myErrors.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"code":"not_found","detail":"not found"}`,
+		},
+		{
+			name:           "Application error with message",
+			method:         http.MethodPost,
+			err:            myErrors.ErrTaskBlocked.WithMessage("task is blocked by unfinished tasks"),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"code":"task_blocked","detail":"task is blocked by unfinished tasks"}`,
+		},
+		{
+			name:           "Wrapped application error",
+			method:         http.MethodGet,
+			err:            fmt.Errorf("get task: %w", myErrors.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden"}`,
+		},
+		{
+			name:           "Application error with cause",
+			method:         http.MethodGet,
+			err:            myErrors.ErrInternal.Wrap(errors.New("connection refused")),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_server_error","detail":"internal server error"}`,
+		},
+		{
+			name:           "Timeout",
+			method:         http.MethodGet,
+			err:            myErrors.ErrTimeout,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"type":"about:blank","title":"Service Unavailable","status":503,"code":"timeout","detail":"request timed out"}`,
+		},
+		{
+			name:   "Validation error",
+			method: http.MethodPost,
+			err: myErrors.NewValidationError([]*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+				{Field: "status", Rule: "oneof", Message: "status must be one of [todo done]", Params: []string{"todo", "done"}},
+			}),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"validation_failed","detail":"request is invalid","errors":[` +
+				`{"field":"title","rule":"required","message":"title is a required field"},` +
+				`{"field":"status","rule":"oneof","message":"status must be one of [todo done]","params":["todo","done"]}]}`,
+		},
+		{
+			name:           "Echo error",
+			method:         http.MethodGet,
+			err:            echo.ErrMethodNotAllowed,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   `{"type":"about:blank","title":"Method Not Allowed","status":405,"code":"method_not_allowed","detail":"Method Not Allowed"}`,
+		},
+		{
+			name:           "Echo server error hides message",
+			method:         http.MethodGet,
+			err:            echo.NewHTTPError(http.StatusBadGateway, "upstream is down"),
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"type":"about:blank","title":"Bad Gateway","status":502,"code":"bad_gateway","detail":"Bad Gateway"}`,
+		},
+		{
+			name:           "Unknown error",
+			method:         http.MethodGet,
+			err:            errors.New("some error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_server_error","detail":"internal server error"}`,
+		},
+		{
+			name:           "HEAD request",
+			method:         http.MethodHead,
+			err:            myErrors.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/tasks", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			HTTPErrorHandler(tc.err, ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, MIME_APPLICATION_PROBLEM_JSON, rec.Header().Get(echo.HeaderContentType))
+			if tc.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPErrorHandler_Committed(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/events", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	HTTPErrorHandler(myErrors.ErrInternal, ctx)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+	myErrors "todo-api/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HEADER_ETAG     = "ETag"
+	HEADER_IF_MATCH = "If-Match"
+)
+
+// setTaskETag は、タスクのバージョンを ETag ヘッダーに設定する。
+func setTaskETag(ctx echo.Context, version uint) {
+	ctx.Response().Header().Set(HEADER_ETAG, `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// parseIfMatch は、If-Match ヘッダーから更新の前提とするバージョンを取り出す。
+// "*" の場合は nil を返す。
+// If-Match は強い比較のため、弱い ETag や解釈できない値はどのバージョンとも一致しないものとして ErrPreconditionFailed を返す。
+func parseIfMatch(ifMatch string) (*uint, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "*" {
+		return nil, nil
+	}
+	if len(ifMatch) < 2 || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return nil, myErrors.ErrPreconditionFailed
+	}
+	v, err := strconv.ParseUint(ifMatch[1:len(ifMatch)-1], 10, 64)
+	if err != nil {
+		return nil, myErrors.ErrPreconditionFailed
+	}
+	version := uint(v)
+	return &version, nil
+}
+
+// ifMatchVersion は、更新系のAPIで If-Match ヘッダーから前提とするバージョンを返す。
+// ヘッダーがない場合、required なら ErrPreconditionRequired を返し、そうでなければ nil (バージョンを確認しない) を返す。
+// 一致しえない値の場合は ErrPreconditionFailed を返す。
+func ifMatchVersion(ctx echo.Context, required bool) (*uint, error) {
+	ifMatch := ctx.Request().Header.Get(HEADER_IF_MATCH)
+	if ifMatch == "" {
+		if !required {
+			return nil, nil
+		}
+		return nil, myErrors.ErrPreconditionRequired.WithMessage("If-Match header is required")
+	}
+	return parseIfMatch(ifMatch)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type LabelController interface {
+	GetLabels(ctx echo.Context) error
+	CreateLabel(ctx echo.Context) error
+	UpdateLabel(ctx echo.Context) error
+	DeleteLabel(ctx echo.Context) error
+}
+
+type labelController struct {
+	validate     *validator.Validate
+	labelUseCase usecase.LabelUseCase
+}
+
+func NewLabelController(validate *validator.Validate, labelUseCase usecase.LabelUseCase) LabelController {
+	return &labelController{
+		validate:     validate,
+		labelUseCase: labelUseCase,
+	}
+}
+
+func (c *labelController) GetLabels(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	labels, err := c.labelUseCase.GetLabels(ctx.Request().Context(), uint(companyId))
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetLabels: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetLabelsResponseBody(labels))
+}
+
+func (c *labelController) CreateLabel(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	requestBody := &request.CreateLabelRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	label, err := c.labelUseCase.CreateLabel(ctx.Request().Context(), request.NewLabelFromCreateLabelRequestBody(uint(companyId), requestBody))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("label name already exists")
+		}
+
+		slog.Info(fmt.Sprintf("error CreateLabel: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create label")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateLabelResponseBody(label))
+}
+
+func (c *labelController) UpdateLabel(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	labelId, err := strconv.ParseUint(ctx.Param("label_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("label_id is bad request")
+	}
+
+	requestBody := &request.UpdateLabelRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	label, err := c.labelUseCase.UpdateLabel(ctx.Request().Context(), request.NewLabelFromUpdateLabelRequestBody(uint(labelId), uint(companyId), requestBody))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("label name already exists")
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateLabel: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateLabelResponseBody(label))
+}
+
+func (c *labelController) DeleteLabel(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	labelId, err := strconv.ParseUint(ctx.Param("label_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("label_id is bad request")
+	}
+
+	err = c.labelUseCase.DeleteLabel(ctx.Request().Context(), uint(companyId), uint(labelId))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteLabel: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLabelController_GetLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockLabelUseCase(ctrl)
+	validate := NewValidator()
+	labelController := NewLabelController(validate, mockUseCase)
+
+	labels := []*model.Label{{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetLabels(gomock.Any(), uint(1)).Return(labels, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetLabelsResponseBody(labels),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetLabels(gomock.Any(), uint(1)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/labels", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := labelController.GetLabels(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestLabelController_CreateLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockLabelUseCase(ctrl)
+	validate := NewValidator()
+	labelController := NewLabelController(validate, mockUseCase)
+
+	label := &model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.CreateLabelRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: &request.CreateLabelRequestBody{Name: "bug", Color: "#ff0000"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateLabel(gomock.Any(), &model.Label{CompanyID: 1, Name: "bug", Color: "#ff0000"}).Return(label, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateLabelResponseBody(label),
+		},
+		{
+			name:           "Invalid color",
+			requestBody:    &request.CreateLabelRequestBody{Name: "bug", Color: "red"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Short hex color",
+			requestBody:    &request.CreateLabelRequestBody{Name: "bug", Color: "#f00"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Name already exists",
+			requestBody: &request.CreateLabelRequestBody{Name: "bug", Color: "#ff0000"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateLabel(gomock.Any(), &model.Label{CompanyID: 1, Name: "bug", Color: "#ff0000"}).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "label name already exists"),
+		},
+		{
+			name:        "Internal server error",
+			requestBody: &request.CreateLabelRequestBody{Name: "bug", Color: "#ff0000"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateLabel(gomock.Any(), &model.Label{CompanyID: 1, Name: "bug", Color: "#ff0000"}).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create label"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/labels", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues("1")
+
+			tc.mockFunc()
+
+			if err := labelController.CreateLabel(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestLabelController_UpdateLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockLabelUseCase(ctrl)
+	validate := NewValidator()
+	labelController := NewLabelController(validate, mockUseCase)
+
+	label := &model.Label{ID: 2, CompanyID: 1, Name: "defect", Color: "#00ff00"}
+
+	testCases := []struct {
+		name           string
+		labelID        string
+		requestBody    *request.UpdateLabelRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			labelID:     "2",
+			requestBody: &request.UpdateLabelRequestBody{Name: "defect", Color: "#00ff00"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateLabel(gomock.Any(), label).Return(label, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateLabelResponseBody(label),
+		},
+		{
+			name:           "Invalid label ID",
+			labelID:        "invalid",
+			requestBody:    &request.UpdateLabelRequestBody{Name: "defect", Color: "#00ff00"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "label_id is bad request"),
+		},
+		{
+			name:        "Not found",
+			labelID:     "2",
+			requestBody: &request.UpdateLabelRequestBody{Name: "defect", Color: "#00ff00"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateLabel(gomock.Any(), label).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:        "Name already exists",
+			labelID:     "2",
+			requestBody: &request.UpdateLabelRequestBody{Name: "defect", Color: "#00ff00"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateLabel(gomock.Any(), label).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "label name already exists"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/companies/1/labels/"+tc.labelID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "label_id")
+			ctx.SetParamValues("1", tc.labelID)
+
+			tc.mockFunc()
+
+			if err := labelController.UpdateLabel(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestLabelController_DeleteLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockLabelUseCase(ctrl)
+	validate := NewValidator()
+	labelController := NewLabelController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		labelID        string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:    "Success",
+			labelID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteLabel(gomock.Any(), uint(1), uint(2)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:    "Not found",
+			labelID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteLabel(gomock.Any(), uint(1), uint(2)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/1/labels/"+tc.labelID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "label_id")
+			ctx.SetParamValues("1", tc.labelID)
+
+			tc.mockFunc()
+
+			if err := labelController.DeleteLabel(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
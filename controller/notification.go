@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// 通知のMAX取得制限数
+	MAX_NOTIFICATION_LIMIT = 100
+
+	// 通知のデフォルトの取得制限数
+	DEFAULT_NOTIFICATION_LIMIT = 20
+
+	// 通知のデフォルトのオフセット値
+	DEFAULT_NOTIFICATION_OFFSET = 0
+)
+
+type NotificationController interface {
+	GetNotifications(ctx echo.Context) error
+	MarkNotificationRead(ctx echo.Context) error
+	MarkAllNotificationsRead(ctx echo.Context) error
+	GetNotificationPreferences(ctx echo.Context) error
+	UpdateNotificationPreferences(ctx echo.Context) error
+}
+
+type notificationController struct {
+	validate            *validator.Validate
+	notificationUseCase usecase.NotificationUseCase
+}
+
+func NewNotificationController(validate *validator.Validate, notificationUseCase usecase.NotificationUseCase) NotificationController {
+	return &notificationController{
+		validate:            validate,
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (c *notificationController) GetNotifications(ctx echo.Context) error {
+	queryParams := &request.GetNotificationsRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_NOTIFICATION_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_NOTIFICATION_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_NOTIFICATION_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_NOTIFICATION_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	page, err := c.notificationUseCase.GetNotifications(ctx.Request().Context(), user.ID, queryParams.Unread, limit, offset)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetNotifications: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetNotificationsResponseBody(page))
+}
+
+func (c *notificationController) MarkNotificationRead(ctx echo.Context) error {
+	notificationId, err := strconv.ParseUint(ctx.Param("notification_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("notification_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	if err := c.notificationUseCase.MarkNotificationRead(ctx.Request().Context(), user.ID, uint(notificationId)); err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error MarkNotificationRead: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *notificationController) MarkAllNotificationsRead(ctx echo.Context) error {
+	user := ctx.Get("user").(*model.User)
+	if err := c.notificationUseCase.MarkAllNotificationsRead(ctx.Request().Context(), user.ID); err != nil {
+		slog.Info(fmt.Sprintf("error MarkAllNotificationsRead: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *notificationController) GetNotificationPreferences(ctx echo.Context) error {
+	user := ctx.Get("user").(*model.User)
+	preferences, err := c.notificationUseCase.GetNotificationPreferences(ctx.Request().Context(), user.ID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetNotificationPreferences: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetNotificationPreferencesResponseBody(preferences))
+}
+
+func (c *notificationController) UpdateNotificationPreferences(ctx echo.Context) error {
+	requestBody := &request.UpdateNotificationPreferencesRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	preferences, err := c.notificationUseCase.UpdateNotificationPreferences(ctx.Request().Context(), user.ID, request.NewNotificationPreferencesFromUpdateNotificationPreferencesRequestBody(user.ID, requestBody))
+	if err != nil {
+		slog.Info(fmt.Sprintf("error UpdateNotificationPreferences: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to update notification preferences")
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetNotificationPreferencesResponseBody(preferences))
+}
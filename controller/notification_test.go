@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todo-api/controller/request"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationController_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockNotificationUseCase(ctrl)
+	validate := NewValidator()
+	notificationController := NewNotificationController(validate, mockUseCase)
+
+	taskId := uint(4)
+	actorId := uint(5)
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	readAt := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	page := &model.NotificationPage{
+		Notifications: []*model.Notification{
+			{ID: 2, UserID: 1, CompanyID: 3, TaskID: &taskId, ActorID: &actorId, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED, Title: "Task", CreatedAt: &createdAt},
+			{ID: 1, UserID: 1, CompanyID: 3, TaskID: &taskId, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Title: "Task", ReadAt: &readAt, CreatedAt: &createdAt},
+		},
+		UnreadCount: 1,
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:  "Success",
+			query: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetNotifications(gomock.Any(), uint(1), false, DEFAULT_NOTIFICATION_LIMIT, DEFAULT_NOTIFICATION_OFFSET).Return(page, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"notifications": []map[string]interface{}{
+					{"id": 2, "type": "task_assigned", "company_id": 3, "task_id": 4, "actor_id": 5, "title": "Task", "read": false, "read_at": nil, "created_at": "2024-01-01T00:00:00Z"},
+					{"id": 1, "type": "task_overdue", "company_id": 3, "task_id": 4, "actor_id": nil, "title": "Task", "read": true, "read_at": "2024-01-02T00:00:00Z", "created_at": "2024-01-01T00:00:00Z"},
+				},
+				"unread_count": 1,
+			},
+		},
+		{
+			name:  "Success - unread only",
+			query: "?unread=true&limit=10&offset=20",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetNotifications(gomock.Any(), uint(1), true, 10, 20).Return(&model.NotificationPage{Notifications: []*model.Notification{}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"notifications": []map[string]interface{}{},
+				"unread_count":  0,
+			},
+		},
+		{
+			name:           "Invalid unread",
+			query:          "?unread=invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "query parameter is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			query:          "?limit=101",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 100"),
+		},
+		{
+			name:  "Internal server error",
+			query: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetNotifications(gomock.Any(), uint(1), false, DEFAULT_NOTIFICATION_LIMIT, DEFAULT_NOTIFICATION_OFFSET).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/notifications"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.Set("user", &model.User{ID: 1})
+
+			tc.mockFunc()
+
+			if err := notificationController.GetNotifications(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotificationController_MarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockNotificationUseCase(ctrl)
+	validate := NewValidator()
+	notificationController := NewNotificationController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		notificationID string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:           "Success",
+			notificationID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().MarkNotificationRead(gomock.Any(), uint(1), uint(2)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid notification ID",
+			notificationID: "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "notification_id is bad request"),
+		},
+		{
+			name:           "Notification not found",
+			notificationID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().MarkNotificationRead(gomock.Any(), uint(1), uint(2)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:           "Internal server error",
+			notificationID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().MarkNotificationRead(gomock.Any(), uint(1), uint(2)).Return(errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/notifications/"+tc.notificationID+"/read", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("notification_id")
+			ctx.SetParamValues(tc.notificationID)
+			ctx.Set("user", &model.User{ID: 1})
+
+			tc.mockFunc()
+
+			if err := notificationController.MarkNotificationRead(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotificationController_UpdateNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockNotificationUseCase(ctrl)
+	validate := NewValidator()
+	notificationController := NewNotificationController(validate, mockUseCase)
+
+	enabled := false
+	preferences := []*model.NotificationPreference{
+		{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED, Enabled: true},
+		{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_STATUS_CHANGED, Enabled: true},
+		{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: false},
+		{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: true},
+		{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Enabled: true},
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.UpdateNotificationPreferencesRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name: "Success",
+			requestBody: &request.UpdateNotificationPreferencesRequestBody{
+				Preferences: []*request.UpdateNotificationPreferencesRequestBodyPreference{
+					{Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: &enabled},
+				},
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateNotificationPreferences(gomock.Any(), uint(1), []*model.NotificationPreference{
+					{UserID: 1, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: false},
+				}).Return(preferences, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"preferences": []map[string]interface{}{
+					{"type": "task_assigned", "enabled": true},
+					{"type": "task_status_changed", "enabled": true},
+					{"type": "task_commented", "enabled": false},
+					{"type": "task_due_soon", "enabled": true},
+					{"type": "task_overdue", "enabled": true},
+				},
+			},
+		},
+		{
+			name: "Unknown type",
+			requestBody: &request.UpdateNotificationPreferencesRequestBody{
+				Preferences: []*request.UpdateNotificationPreferencesRequestBodyPreference{
+					{Type: "unknown", Enabled: &enabled},
+				},
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "Enabled is missing",
+			requestBody: &request.UpdateNotificationPreferencesRequestBody{
+				Preferences: []*request.UpdateNotificationPreferencesRequestBodyPreference{
+					{Type: model.NOTIFICATION_TYPE_TASK_COMMENTED},
+				},
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "Internal server error",
+			requestBody: &request.UpdateNotificationPreferencesRequestBody{
+				Preferences: []*request.UpdateNotificationPreferencesRequestBodyPreference{
+					{Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: &enabled},
+				},
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateNotificationPreferences(gomock.Any(), uint(1), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to update notification preferences"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/notifications/preferences", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.Set("user", &model.User{ID: 1})
+
+			tc.mockFunc()
+
+			if err := notificationController.UpdateNotificationPreferences(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// 接続を維持するためにコメントを送る間隔。プロキシのアイドルタイムアウトより短くする。
+	EVENT_STREAM_KEEPALIVE_INTERVAL = 25 * time.Second
+
+	// 切断された場合にクライアントが再接続するまでの時間 (ミリ秒)
+	EVENT_STREAM_RETRY_MILLISECONDS = 3000
+)
+
+type RealtimeController interface {
+	StreamEvents(ctx echo.Context) error
+	ConnectWebSocket(ctx echo.Context) error
+}
+
+type realtimeController struct {
+	validate        *validator.Validate
+	realtimeUseCase usecase.RealtimeUseCase
+	upgrader        websocket.Upgrader
+}
+
+func NewRealtimeController(validate *validator.Validate, realtimeUseCase usecase.RealtimeUseCase) RealtimeController {
+	return &realtimeController{
+		validate:        validate,
+		realtimeUseCase: realtimeUseCase,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{WEBSOCKET_PROTOCOL},
+			// Cookie ではなくアクセストークンで認証するため、他のオリジンからの接続も許可する
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// StreamEvents は、会社のタスクとコメントの変更を Server-Sent Events で配信する。
+// Last-Event-ID ヘッダが指定された場合は、その ID より後のイベントを再送してから配信を始める。
+// クライアントが切断するか、サーバの停止などで購読が終了するまで応答を返し続ける。
+func (c *realtimeController) StreamEvents(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	var lastEventId *uint64
+	if value := ctx.Request().Header.Get("Last-Event-ID"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return myErrors.ErrBadRequest.WithMessage("Last-Event-ID is bad request")
+		}
+		lastEventId = &id
+	}
+
+	user := ctx.Get("user").(*model.User)
+	subscription, err := c.realtimeUseCase.SubscribeEvents(ctx.Request().Context(), uint(companyId), user.ID, lastEventId)
+	if err != nil {
+		if errors.Is(err, realtime.ErrClosed) {
+			return myErrors.ErrUnavailable.WithMessage("server is shutting down")
+		}
+
+		slog.Info(fmt.Sprintf("error StreamEvents: %v", err))
+		return myErrors.ErrInternal
+	}
+	defer subscription.Close()
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	// nginx などのプロキシでバッファリングされないようにする
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", EVENT_STREAM_RETRY_MILLISECONDS); err != nil {
+		return nil
+	}
+	for _, event := range subscription.Replay {
+		if err := writeEvent(res, event); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	keepalive := time.NewTicker(EVENT_STREAM_KEEPALIVE_INTERVAL)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			// 購読が終了した場合は応答を終え、クライアントに Last-Event-ID で再接続させる
+			if !ok {
+				return nil
+			}
+			// プレゼンスは WebSocket でのみ配信する
+			if event.Transient {
+				continue
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// writeEvent は、イベントを Server-Sent Events の形式で書き込む。Data は改行を含まない JSON とする。
+func writeEvent(res *echo.Response, event *realtime.Event) error {
+	_, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+	"todo-api/realtime"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRealtimeController_StreamEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockRealtimeUseCase(ctrl)
+	realtimeController := NewRealtimeController(NewValidator(), mockUseCase)
+
+	// 購読の終了を再現するため、発行済みのイベントを入れて閉じたチャネルを返す
+	newSubscription := func(replay []*realtime.Event, events []*realtime.Event) *realtime.Subscription {
+		ch := make(chan *realtime.Event, len(events))
+		for _, event := range events {
+			ch <- event
+		}
+		close(ch)
+		return realtime.NewSubscription(replay, ch, func() {})
+	}
+	lastEventId := uint64(10)
+
+	testCases := []struct {
+		name           string
+		lastEventID    string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   string
+		expectedJSON   interface{}
+	}{
+		{
+			name:        "Success",
+			lastEventID: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(newSubscription([]*realtime.Event{}, []*realtime.Event{
+					{ID: 11, CompanyID: 1, Type: realtime.EVENT_TYPE_TASK_CREATED, Data: []byte(`{"task":{"id":3}}`)},
+					{ID: 12, CompanyID: 1, Type: realtime.EVENT_TYPE_PRESENCE_UPDATED, Data: []byte(`{"task_id":3,"user_id":4,"state":"viewing"}`), Transient: true},
+					{ID: 13, CompanyID: 1, Type: realtime.EVENT_TYPE_COMMENT_CREATED, Data: []byte(`{"task_id":3}`)},
+				}), nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 11\nevent: task.created\ndata: {\"task\":{\"id\":3}}\n\n" +
+				"id: 13\nevent: comment.created\ndata: {\"task_id\":3}\n\n",
+		},
+		{
+			name:        "Success - resume from Last-Event-ID",
+			lastEventID: "10",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), &lastEventId).Return(newSubscription([]*realtime.Event{
+					{ID: 11, CompanyID: 1, Type: realtime.EVENT_TYPE_TASK_DELETED, Data: []byte(`{"task":{"id":3}}`)},
+				}, []*realtime.Event{
+					{ID: 12, CompanyID: 1, Type: realtime.EVENT_TYPE_TASK_RESTORED, Data: []byte(`{"task":{"id":3}}`)},
+				}), nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 11\nevent: task.deleted\ndata: {\"task\":{\"id\":3}}\n\n" +
+				"id: 12\nevent: task.restored\ndata: {\"task\":{\"id\":3}}\n\n",
+		},
+		{
+			name:        "Success - reset",
+			lastEventID: "10",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), &lastEventId).Return(newSubscription([]*realtime.Event{
+					{ID: 20, CompanyID: 1, Type: realtime.EVENT_TYPE_STREAM_RESET, Data: []byte(`{}`)},
+				}, []*realtime.Event{}), nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 3000\n\nid: 20\nevent: stream.reset\ndata: {}\n\n",
+		},
+		{
+			name:           "Invalid Last-Event-ID",
+			lastEventID:    "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON:   problem(http.StatusBadRequest, "bad_request", "Last-Event-ID is bad request"),
+		},
+		{
+			name:        "Shutting down",
+			lastEventID: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(nil, realtime.ErrClosed).Times(1)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedJSON:   problem(http.StatusServiceUnavailable, "service_unavailable", "server is shutting down"),
+		},
+		{
+			name:        "Internal server error",
+			lastEventID: "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/events", nil)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues("1")
+			ctx.Set("user", &model.User{ID: 2})
+
+			tc.mockFunc()
+
+			if err := realtimeController.StreamEvents(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+			if tc.expectedJSON != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedJSON)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
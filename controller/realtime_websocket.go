@@ -0,0 +1,378 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/usecase"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// WebSocket のサブプロトコル。クライアントは Sec-WebSocket-Protocol ヘッダで指定する
+	WEBSOCKET_PROTOCOL = "todo-api.v1"
+
+	// クライアントから pong が返らない場合に切断するまでの時間
+	WEBSOCKET_PONG_WAIT = 60 * time.Second
+
+	// ping を送る間隔。WEBSOCKET_PONG_WAIT より短くする
+	WEBSOCKET_PING_INTERVAL = 25 * time.Second
+
+	// 1つのメッセージの書き込みを待つ時間。超えた場合は受信が追いつかないとみなして切断する
+	WEBSOCKET_WRITE_WAIT = 10 * time.Second
+
+	// プレゼンスを通知し直す間隔。後から購読したクライアントは、この間隔で他のユーザの状態を受け取る。
+	// クライアントは、この間隔の2倍の間通知されない状態を left とみなす
+	WEBSOCKET_PRESENCE_REFRESH_INTERVAL = 30 * time.Second
+
+	// クライアントから受け取るメッセージの最大サイズ (バイト)
+	WEBSOCKET_MAX_MESSAGE_SIZE = 4096
+
+	// 送信を待っている応答の件数の上限。超えた場合はクライアントが受信していないとみなして切断する
+	websocketSendBufferSize = 16
+)
+
+// ConnectWebSocket は、会社のタスクとコメントの変更、ユーザのプレゼンスを WebSocket で送受信する。
+// クライアントは次の JSON のメッセージを送る。
+//   - {"type":"subscribe"}: 会社の全てのタスクとコメントのイベントを購読する
+//   - {"type":"subscribe","task_id":12}: タスクのイベントと、タスクに対する他のユーザのプレゼンスを購読する
+//   - {"type":"unsubscribe"} / {"type":"unsubscribe","task_id":12}: 購読を終了する
+//   - {"type":"presence","task_id":12,"state":"viewing"}: 購読しているタスクに対する状態 (viewing, typing, left) を通知する
+//
+// サーバは subscribed, unsubscribed, event, presence, error の type のメッセージを送る。
+// 接続が切断された場合は、通知していた状態を left にする。
+func (c *realtimeController) ConnectWebSocket(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	subscription, err := c.realtimeUseCase.SubscribeEvents(ctx.Request().Context(), uint(companyId), user.ID, nil)
+	if err != nil {
+		if errors.Is(err, realtime.ErrClosed) {
+			return myErrors.ErrUnavailable.WithMessage("server is shutting down")
+		}
+
+		slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	// アップグレードに失敗した場合は、upgrader がエラーの応答を返す
+	conn, err := c.upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		subscription.Close()
+		slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+		return nil
+	}
+
+	session := &webSocketSession{
+		conn:            conn,
+		validate:        c.validate,
+		translator:      requestTranslator(ctx.Request()),
+		realtimeUseCase: c.realtimeUseCase,
+		subscription:    subscription,
+		companyId:       uint(companyId),
+		userId:          user.ID,
+		send:            make(chan *response.RealtimeMessage, websocketSendBufferSize),
+		done:            make(chan struct{}),
+		tasks:           map[uint]string{},
+	}
+	session.run(ctx.Request().Context())
+	return nil
+}
+
+// webSocketSession は、WebSocket の1つの接続。
+// メッセージの受信と送信を別々のゴルーチンで行い、接続へのメッセージの書き込みは送信側のみが行う。
+type webSocketSession struct {
+	conn     *websocket.Conn
+	validate *validator.Validate
+	// translator は、検証エラーのメッセージの言語。接続時の Accept-Language で決める
+	translator      ut.Translator
+	realtimeUseCase usecase.RealtimeUseCase
+	subscription    *realtime.Subscription
+	companyId       uint
+	userId          uint
+	// send は、受信したメッセージに対する応答
+	send chan *response.RealtimeMessage
+	done chan struct{}
+
+	mu sync.Mutex
+	// company は、会社の全てのタスクとコメントのイベントを購読しているかどうか
+	company bool
+	// tasks は、購読しているタスクの ID と、そのタスクに対して最後に通知した状態
+	tasks map[uint]string
+}
+
+// run は、接続が切断されるまでメッセージを送受信する。
+func (s *webSocketSession) run(ctx context.Context) {
+	defer s.subscription.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.writeLoop(ctx)
+	}()
+	s.readLoop(ctx)
+	close(s.done)
+	wg.Wait()
+
+	for taskId, state := range s.tasks {
+		if state != realtime.PRESENCE_STATE_LEFT {
+			s.publishPresence(ctx, taskId, realtime.PRESENCE_STATE_LEFT)
+		}
+	}
+}
+
+// readLoop は、クライアントからメッセージを受け取って処理する。接続が切断されるか、応答を送れなくなった場合に終了する。
+func (s *webSocketSession) readLoop(ctx context.Context) {
+	s.conn.SetReadLimit(WEBSOCKET_MAX_MESSAGE_SIZE)
+	s.conn.SetReadDeadline(time.Now().Add(WEBSOCKET_PONG_WAIT))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(WEBSOCKET_PONG_WAIT))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+				slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+			}
+			return
+		}
+
+		message := &request.RealtimeMessage{}
+		if err := json.Unmarshal(data, message); err != nil {
+			if !s.reply(&response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_ERROR, Error: "message is bad request"}) {
+				return
+			}
+			continue
+		}
+		if err := s.validate.Struct(message); err != nil {
+			if !s.reply(s.validationErrorMessage(message.TaskID, err)) {
+				return
+			}
+			continue
+		}
+		if !s.reply(s.handle(ctx, message)) {
+			return
+		}
+	}
+}
+
+// handle は、クライアントから受け取ったメッセージを処理し、応答を返す。応答がない場合は nil を返す。
+func (s *webSocketSession) handle(ctx context.Context, message *request.RealtimeMessage) *response.RealtimeMessage {
+	switch message.Type {
+	case "subscribe":
+		if message.TaskID == nil {
+			s.mu.Lock()
+			s.company = true
+			s.mu.Unlock()
+			return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_SUBSCRIBED}
+		}
+
+		if _, err := s.realtimeUseCase.GetTask(ctx, s.companyId, *message.TaskID, s.userId); err != nil {
+			return s.errorMessage(*message.TaskID, err)
+		}
+		s.mu.Lock()
+		if _, ok := s.tasks[*message.TaskID]; !ok {
+			s.tasks[*message.TaskID] = realtime.PRESENCE_STATE_LEFT
+		}
+		s.mu.Unlock()
+		return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_SUBSCRIBED, TaskID: message.TaskID}
+	case "unsubscribe":
+		if message.TaskID == nil {
+			s.mu.Lock()
+			s.company = false
+			s.mu.Unlock()
+			return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_UNSUBSCRIBED}
+		}
+
+		s.mu.Lock()
+		state, ok := s.tasks[*message.TaskID]
+		delete(s.tasks, *message.TaskID)
+		s.mu.Unlock()
+		if ok && state != realtime.PRESENCE_STATE_LEFT {
+			s.publishPresence(ctx, *message.TaskID, realtime.PRESENCE_STATE_LEFT)
+		}
+		return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_UNSUBSCRIBED, TaskID: message.TaskID}
+	default:
+		s.mu.Lock()
+		state, ok := s.tasks[*message.TaskID]
+		if ok {
+			s.tasks[*message.TaskID] = message.State
+		}
+		s.mu.Unlock()
+		if !ok {
+			return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_ERROR, TaskID: message.TaskID, Error: "task is not subscribed"}
+		}
+		// 状態が変わらない場合は通知しない。入力中の通知が頻繁に送られても、他のクライアントへの配信は増えない
+		if state == message.State {
+			return nil
+		}
+
+		if err := s.realtimeUseCase.PublishPresence(ctx, s.companyId, *message.TaskID, s.userId, message.State); err != nil {
+			s.mu.Lock()
+			if errors.Is(err, myErrors.ErrNotFound) {
+				delete(s.tasks, *message.TaskID)
+			} else {
+				s.tasks[*message.TaskID] = state
+			}
+			s.mu.Unlock()
+			return s.errorMessage(*message.TaskID, err)
+		}
+		return nil
+	}
+}
+
+// errorMessage は、タスクに関するメッセージを処理できなかった場合の応答を作成する
+func (s *webSocketSession) errorMessage(taskId uint, err error) *response.RealtimeMessage {
+	if errors.Is(err, myErrors.ErrNotFound) {
+		return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_ERROR, TaskID: &taskId, Error: "not found"}
+	}
+
+	slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+	return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_ERROR, TaskID: &taskId, Error: "internal server error"}
+}
+
+// validationErrorMessage は、メッセージの検証に失敗した場合の応答を作成する
+func (s *webSocketSession) validationErrorMessage(taskId *uint, err error) *response.RealtimeMessage {
+	fields, ok := validationFieldErrors(s.translator, err)
+	if !ok {
+		return &response.RealtimeMessage{Type: response.REALTIME_MESSAGE_TYPE_ERROR, TaskID: taskId, Error: "message is bad request"}
+	}
+	return &response.RealtimeMessage{
+		Type:   response.REALTIME_MESSAGE_TYPE_ERROR,
+		TaskID: taskId,
+		Error:  "message is invalid",
+		Errors: response.NewProblemResponseBodyErrors(fields),
+	}
+}
+
+// reply は、応答を送信側に渡す。送信を待っている応答が上限に達している場合は、接続を閉じて false を返す。
+func (s *webSocketSession) reply(message *response.RealtimeMessage) bool {
+	if message == nil {
+		return true
+	}
+
+	select {
+	case s.send <- message:
+		return true
+	default:
+		s.close(websocket.CloseTryAgainLater, "too many pending messages")
+		return false
+	}
+}
+
+// publishPresence は、タスクに対する状態を通知する。切断時などの応答を返せない場合に使う。
+func (s *webSocketSession) publishPresence(ctx context.Context, taskId uint, state string) {
+	if err := s.realtimeUseCase.PublishPresence(ctx, s.companyId, taskId, s.userId, state); err != nil && !errors.Is(err, myErrors.ErrNotFound) {
+		slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+	}
+}
+
+// writeLoop は、応答と購読しているイベントをクライアントに送り、定期的に ping を送る。
+// 受信側が終了するか、書き込みに失敗するか、ブローカーの購読が終了した場合に接続を閉じて終了する。
+func (s *webSocketSession) writeLoop(ctx context.Context) {
+	defer s.conn.Close()
+
+	ping := time.NewTicker(WEBSOCKET_PING_INTERVAL)
+	defer ping.Stop()
+	presence := time.NewTicker(WEBSOCKET_PRESENCE_REFRESH_INTERVAL)
+	defer presence.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case message := <-s.send:
+			if err := s.write(message); err != nil {
+				return
+			}
+		case event, ok := <-s.subscription.Events:
+			// 受信が追いつかない場合やサーバの停止で購読が終了した場合は、クライアントに再接続させる
+			if !ok {
+				s.close(websocket.CloseTryAgainLater, "subscription closed")
+				return
+			}
+			message := s.eventMessage(event)
+			if message == nil {
+				continue
+			}
+			if err := s.write(message); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WEBSOCKET_WRITE_WAIT)); err != nil {
+				return
+			}
+		case <-presence.C:
+			s.mu.Lock()
+			states := map[uint]string{}
+			for taskId, state := range s.tasks {
+				if state != realtime.PRESENCE_STATE_LEFT {
+					states[taskId] = state
+				}
+			}
+			s.mu.Unlock()
+			for taskId, state := range states {
+				s.publishPresence(ctx, taskId, state)
+			}
+		}
+	}
+}
+
+// eventMessage は、購読しているタスクのイベントをメッセージに変換する。送らないイベントの場合は nil を返す。
+func (s *webSocketSession) eventMessage(event *realtime.Event) *response.RealtimeMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, subscribed := s.tasks[event.TaskID]
+	if event.Type == realtime.EVENT_TYPE_PRESENCE_UPDATED {
+		if !subscribed || event.ActorID == s.userId {
+			return nil
+		}
+		message, err := response.NewRealtimePresenceMessage(event)
+		if err != nil {
+			slog.Info(fmt.Sprintf("error ConnectWebSocket: %v", err))
+			return nil
+		}
+		return message
+	}
+
+	if !s.company && !subscribed {
+		return nil
+	}
+	// 閲覧できなくなったタスクのプレゼンスは受け取らない
+	if event.Type == realtime.EVENT_TYPE_TASK_HIDDEN {
+		delete(s.tasks, event.TaskID)
+	}
+	return response.NewRealtimeEventMessage(event)
+}
+
+func (s *webSocketSession) write(message *response.RealtimeMessage) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(WEBSOCKET_WRITE_WAIT)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(message)
+}
+
+// close は、code と reason をクライアントに通知して接続を閉じる。
+func (s *webSocketSession) close(code int, reason string) {
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(WEBSOCKET_WRITE_WAIT))
+	s.conn.Close()
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+	"todo-api/realtime"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// webSocketStep は、WebSocket のテストでの1つの操作。
+// send はクライアントからメッセージを送り、publish は購読にイベントを配信し、expected はサーバから受け取るメッセージを確認する。
+type webSocketStep struct {
+	send     string
+	publish  *realtime.Event
+	expected string
+}
+
+func TestRealtimeController_ConnectWebSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockRealtimeUseCase(ctrl)
+	realtimeController := NewRealtimeController(NewValidator(), mockUseCase)
+
+	var events chan *realtime.Event
+	subscribe := func() {
+		mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).DoAndReturn(func(_ context.Context, companyId, userId uint, lastEventId *uint64) (*realtime.Subscription, error) {
+			return realtime.NewSubscription([]*realtime.Event{}, events, func() {}), nil
+		}).Times(1)
+	}
+
+	testCases := []struct {
+		name              string
+		mockFunc          func()
+		steps             []webSocketStep
+		closeEvents       bool
+		expectedCloseCode int
+	}{
+		{
+			name: "Success - company events",
+			mockFunc: func() {
+				subscribe()
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe"}`},
+				{expected: `{"type":"subscribed"}`},
+				{publish: &realtime.Event{ID: 11, CompanyID: 1, TaskID: 3, Type: realtime.EVENT_TYPE_TASK_CREATED, Data: []byte(`{"task":{"id":3}}`)}},
+				{expected: `{"type":"event","event":{"id":11,"type":"task.created","data":{"task":{"id":3}}}}`},
+				// タスクごとに購読していないため、プレゼンスは受け取らない
+				{publish: &realtime.Event{ID: 12, CompanyID: 1, TaskID: 3, Type: realtime.EVENT_TYPE_PRESENCE_UPDATED, Data: []byte(`{"task_id":3,"user_id":4,"state":"viewing"}`), ActorID: 4, Transient: true}},
+				{publish: &realtime.Event{ID: 13, CompanyID: 1, TaskID: 3, Type: realtime.EVENT_TYPE_COMMENT_CREATED, Data: []byte(`{"task_id":3}`)}},
+				{expected: `{"type":"event","event":{"id":13,"type":"comment.created","data":{"task_id":3}}}`},
+				{send: `{"type":"unsubscribe"}`},
+				{expected: `{"type":"unsubscribed"}`},
+			},
+		},
+		{
+			name: "Success - task presence",
+			mockFunc: func() {
+				subscribe()
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(&model.Task{ID: 12}, nil).Times(1)
+				// 同じ状態を続けて送っても、通知は1回のみ
+				mockUseCase.EXPECT().PublishPresence(gomock.Any(), uint(1), uint(12), uint(2), realtime.PRESENCE_STATE_TYPING).Return(nil).Times(1)
+				// 切断時に left を通知する
+				mockUseCase.EXPECT().PublishPresence(gomock.Any(), uint(1), uint(12), uint(2), realtime.PRESENCE_STATE_LEFT).Return(nil).Times(1)
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe","task_id":12}`},
+				{expected: `{"type":"subscribed","task_id":12}`},
+				{send: `{"type":"presence","task_id":12,"state":"typing"}`},
+				{send: `{"type":"presence","task_id":12,"state":"typing"}`},
+				// 自分のプレゼンスと、購読していないタスクのイベントは受け取らない
+				{publish: &realtime.Event{ID: 11, CompanyID: 1, TaskID: 12, Type: realtime.EVENT_TYPE_PRESENCE_UPDATED, Data: []byte(`{"task_id":12,"user_id":2,"state":"typing"}`), ActorID: 2, Transient: true}},
+				{publish: &realtime.Event{ID: 12, CompanyID: 1, TaskID: 13, Type: realtime.EVENT_TYPE_TASK_UPDATED, Data: []byte(`{"task":{"id":13}}`)}},
+				{publish: &realtime.Event{ID: 13, CompanyID: 1, TaskID: 12, Type: realtime.EVENT_TYPE_PRESENCE_UPDATED, Data: []byte(`{"task_id":12,"user_id":4,"state":"viewing"}`), ActorID: 4, Transient: true}},
+				{expected: `{"type":"presence","task_id":12,"user_id":4,"state":"viewing"}`},
+				{publish: &realtime.Event{ID: 14, CompanyID: 1, TaskID: 12, Type: realtime.EVENT_TYPE_TASK_UPDATED, Data: []byte(`{"task":{"id":12}}`)}},
+				{expected: `{"type":"event","event":{"id":14,"type":"task.updated","data":{"task":{"id":12}}}}`},
+			},
+		},
+		{
+			name: "Success - unsubscribe task",
+			mockFunc: func() {
+				subscribe()
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(&model.Task{ID: 12}, nil).Times(1)
+				mockUseCase.EXPECT().PublishPresence(gomock.Any(), uint(1), uint(12), uint(2), realtime.PRESENCE_STATE_VIEWING).Return(nil).Times(1)
+				mockUseCase.EXPECT().PublishPresence(gomock.Any(), uint(1), uint(12), uint(2), realtime.PRESENCE_STATE_LEFT).Return(nil).Times(1)
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe","task_id":12}`},
+				{expected: `{"type":"subscribed","task_id":12}`},
+				{send: `{"type":"presence","task_id":12,"state":"viewing"}`},
+				{send: `{"type":"unsubscribe","task_id":12}`},
+				{expected: `{"type":"unsubscribed","task_id":12}`},
+				{send: `{"type":"presence","task_id":12,"state":"typing"}`},
+				{expected: `{"type":"error","task_id":12,"error":"task is not subscribed"}`},
+			},
+		},
+		{
+			name: "Task not found",
+			mockFunc: func() {
+				subscribe()
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe","task_id":12}`},
+				{expected: `{"type":"error","task_id":12,"error":"not found"}`},
+				{send: `{"type":"presence","task_id":12,"state":"viewing"}`},
+				{expected: `{"type":"error","task_id":12,"error":"task is not subscribed"}`},
+			},
+		},
+		{
+			name: "Task became invisible",
+			mockFunc: func() {
+				subscribe()
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(&model.Task{ID: 12}, nil).Times(1)
+				mockUseCase.EXPECT().PublishPresence(gomock.Any(), uint(1), uint(12), uint(2), realtime.PRESENCE_STATE_VIEWING).Return(myErrors.ErrNotFound).Times(1)
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe","task_id":12}`},
+				{expected: `{"type":"subscribed","task_id":12}`},
+				{send: `{"type":"presence","task_id":12,"state":"viewing"}`},
+				{expected: `{"type":"error","task_id":12,"error":"not found"}`},
+			},
+		},
+		{
+			name: "Failed to get task",
+			mockFunc: func() {
+				subscribe()
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(nil, errors.New("database error")).Times(1)
+			},
+			steps: []webSocketStep{
+				{send: `{"type":"subscribe","task_id":12}`},
+				{expected: `{"type":"error","task_id":12,"error":"internal server error"}`},
+			},
+		},
+		{
+			name: "Invalid message",
+			mockFunc: func() {
+				subscribe()
+			},
+			steps: []webSocketStep{
+				{send: `not json`},
+				{expected: `{"type":"error","error":"message is bad request"}`},
+				{send: `{"type":"join"}`},
+				{expected: `{"type":"error","error":"message is invalid","errors":[{"field":"type","rule":"oneof","message":"type must be one of [subscribe unsubscribe presence]","params":["subscribe","unsubscribe","presence"]}]}`},
+				{send: `{"type":"presence","state":"viewing"}`},
+				{expected: `{"type":"error","error":"message is invalid","errors":[{"field":"task_id","rule":"required_if","message":"task_id is a required field","params":["Type","presence"]}]}`},
+				{send: `{"type":"presence","task_id":12,"state":"sleeping"}`},
+				{expected: `{"type":"error","task_id":12,"error":"message is invalid","errors":[{"field":"state","rule":"oneof","message":"state must be one of [viewing typing left]","params":["viewing","typing","left"]}]}`},
+			},
+		},
+		{
+			name: "Subscription closed",
+			mockFunc: func() {
+				subscribe()
+			},
+			closeEvents:       true,
+			expectedCloseCode: websocket.CloseTryAgainLater,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events = make(chan *realtime.Event)
+			tc.mockFunc()
+
+			// 接続の終了を待ってから、切断時の呼び出しを確認する
+			done := make(chan struct{})
+			e := echo.New()
+			e.GET("/api/v1/companies/:company_id/ws", func(ctx echo.Context) error {
+				defer close(done)
+				ctx.Set("user", &model.User{ID: 2})
+				return realtimeController.ConnectWebSocket(ctx)
+			})
+			server := httptest.NewServer(e)
+			defer server.Close()
+
+			url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/companies/1/ws"
+			conn, res, err := websocket.DefaultDialer.Dial(url, http.Header{"Sec-WebSocket-Protocol": {WEBSOCKET_PROTOCOL}})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+			assert.Equal(t, WEBSOCKET_PROTOCOL, res.Header.Get("Sec-WebSocket-Protocol"))
+
+			for _, step := range tc.steps {
+				switch {
+				case step.send != "":
+					assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(step.send)))
+				case step.publish != nil:
+					events <- step.publish
+				default:
+					conn.SetReadDeadline(time.Now().Add(time.Second))
+					_, data, err := conn.ReadMessage()
+					if assert.NoError(t, err) {
+						assert.JSONEq(t, step.expected, string(data))
+					}
+				}
+			}
+
+			if tc.closeEvents {
+				close(events)
+				conn.SetReadDeadline(time.Now().Add(time.Second))
+				_, _, err := conn.ReadMessage()
+				assert.True(t, websocket.IsCloseError(err, tc.expectedCloseCode), "unexpected error: %v", err)
+			} else {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("connection was not closed")
+			}
+		})
+	}
+}
+
+func TestRealtimeController_ConnectWebSocket_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockRealtimeUseCase(ctrl)
+	realtimeController := NewRealtimeController(NewValidator(), mockUseCase)
+
+	testCases := []struct {
+		name           string
+		companyId      string
+		mockFunc       func()
+		expectedStatus int
+		expectedJSON   interface{}
+	}{
+		{
+			name:           "Invalid company id",
+			companyId:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "Shutting down",
+			companyId: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(nil, realtime.ErrClosed).Times(1)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedJSON:   problem(http.StatusServiceUnavailable, "service_unavailable", "server is shutting down"),
+		},
+		{
+			name:      "Internal server error",
+			companyId: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "Not a WebSocket request",
+			companyId: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SubscribeEvents(gomock.Any(), uint(1), uint(2), nil).Return(realtime.NewSubscription([]*realtime.Event{}, make(chan *realtime.Event), func() {}), nil).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyId+"/ws", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyId)
+			ctx.Set("user", &model.User{ID: 2})
+
+			tc.mockFunc()
+
+			if err := realtimeController.ConnectWebSocket(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedJSON != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedJSON)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -4,3 +4,7 @@ type LoginRequestBody struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+type RefreshTokenRequestBody struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
@@ -0,0 +1,69 @@
+package request
+
+import "todo-api/model"
+
+type CreateCompanyRequestBody struct {
+	Name                  string  `json:"name" validate:"required,max=255"`
+	DisableTaskTotalCount bool    `json:"disable_task_total_count"`
+	TrashRetentionDays    *uint   `json:"trash_retention_days" validate:"omitempty,min=1,max=3650"`
+	AttachmentQuotaBytes  *int64  `json:"attachment_quota_bytes" validate:"omitempty,min=0"`
+	TimeZone              *string `json:"time_zone" validate:"omitempty,timezone"`
+}
+
+func NewCompanyFromCreateCompanyRequestBody(requestBody *CreateCompanyRequestBody) *model.Company {
+	return &model.Company{
+		Name:                  requestBody.Name,
+		DisableTaskTotalCount: requestBody.DisableTaskTotalCount,
+		TrashRetentionDays:    trashRetentionDays(requestBody.TrashRetentionDays),
+		AttachmentQuotaBytes:  attachmentQuotaBytes(requestBody.AttachmentQuotaBytes),
+		TimeZone:              timeZone(requestBody.TimeZone),
+	}
+}
+
+type UpdateCompanyRequestBody struct {
+	Name                  string  `json:"name" validate:"required,max=255"`
+	DisableTaskTotalCount bool    `json:"disable_task_total_count"`
+	TrashRetentionDays    *uint   `json:"trash_retention_days" validate:"omitempty,min=1,max=3650"`
+	AttachmentQuotaBytes  *int64  `json:"attachment_quota_bytes" validate:"omitempty,min=0"`
+	TimeZone              *string `json:"time_zone" validate:"omitempty,timezone"`
+}
+
+// NewCompanyFromUpdateCompanyRequestBody は、更新する会社を組み立てる。
+// タイムゾーンの指定がない場合は空にし、現在のタイムゾーンを維持する。
+func NewCompanyFromUpdateCompanyRequestBody(id uint, requestBody *UpdateCompanyRequestBody) *model.Company {
+	company := &model.Company{
+		ID:                    id,
+		Name:                  requestBody.Name,
+		DisableTaskTotalCount: requestBody.DisableTaskTotalCount,
+		TrashRetentionDays:    trashRetentionDays(requestBody.TrashRetentionDays),
+		AttachmentQuotaBytes:  attachmentQuotaBytes(requestBody.AttachmentQuotaBytes),
+	}
+	if requestBody.TimeZone != nil {
+		company.TimeZone = *requestBody.TimeZone
+	}
+	return company
+}
+
+// trashRetentionDays は、指定がない場合にデフォルトの保持日数を返す
+func trashRetentionDays(days *uint) uint {
+	if days == nil {
+		return model.DEFAULT_TRASH_RETENTION_DAYS
+	}
+	return *days
+}
+
+// attachmentQuotaBytes は、指定がない場合にデフォルトの添付ファイルの容量の上限を返す
+func attachmentQuotaBytes(bytes *int64) int64 {
+	if bytes == nil {
+		return model.DEFAULT_ATTACHMENT_QUOTA_BYTES
+	}
+	return *bytes
+}
+
+// timeZone は、指定がない場合にデフォルトのタイムゾーンを返す
+func timeZone(name *string) string {
+	if name == nil {
+		return model.DEFAULT_COMPANY_TIME_ZONE
+	}
+	return *name
+}
@@ -0,0 +1,10 @@
+package request
+
+type CreateCompanyUserRequestBody struct {
+	UserID uint   `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"omitempty,oneof=owner manager member viewer"`
+}
+
+type UpdateCompanyUserRequestBody struct {
+	Role string `json:"role" validate:"required,oneof=owner manager member viewer"`
+}
@@ -0,0 +1,30 @@
+package request
+
+import "todo-api/model"
+
+type CreateLabelRequestBody struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"required,hexcolor,len=7"`
+}
+
+func NewLabelFromCreateLabelRequestBody(companyId uint, requestBody *CreateLabelRequestBody) *model.Label {
+	return &model.Label{
+		CompanyID: companyId,
+		Name:      requestBody.Name,
+		Color:     requestBody.Color,
+	}
+}
+
+type UpdateLabelRequestBody struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"required,hexcolor,len=7"`
+}
+
+func NewLabelFromUpdateLabelRequestBody(id, companyId uint, requestBody *UpdateLabelRequestBody) *model.Label {
+	return &model.Label{
+		ID:        id,
+		CompanyID: companyId,
+		Name:      requestBody.Name,
+		Color:     requestBody.Color,
+	}
+}
@@ -0,0 +1,28 @@
+package request
+
+import "todo-api/model"
+
+type GetNotificationsRequestQuery struct {
+	Unread bool `query:"unread"`
+}
+
+type UpdateNotificationPreferencesRequestBody struct {
+	Preferences []*UpdateNotificationPreferencesRequestBodyPreference `json:"preferences" validate:"required,dive"`
+}
+
+type UpdateNotificationPreferencesRequestBodyPreference struct {
+	Type    string `json:"type" validate:"required,oneof=task_assigned task_status_changed task_commented task_due_soon task_overdue"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
+
+func NewNotificationPreferencesFromUpdateNotificationPreferencesRequestBody(userId uint, requestBody *UpdateNotificationPreferencesRequestBody) []*model.NotificationPreference {
+	preferences := []*model.NotificationPreference{}
+	for _, preference := range requestBody.Preferences {
+		preferences = append(preferences, &model.NotificationPreference{
+			UserID:  userId,
+			Type:    preference.Type,
+			Enabled: *preference.Enabled,
+		})
+	}
+	return preferences
+}
@@ -0,0 +1,24 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PatchField は JSON Merge Patch (RFC 7396) のフィールド。
+// キーが存在しない場合は Set が false、null が指定された場合は Null が true となる。
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// UnmarshalJSON はキーが存在する場合のみ呼ばれる
+func (f *PatchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
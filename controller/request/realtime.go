@@ -0,0 +1,9 @@
+package request
+
+// RealtimeMessage は、WebSocket でクライアントから受け取るメッセージ
+// task_id を省略した subscribe と unsubscribe は、会社の全てのタスクとコメントのイベントを対象とする
+type RealtimeMessage struct {
+	Type   string `json:"type" validate:"required,oneof=subscribe unsubscribe presence"`
+	TaskID *uint  `json:"task_id" validate:"required_if=Type presence"`
+	State  string `json:"state" validate:"required_if=Type presence,omitempty,oneof=viewing typing left"`
+}
@@ -1,94 +1,324 @@
 package request
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	myErrors "todo-api/errors"
 	"todo-api/model"
+
+	"github.com/go-playground/validator/v10"
 )
 
+type GetTasksRequestQuery struct {
+	Status       *string    `query:"status" validate:"omitempty,oneof=pending in_progress done"`
+	AssigneeID   *uint      `query:"assignee_id"`
+	CreateUserID *uint      `query:"create_user_id"`
+	Visibility   *string    `query:"visibility" validate:"omitempty,oneof=company private"`
+	DueBefore    *time.Time `query:"due_before"`
+	DueAfter     *time.Time `query:"due_after"`
+	Overdue      bool       `query:"overdue"`
+	Labels       string     `query:"labels"`
+	LabelMatch   string     `query:"label_match" validate:"omitempty,oneof=any all"`
+	Sort         string     `query:"sort"`
+	Cursor       string     `query:"cursor"`
+	TotalCount   *bool      `query:"total_count"`
+}
+
+// NewTaskFilterFromGetTasksRequestQuery は、クエリパラメータからタスクの絞り込み条件を生成する。
+// sort はカンマ区切りで指定し、先頭に - を付けると降順になる。(例: sort=due_date,-created_at)
+// labels はラベル名をカンマ区切りで指定し、label_match=all の場合は全てのラベル、それ以外はいずれかのラベルで絞り込む。
+func NewTaskFilterFromGetTasksRequestQuery(query *GetTasksRequestQuery) (*model.TaskFilter, error) {
+	filter := &model.TaskFilter{
+		Status:       query.Status,
+		AssigneeID:   query.AssigneeID,
+		CreateUserID: query.CreateUserID,
+		Visibility:   query.Visibility,
+		DueBefore:    query.DueBefore,
+		DueAfter:     query.DueAfter,
+		Overdue:      query.Overdue,
+		LabelMatch:   query.LabelMatch,
+	}
+	for _, label := range strings.Split(query.Labels, ",") {
+		label = strings.TrimSpace(label)
+		if label != "" && !slices.Contains(filter.Labels, label) {
+			filter.Labels = append(filter.Labels, label)
+		}
+	}
+
+	if query.Sort == "" {
+		return filter, nil
+	}
+	for _, field := range strings.Split(query.Sort, ",") {
+		field = strings.TrimSpace(field)
+		sort := model.TaskSort{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+		if !model.IsTaskSortField(sort.Field) {
+			return nil, myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("sort field %q is not allowed", sort.Field))
+		}
+		filter.Sort = append(filter.Sort, sort)
+	}
+	return filter, nil
+}
+
+// NewTaskPaginationFromGetTasksRequestQuery は、クエリパラメータからタスクのページング条件を生成する。
+// cursor が指定された場合はキーセットページングとなり、offset は使用しない。
+// total_count=false の場合は総件数を取得しない。
+func NewTaskPaginationFromGetTasksRequestQuery(query *GetTasksRequestQuery, filter *model.TaskFilter, limit, offset int) (*model.TaskPagination, error) {
+	pagination := &model.TaskPagination{
+		Limit:          limit,
+		Offset:         offset,
+		WithTotalCount: query.TotalCount == nil || *query.TotalCount,
+	}
+
+	if query.Cursor != "" {
+		cursor, err := model.DecodeTaskCursor(query.Cursor, filter)
+		if err != nil {
+			return nil, myErrors.ErrBadRequest.WithMessage(err.Error())
+		}
+		pagination.Cursor = cursor
+		pagination.Offset = 0
+	}
+	return pagination, nil
+}
+
+type DeleteTaskRequestQuery struct {
+	SubtaskPolicy string `query:"subtask_policy" validate:"omitempty,oneof=block cascade"`
+}
+
+// NewSubtaskPolicyFromDeleteTaskRequestQuery は、サブタスクの削除方針を返す。指定されない場合は block とする。
+func NewSubtaskPolicyFromDeleteTaskRequestQuery(query *DeleteTaskRequestQuery) string {
+	if query.SubtaskPolicy == "" {
+		return model.SUBTASK_DELETE_POLICY_BLOCK
+	}
+	return query.SubtaskPolicy
+}
+
+// UpdateTaskRequestQuery は、タスクの更新APIのクエリパラメータ。
+// force=true の場合、未完了のブロッカーがあってもタスクを完了にできる。
+type UpdateTaskRequestQuery struct {
+	Force bool `query:"force"`
+}
+
+type SearchTasksRequestQuery struct {
+	Q string `query:"q" validate:"required,max=255"`
+}
+
 type CreateTaskRequestBody struct {
-	Title       string     `json:"title" validate:"required"`
-	Description string     `json:"description" validate:"required"`
-	DueDate     *time.Time `json:"due_date"`
-	AssigneeID  *uint      `json:"assignee_id"`
-	Visibility  string     `json:"visibility" validate:"required,oneof=company private"`
-	Status      string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	Title          string     `json:"title" validate:"required"`
+	Description    string     `json:"description" validate:"required"`
+	DueDate        *time.Time `json:"due_date"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	Visibility     string     `json:"visibility" validate:"required,oneof=company private"`
+	Status         string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	LabelIDs       []uint     `json:"label_ids" validate:"max=20"`
+	RecurrenceRule *string    `json:"recurrence_rule" validate:"omitempty,max=255"`
 }
 
 func NewTaskFromCreateTaskRequestBody(companyId uint, createUserId uint, requestBody *CreateTaskRequestBody) *model.Task {
 	return &model.Task{
-		CompanyID:    companyId,
-		CreateUserId: createUserId,
-		Title:        requestBody.Title,
-		Description:  requestBody.Description,
-		DueDate:      requestBody.DueDate,
-		AssigneeID:   requestBody.AssigneeID,
-		Visibility:   requestBody.Visibility,
-		Status:       requestBody.Status,
+		CompanyID:      companyId,
+		CreateUserId:   createUserId,
+		Title:          requestBody.Title,
+		Description:    requestBody.Description,
+		DueDate:        requestBody.DueDate,
+		AssigneeID:     requestBody.AssigneeID,
+		ParentTaskID:   requestBody.ParentTaskID,
+		Visibility:     requestBody.Visibility,
+		Status:         requestBody.Status,
+		Labels:         newLabelsFromIds(requestBody.LabelIDs),
+		RecurrenceRule: requestBody.RecurrenceRule,
 	}
 }
 
 type CreateTaskByAdminRequestBody struct {
-	CompanyId   uint       `json:"company_id" validate:"required"`
-	Title       string     `json:"title" validate:"required"`
-	Description string     `json:"description" validate:"required"`
-	DueDate     *time.Time `json:"due_date"`
-	AssigneeID  *uint      `json:"assignee_id"`
-	Visibility  string     `json:"visibility" validate:"required,oneof=company private"`
-	Status      string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	CompanyId      uint       `json:"company_id" validate:"required"`
+	Title          string     `json:"title" validate:"required"`
+	Description    string     `json:"description" validate:"required"`
+	DueDate        *time.Time `json:"due_date"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	Visibility     string     `json:"visibility" validate:"required,oneof=company private"`
+	Status         string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	LabelIDs       []uint     `json:"label_ids" validate:"max=20"`
+	RecurrenceRule *string    `json:"recurrence_rule" validate:"omitempty,max=255"`
 }
 
 func NewTaskFromCreateTaskByAdminRequestBody(createUserId uint, requestBody *CreateTaskByAdminRequestBody) *model.Task {
 	return &model.Task{
-		CompanyID:    requestBody.CompanyId,
-		CreateUserId: createUserId,
-		Title:        requestBody.Title,
-		Description:  requestBody.Description,
-		DueDate:      requestBody.DueDate,
-		AssigneeID:   requestBody.AssigneeID,
-		Visibility:   requestBody.Visibility,
-		Status:       requestBody.Status,
+		CompanyID:      requestBody.CompanyId,
+		CreateUserId:   createUserId,
+		Title:          requestBody.Title,
+		Description:    requestBody.Description,
+		DueDate:        requestBody.DueDate,
+		AssigneeID:     requestBody.AssigneeID,
+		ParentTaskID:   requestBody.ParentTaskID,
+		Visibility:     requestBody.Visibility,
+		Status:         requestBody.Status,
+		Labels:         newLabelsFromIds(requestBody.LabelIDs),
+		RecurrenceRule: requestBody.RecurrenceRule,
 	}
 }
 
 type UpdateTaskByAdminRequestBody struct {
-	Title       string     `json:"title" validate:"required"`
-	Description string     `json:"description" validate:"required"`
-	DueDate     *time.Time `json:"due_date"`
-	AssigneeID  *uint      `json:"assignee_id"`
-	Visibility  string     `json:"visibility" validate:"required,oneof=company private"`
-	Status      string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	Title          string     `json:"title" validate:"required"`
+	Description    string     `json:"description" validate:"required"`
+	DueDate        *time.Time `json:"due_date"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	Visibility     string     `json:"visibility" validate:"required,oneof=company private"`
+	Status         string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	LabelIDs       []uint     `json:"label_ids" validate:"max=20"`
+	RecurrenceRule *string    `json:"recurrence_rule" validate:"omitempty,max=255"`
 }
 
 func NewTaskFromUpdateTaskByAdminRequestBody(id uint, requestBody *UpdateTaskByAdminRequestBody) *model.Task {
 	return &model.Task{
-		ID:          id,
-		Title:       requestBody.Title,
-		Description: requestBody.Description,
-		DueDate:     requestBody.DueDate,
-		AssigneeID:  requestBody.AssigneeID,
-		Visibility:  requestBody.Visibility,
-		Status:      requestBody.Status,
+		ID:             id,
+		Title:          requestBody.Title,
+		Description:    requestBody.Description,
+		DueDate:        requestBody.DueDate,
+		AssigneeID:     requestBody.AssigneeID,
+		ParentTaskID:   requestBody.ParentTaskID,
+		Visibility:     requestBody.Visibility,
+		Status:         requestBody.Status,
+		Labels:         newLabelsFromIds(requestBody.LabelIDs),
+		RecurrenceRule: requestBody.RecurrenceRule,
 	}
 }
 
 type UpdateTaskRequestBody struct {
-	Title       string     `json:"title" validate:"required"`
-	Description string     `json:"description" validate:"required"`
-	DueDate     *time.Time `json:"due_date"`
-	AssigneeID  *uint      `json:"assignee_id"`
-	Visibility  string     `json:"visibility" validate:"required,oneof=company private"`
-	Status      string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	Title          string     `json:"title" validate:"required"`
+	Description    string     `json:"description" validate:"required"`
+	DueDate        *time.Time `json:"due_date"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	Visibility     string     `json:"visibility" validate:"required,oneof=company private"`
+	Status         string     `json:"status" validate:"required,oneof=pending in_progress done"`
+	LabelIDs       []uint     `json:"label_ids" validate:"max=20"`
+	RecurrenceRule *string    `json:"recurrence_rule" validate:"omitempty,max=255"`
 }
 
 func NewTaskFromUpdateTaskRequestBody(id, companyId uint, requestBody *UpdateTaskRequestBody) *model.Task {
 	return &model.Task{
-		ID:          id,
-		CompanyID:   companyId,
-		Title:       requestBody.Title,
-		Description: requestBody.Description,
-		DueDate:     requestBody.DueDate,
-		AssigneeID:  requestBody.AssigneeID,
-		Visibility:  requestBody.Visibility,
-		Status:      requestBody.Status,
+		ID:             id,
+		CompanyID:      companyId,
+		Title:          requestBody.Title,
+		Description:    requestBody.Description,
+		DueDate:        requestBody.DueDate,
+		AssigneeID:     requestBody.AssigneeID,
+		ParentTaskID:   requestBody.ParentTaskID,
+		Visibility:     requestBody.Visibility,
+		Status:         requestBody.Status,
+		Labels:         newLabelsFromIds(requestBody.LabelIDs),
+		RecurrenceRule: requestBody.RecurrenceRule,
+	}
+}
+
+// PatchTaskRequestBody はタスクの部分更新(JSON Merge Patch)のリクエストボディ
+type PatchTaskRequestBody struct {
+	Title          PatchField[string]    `json:"title"`
+	Description    PatchField[string]    `json:"description"`
+	DueDate        PatchField[time.Time] `json:"due_date"`
+	AssigneeID     PatchField[uint]      `json:"assignee_id"`
+	ParentTaskID   PatchField[uint]      `json:"parent_task_id"`
+	Visibility     PatchField[string]    `json:"visibility"`
+	Status         PatchField[string]    `json:"status"`
+	LabelIDs       PatchField[[]uint]    `json:"label_ids"`
+	RecurrenceRule PatchField[string]    `json:"recurrence_rule"`
+}
+
+// patchTaskRequestValues は、指定されたフィールドのみを検証するための構造体
+type patchTaskRequestValues struct {
+	Title          *string `json:"title" validate:"omitnil,min=1"`
+	Description    *string `json:"description" validate:"omitnil,min=1"`
+	Visibility     *string `json:"visibility" validate:"omitnil,oneof=company private"`
+	Status         *string `json:"status" validate:"omitnil,oneof=pending in_progress done"`
+	LabelIDs       []uint  `json:"label_ids" validate:"max=20"`
+	RecurrenceRule *string `json:"recurrence_rule" validate:"omitnil,max=255"`
+}
+
+// Validate は、指定されたフィールドごとに検証を行う。
+// null を指定できるのは due_date、assignee_id、parent_task_id、recurrence_rule のみ。
+func (b *PatchTaskRequestBody) Validate(validate *validator.Validate) error {
+	values := &patchTaskRequestValues{}
+	if b.LabelIDs.Set {
+		if b.LabelIDs.Null {
+			return fmt.Errorf("label_ids cannot be null")
+		}
+		values.LabelIDs = b.LabelIDs.Value
+	}
+	if b.RecurrenceRule.Set && !b.RecurrenceRule.Null {
+		values.RecurrenceRule = &b.RecurrenceRule.Value
+	}
+	for _, field := range []struct {
+		name  string
+		patch *PatchField[string]
+		value **string
+	}{
+		{"title", &b.Title, &values.Title},
+		{"description", &b.Description, &values.Description},
+		{"visibility", &b.Visibility, &values.Visibility},
+		{"status", &b.Status, &values.Status},
+	} {
+		if !field.patch.Set {
+			continue
+		}
+		if field.patch.Null {
+			return fmt.Errorf("%s cannot be null", field.name)
+		}
+		*field.value = &field.patch.Value
+	}
+	return validate.Struct(values)
+}
+
+func NewTaskPatchFromPatchTaskRequestBody(requestBody *PatchTaskRequestBody) *model.TaskPatch {
+	patch := &model.TaskPatch{
+		Title:       model.Optional[string]{Set: requestBody.Title.Set, Value: requestBody.Title.Value},
+		Description: model.Optional[string]{Set: requestBody.Description.Set, Value: requestBody.Description.Value},
+		Visibility:  model.Optional[string]{Set: requestBody.Visibility.Set, Value: requestBody.Visibility.Value},
+		Status:      model.Optional[string]{Set: requestBody.Status.Set, Value: requestBody.Status.Value},
+	}
+	if requestBody.DueDate.Set {
+		patch.DueDate.Set = true
+		if !requestBody.DueDate.Null {
+			patch.DueDate.Value = &requestBody.DueDate.Value
+		}
+	}
+	if requestBody.AssigneeID.Set {
+		patch.AssigneeID.Set = true
+		if !requestBody.AssigneeID.Null {
+			patch.AssigneeID.Value = &requestBody.AssigneeID.Value
+		}
+	}
+	if requestBody.ParentTaskID.Set {
+		patch.ParentTaskID.Set = true
+		if !requestBody.ParentTaskID.Null {
+			patch.ParentTaskID.Value = &requestBody.ParentTaskID.Value
+		}
+	}
+	if requestBody.RecurrenceRule.Set {
+		patch.RecurrenceRule.Set = true
+		if !requestBody.RecurrenceRule.Null {
+			patch.RecurrenceRule.Value = &requestBody.RecurrenceRule.Value
+		}
+	}
+	if requestBody.LabelIDs.Set {
+		patch.Labels.Set = true
+		patch.Labels.Value = newLabelsFromIds(requestBody.LabelIDs.Value)
+	}
+	return patch
+}
+
+// newLabelsFromIds は、ID のみを持つラベルを生成する。ids が nil の場合は nil を返す。
+func newLabelsFromIds(ids []uint) []*model.Label {
+	if ids == nil {
+		return nil
+	}
+	labels := []*model.Label{}
+	for _, id := range ids {
+		labels = append(labels, &model.Label{ID: id})
 	}
+	return labels
 }
@@ -0,0 +1,10 @@
+package request
+
+type CreateTaskChecklistItemRequestBody struct {
+	Title string `json:"title" validate:"required,max=255"`
+}
+
+type UpdateTaskChecklistItemRequestBody struct {
+	Title string `json:"title" validate:"required,max=255"`
+	Done  bool   `json:"done"`
+}
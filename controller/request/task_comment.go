@@ -0,0 +1,9 @@
+package request
+
+type CreateTaskCommentRequestBody struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type UpdateTaskCommentRequestBody struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type CreateTaskDependencyRequestBody struct {
+	BlockedByTaskID uint `json:"blocked_by_task_id" validate:"required"`
+}
@@ -1,9 +1,17 @@
 package request
 
+// CreateUserRequestBody は、誰でも行えるユーザ登録のリクエスト。ロールと所属する会社は指定できず、常にどの会社にも所属しない一般ユーザとする
 type CreateUserRequestBody struct {
+	Username string `json:"username" validate:"required"`
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// CreateUserByAdminRequestBody は、システムオペレーターによるユーザ登録のリクエスト
+type CreateUserByAdminRequestBody struct {
 	Username   string `json:"username" validate:"required"`
 	Email      string `json:"email" validate:"required"`
 	Password   string `json:"password" validate:"required"`
-	Role       string `json:"role" validate:"required,oneof=admin user"`
+	Role       string `json:"role" validate:"required,oneof=system_operator user"`
 	CompanyIds []uint `json:"company_ids" validate:"required,min=1,dive,gt=0"`
 }
@@ -0,0 +1,40 @@
+package request
+
+import "todo-api/model"
+
+type CreateWebhookRequestBody struct {
+	URL        string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,unique,dive,oneof=task.created task.updated task.deleted task.restored"`
+	// 省略した場合は有効にする
+	Active *bool `json:"active"`
+}
+
+func NewWebhookFromCreateWebhookRequestBody(companyId uint, requestBody *CreateWebhookRequestBody) *model.Webhook {
+	return &model.Webhook{
+		CompanyID:  companyId,
+		URL:        requestBody.URL,
+		Secret:     requestBody.Secret,
+		EventTypes: requestBody.EventTypes,
+		Active:     requestBody.Active == nil || *requestBody.Active,
+	}
+}
+
+type UpdateWebhookRequestBody struct {
+	URL string `json:"url" validate:"required,http_url,max=2048"`
+	// 省略した場合は変更しない
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,unique,dive,oneof=task.created task.updated task.deleted task.restored"`
+	Active     *bool    `json:"active" validate:"required"`
+}
+
+func NewWebhookFromUpdateWebhookRequestBody(id, companyId uint, requestBody *UpdateWebhookRequestBody) *model.Webhook {
+	return &model.Webhook{
+		ID:         id,
+		CompanyID:  companyId,
+		URL:        requestBody.URL,
+		Secret:     requestBody.Secret,
+		EventTypes: requestBody.EventTypes,
+		Active:     *requestBody.Active,
+	}
+}
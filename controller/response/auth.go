@@ -1,11 +1,34 @@
 package response
 
+import (
+	"time"
+	"todo-api/usecase"
+)
+
 type LoginResponseBody struct {
-	Token string `json:"token"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
 
-func NewLoginResponseBody(token string) *LoginResponseBody {
+func NewLoginResponseBody(authToken *usecase.AuthToken) *LoginResponseBody {
 	return &LoginResponseBody{
-		Token: token,
+		Token:        authToken.AccessToken,
+		RefreshToken: authToken.RefreshToken,
+		ExpiresAt:    authToken.ExpiresAt,
+	}
+}
+
+type RefreshTokenResponseBody struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func NewRefreshTokenResponseBody(authToken *usecase.AuthToken) *RefreshTokenResponseBody {
+	return &RefreshTokenResponseBody{
+		Token:        authToken.AccessToken,
+		RefreshToken: authToken.RefreshToken,
+		ExpiresAt:    authToken.ExpiresAt,
 	}
 }
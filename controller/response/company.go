@@ -0,0 +1,105 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetCompaniesResponseBody は会社一覧取得APIのレスポンスボディ
+type GetCompaniesResponseBody struct {
+	Companies []*GetCompaniesResponseBodyCompany `json:"companies"`
+}
+
+type GetCompaniesResponseBodyCompany struct {
+	ID                    uint       `json:"id"`
+	Name                  string     `json:"name"`
+	DisableTaskTotalCount bool       `json:"disable_task_total_count"`
+	TrashRetentionDays    uint       `json:"trash_retention_days"`
+	AttachmentQuotaBytes  int64      `json:"attachment_quota_bytes"`
+	TimeZone              string     `json:"time_zone"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+func NewGetCompaniesResponseBody(companies []*model.Company) *GetCompaniesResponseBody {
+	resCompanies := []*GetCompaniesResponseBodyCompany{}
+
+	for _, company := range companies {
+		resCompanies = append(resCompanies, &GetCompaniesResponseBodyCompany{
+			ID:                    company.ID,
+			Name:                  company.Name,
+			DisableTaskTotalCount: company.DisableTaskTotalCount,
+			TrashRetentionDays:    company.TrashRetentionDays,
+			AttachmentQuotaBytes:  company.AttachmentQuotaBytes,
+			TimeZone:              company.TimeZone,
+			CreatedAt:             company.CreatedAt,
+			UpdatedAt:             company.UpdatedAt,
+		})
+	}
+
+	return &GetCompaniesResponseBody{
+		Companies: resCompanies,
+	}
+}
+
+// CreateCompanyResponseBody は会社作成APIのレスポンスボディ
+type CreateCompanyResponseBody struct {
+	Company *CreateCompanyResponseBodyCompany `json:"company"`
+}
+
+type CreateCompanyResponseBodyCompany struct {
+	ID                    uint       `json:"id"`
+	Name                  string     `json:"name"`
+	DisableTaskTotalCount bool       `json:"disable_task_total_count"`
+	TrashRetentionDays    uint       `json:"trash_retention_days"`
+	AttachmentQuotaBytes  int64      `json:"attachment_quota_bytes"`
+	TimeZone              string     `json:"time_zone"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+func NewCreateCompanyResponseBody(company *model.Company) *CreateCompanyResponseBody {
+	return &CreateCompanyResponseBody{
+		Company: &CreateCompanyResponseBodyCompany{
+			ID:                    company.ID,
+			Name:                  company.Name,
+			DisableTaskTotalCount: company.DisableTaskTotalCount,
+			TrashRetentionDays:    company.TrashRetentionDays,
+			AttachmentQuotaBytes:  company.AttachmentQuotaBytes,
+			TimeZone:              company.TimeZone,
+			CreatedAt:             company.CreatedAt,
+			UpdatedAt:             company.UpdatedAt,
+		},
+	}
+}
+
+// UpdateCompanyResponseBody は会社更新APIのレスポンスボディ
+type UpdateCompanyResponseBody struct {
+	Company *UpdateCompanyResponseBodyCompany `json:"company"`
+}
+
+type UpdateCompanyResponseBodyCompany struct {
+	ID                    uint       `json:"id"`
+	Name                  string     `json:"name"`
+	DisableTaskTotalCount bool       `json:"disable_task_total_count"`
+	TrashRetentionDays    uint       `json:"trash_retention_days"`
+	AttachmentQuotaBytes  int64      `json:"attachment_quota_bytes"`
+	TimeZone              string     `json:"time_zone"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+func NewUpdateCompanyResponseBody(company *model.Company) *UpdateCompanyResponseBody {
+	return &UpdateCompanyResponseBody{
+		Company: &UpdateCompanyResponseBodyCompany{
+			ID:                    company.ID,
+			Name:                  company.Name,
+			DisableTaskTotalCount: company.DisableTaskTotalCount,
+			TrashRetentionDays:    company.TrashRetentionDays,
+			AttachmentQuotaBytes:  company.AttachmentQuotaBytes,
+			TimeZone:              company.TimeZone,
+			CreatedAt:             company.CreatedAt,
+			UpdatedAt:             company.UpdatedAt,
+		},
+	}
+}
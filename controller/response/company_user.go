@@ -0,0 +1,92 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetCompanyUsersResponseBody はメンバー一覧取得APIのレスポンスボディ
+type GetCompanyUsersResponseBody struct {
+	Members []*GetCompanyUsersResponseBodyMember `json:"members"`
+}
+
+type GetCompanyUsersResponseBodyMember struct {
+	UserID    uint       `json:"user_id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func NewGetCompanyUsersResponseBody(companyUsers []*model.CompanyUser) *GetCompanyUsersResponseBody {
+	resMembers := []*GetCompanyUsersResponseBodyMember{}
+
+	for _, companyUser := range companyUsers {
+		member := &GetCompanyUsersResponseBodyMember{
+			UserID:    companyUser.UserID,
+			Role:      companyUser.Role,
+			CreatedAt: companyUser.CreatedAt,
+		}
+		if companyUser.User != nil {
+			member.Username = companyUser.User.Username
+			member.Email = companyUser.User.Email
+		}
+		resMembers = append(resMembers, member)
+	}
+
+	return &GetCompanyUsersResponseBody{
+		Members: resMembers,
+	}
+}
+
+// CreateCompanyUserResponseBody はメンバー追加APIのレスポンスボディ
+type CreateCompanyUserResponseBody struct {
+	Member *CreateCompanyUserResponseBodyMember `json:"member"`
+}
+
+type CreateCompanyUserResponseBodyMember struct {
+	UserID    uint       `json:"user_id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func NewCreateCompanyUserResponseBody(companyUser *model.CompanyUser) *CreateCompanyUserResponseBody {
+	member := &CreateCompanyUserResponseBodyMember{
+		UserID:    companyUser.UserID,
+		Role:      companyUser.Role,
+		CreatedAt: companyUser.CreatedAt,
+	}
+	if companyUser.User != nil {
+		member.Username = companyUser.User.Username
+		member.Email = companyUser.User.Email
+	}
+
+	return &CreateCompanyUserResponseBody{
+		Member: member,
+	}
+}
+
+// UpdateCompanyUserResponseBody はメンバーのロール変更APIのレスポンスボディ
+type UpdateCompanyUserResponseBody struct {
+	Member *UpdateCompanyUserResponseBodyMember `json:"member"`
+}
+
+type UpdateCompanyUserResponseBodyMember struct {
+	UserID    uint       `json:"user_id"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewUpdateCompanyUserResponseBody(companyUser *model.CompanyUser) *UpdateCompanyUserResponseBody {
+	return &UpdateCompanyUserResponseBody{
+		Member: &UpdateCompanyUserResponseBodyMember{
+			UserID:    companyUser.UserID,
+			Role:      companyUser.Role,
+			CreatedAt: companyUser.CreatedAt,
+			UpdatedAt: companyUser.UpdatedAt,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetLabelsResponseBody はラベル一覧取得APIのレスポンスボディ
+type GetLabelsResponseBody struct {
+	Labels []*GetLabelsResponseBodyLabel `json:"labels"`
+}
+
+type GetLabelsResponseBodyLabel struct {
+	ID        uint       `json:"id"`
+	CompanyID uint       `json:"company_id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewGetLabelsResponseBody(labels []*model.Label) *GetLabelsResponseBody {
+	resLabels := []*GetLabelsResponseBodyLabel{}
+
+	for _, label := range labels {
+		resLabels = append(resLabels, &GetLabelsResponseBodyLabel{
+			ID:        label.ID,
+			CompanyID: label.CompanyID,
+			Name:      label.Name,
+			Color:     label.Color,
+			CreatedAt: label.CreatedAt,
+			UpdatedAt: label.UpdatedAt,
+		})
+	}
+
+	return &GetLabelsResponseBody{
+		Labels: resLabels,
+	}
+}
+
+// CreateLabelResponseBody はラベル作成APIのレスポンスボディ
+type CreateLabelResponseBody struct {
+	Label *CreateLabelResponseBodyLabel `json:"label"`
+}
+
+type CreateLabelResponseBodyLabel struct {
+	ID        uint       `json:"id"`
+	CompanyID uint       `json:"company_id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewCreateLabelResponseBody(label *model.Label) *CreateLabelResponseBody {
+	return &CreateLabelResponseBody{
+		Label: &CreateLabelResponseBodyLabel{
+			ID:        label.ID,
+			CompanyID: label.CompanyID,
+			Name:      label.Name,
+			Color:     label.Color,
+			CreatedAt: label.CreatedAt,
+			UpdatedAt: label.UpdatedAt,
+		},
+	}
+}
+
+// UpdateLabelResponseBody はラベル更新APIのレスポンスボディ
+type UpdateLabelResponseBody struct {
+	Label *UpdateLabelResponseBodyLabel `json:"label"`
+}
+
+type UpdateLabelResponseBodyLabel struct {
+	ID        uint       `json:"id"`
+	CompanyID uint       `json:"company_id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewUpdateLabelResponseBody(label *model.Label) *UpdateLabelResponseBody {
+	return &UpdateLabelResponseBody{
+		Label: &UpdateLabelResponseBodyLabel{
+			ID:        label.ID,
+			CompanyID: label.CompanyID,
+			Name:      label.Name,
+			Color:     label.Color,
+			CreatedAt: label.CreatedAt,
+			UpdatedAt: label.UpdatedAt,
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetNotificationsResponseBody は通知一覧取得APIのレスポンスボディ
+type GetNotificationsResponseBody struct {
+	Notifications []*GetNotificationsResponseBodyNotification `json:"notifications"`
+	UnreadCount   int64                                       `json:"unread_count"`
+}
+
+type GetNotificationsResponseBodyNotification struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	CompanyID uint       `json:"company_id"`
+	TaskID    *uint      `json:"task_id"`
+	ActorID   *uint      `json:"actor_id"`
+	Title     string     `json:"title"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func NewGetNotificationsResponseBody(page *model.NotificationPage) *GetNotificationsResponseBody {
+	resNotifications := []*GetNotificationsResponseBodyNotification{}
+
+	for _, notification := range page.Notifications {
+		resNotifications = append(resNotifications, &GetNotificationsResponseBodyNotification{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			CompanyID: notification.CompanyID,
+			TaskID:    notification.TaskID,
+			ActorID:   notification.ActorID,
+			Title:     notification.Title,
+			Read:      notification.ReadAt != nil,
+			ReadAt:    notification.ReadAt,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	return &GetNotificationsResponseBody{
+		Notifications: resNotifications,
+		UnreadCount:   page.UnreadCount,
+	}
+}
+
+// GetNotificationPreferencesResponseBody は通知の設定取得APIと更新APIのレスポンスボディ
+type GetNotificationPreferencesResponseBody struct {
+	Preferences []*GetNotificationPreferencesResponseBodyPreference `json:"preferences"`
+}
+
+type GetNotificationPreferencesResponseBodyPreference struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+func NewGetNotificationPreferencesResponseBody(preferences []*model.NotificationPreference) *GetNotificationPreferencesResponseBody {
+	resPreferences := []*GetNotificationPreferencesResponseBodyPreference{}
+
+	for _, preference := range preferences {
+		resPreferences = append(resPreferences, &GetNotificationPreferencesResponseBodyPreference{
+			Type:    preference.Type,
+			Enabled: preference.Enabled,
+		})
+	}
+
+	return &GetNotificationPreferencesResponseBody{
+		Preferences: resPreferences,
+	}
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"net/http"
+	myErrors "todo-api/errors"
+)
+
+// ProblemResponseBody は、エラーのレスポンスボディ (RFC 7807)
+// Code は機械可読なエラーのコード、Errors は入力値の検証に失敗した項目
+type ProblemResponseBody struct {
+	Type   string                      `json:"type"`
+	Title  string                      `json:"title"`
+	Status int                         `json:"status"`
+	Detail string                      `json:"detail,omitempty"`
+	Code   string                      `json:"code"`
+	Errors []*ProblemResponseBodyError `json:"errors,omitempty"`
+}
+
+type ProblemResponseBodyError struct {
+	Field   string   `json:"field"`
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+	Params  []string `json:"params,omitempty"`
+}
+
+func NewProblemResponseBody(status int, code, detail string, fields []*myErrors.FieldError) *ProblemResponseBody {
+	return &ProblemResponseBody{
+		// エラーの種類は code で区別するため、type は固有の URI を持たない about:blank とする
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: NewProblemResponseBodyErrors(fields),
+	}
+}
+
+// NewProblemResponseBodyErrors は、入力値の検証に失敗した項目のレスポンスを作成する
+func NewProblemResponseBodyErrors(fields []*myErrors.FieldError) []*ProblemResponseBodyError {
+	var resErrors []*ProblemResponseBodyError
+	for _, field := range fields {
+		resErrors = append(resErrors, &ProblemResponseBodyError{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+			Params:  field.Params,
+		})
+	}
+	return resErrors
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"encoding/json"
+	"todo-api/realtime"
+)
+
+const (
+	// 購読を開始した
+	REALTIME_MESSAGE_TYPE_SUBSCRIBED = "subscribed"
+	// 購読を終了した
+	REALTIME_MESSAGE_TYPE_UNSUBSCRIBED = "unsubscribed"
+	// タスクまたはコメントが変更された
+	REALTIME_MESSAGE_TYPE_EVENT = "event"
+	// 他のユーザのタスクに対する状態が変わった
+	REALTIME_MESSAGE_TYPE_PRESENCE = "presence"
+	// クライアントから受け取ったメッセージを処理できなかった
+	REALTIME_MESSAGE_TYPE_ERROR = "error"
+)
+
+// RealtimeMessage は、WebSocket でクライアントに送るメッセージ
+type RealtimeMessage struct {
+	Type   string                `json:"type"`
+	TaskID *uint                 `json:"task_id,omitempty"`
+	UserID *uint                 `json:"user_id,omitempty"`
+	State  string                `json:"state,omitempty"`
+	Event  *RealtimeMessageEvent `json:"event,omitempty"`
+	Error  string                `json:"error,omitempty"`
+	// Errors は、メッセージの検証に失敗した項目
+	Errors []*ProblemResponseBodyError `json:"errors,omitempty"`
+}
+
+type RealtimeMessageEvent struct {
+	ID   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NewRealtimeEventMessage は、タスクまたはコメントのイベントを送るメッセージを作成する
+func NewRealtimeEventMessage(event *realtime.Event) *RealtimeMessage {
+	return &RealtimeMessage{
+		Type: REALTIME_MESSAGE_TYPE_EVENT,
+		Event: &RealtimeMessageEvent{
+			ID:   event.ID,
+			Type: event.Type,
+			Data: json.RawMessage(event.Data),
+		},
+	}
+}
+
+// NewRealtimePresenceMessage は、プレゼンスのイベントを送るメッセージを作成する
+func NewRealtimePresenceMessage(event *realtime.Event) (*RealtimeMessage, error) {
+	var presence struct {
+		TaskID uint   `json:"task_id"`
+		UserID uint   `json:"user_id"`
+		State  string `json:"state"`
+	}
+	if err := json.Unmarshal(event.Data, &presence); err != nil {
+		return nil, err
+	}
+	return &RealtimeMessage{
+		Type:   REALTIME_MESSAGE_TYPE_PRESENCE,
+		TaskID: &presence.TaskID,
+		UserID: &presence.UserID,
+		State:  presence.State,
+	}, nil
+}
@@ -7,21 +7,59 @@ import (
 
 // GetTasksResponseBody はタスク一覧取得APIのレスポンスボディ
 type GetTasksResponseBody struct {
-	Tasks []*GetTasksResponseBodyTask `json:"tasks"`
+	Tasks      []*GetTasksResponseBodyTask `json:"tasks"`
+	NextCursor *string                     `json:"next_cursor"`
+	PrevCursor *string                     `json:"prev_cursor"`
+	TotalCount *int64                      `json:"total_count,omitempty"`
 }
 
 type GetTasksResponseBodyTask struct {
-	ID           uint                          `json:"id"`
-	CompanyID    uint                          `json:"company_id"`
-	CreateUserID uint                          `json:"create_user_id"`
-	Title        string                        `json:"title"`
-	Description  string                        `json:"description"`
-	DueDate      *time.Time                    `json:"due_date"`
-	Visibility   string                        `json:"visibility"`
-	Status       string                        `json:"status"`
-	CreatedAt    *time.Time                    `json:"created_at"`
-	UpdatedAt    *time.Time                    `json:"updated_at"`
-	Assignee     *GetTasksResponseBodyAssignee `json:"assignee"`
+	ID             uint                          `json:"id"`
+	CompanyID      uint                          `json:"company_id"`
+	ParentTaskID   *uint                         `json:"parent_task_id"`
+	CreateUserID   uint                          `json:"create_user_id"`
+	Title          string                        `json:"title"`
+	Description    string                        `json:"description"`
+	DueDate        *time.Time                    `json:"due_date"`
+	Visibility     string                        `json:"visibility"`
+	Status         string                        `json:"status"`
+	RecurrenceRule *string                       `json:"recurrence_rule"`
+	Version        uint                          `json:"version"`
+	CreatedAt      *time.Time                    `json:"created_at"`
+	UpdatedAt      *time.Time                    `json:"updated_at"`
+	Assignee       *GetTasksResponseBodyAssignee `json:"assignee"`
+	Labels         []*GetTasksResponseBodyLabel  `json:"labels"`
+	Progress       GetTasksResponseBodyProgress  `json:"progress"`
+}
+
+type GetTasksResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewGetTasksResponseBodyLabels(labels []*model.Label) []*GetTasksResponseBodyLabel {
+	resLabels := []*GetTasksResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &GetTasksResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type GetTasksResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewGetTasksResponseBodyProgress(progress *model.TaskProgress) GetTasksResponseBodyProgress {
+	return GetTasksResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
 }
 
 type GetTasksResponseBodyAssignee struct {
@@ -34,30 +72,46 @@ type GetTasksResponseBodyAssignee struct {
 	UpdatedAt    *time.Time `json:"updated_at"`
 }
 
-func NewGetTasksResponseBody(tasks []*model.Task) *GetTasksResponseBody {
+func NewGetTasksResponseBody(page *model.TaskPage) *GetTasksResponseBody {
 	resTasks := []*GetTasksResponseBodyTask{}
 
-	for _, task := range tasks {
+	for _, task := range page.Tasks {
 		resTasks = append(resTasks, &GetTasksResponseBodyTask{
-			ID:           task.ID,
-			CompanyID:    task.CompanyID,
-			CreateUserID: task.CreateUserId,
-			Title:        task.Title,
-			Description:  task.Description,
-			DueDate:      task.DueDate,
-			Visibility:   task.Visibility,
-			Status:       task.Status,
-			CreatedAt:    task.CreatedAt,
-			UpdatedAt:    task.UpdatedAt,
-			Assignee:     NewGetTasksResponseBodyAssignee(task.Assignee),
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Assignee:       NewGetTasksResponseBodyAssignee(task.Assignee),
+			Labels:         NewGetTasksResponseBodyLabels(task.Labels),
+			Progress:       NewGetTasksResponseBodyProgress(&task.Progress),
 		})
 	}
 
 	return &GetTasksResponseBody{
-		Tasks: resTasks,
+		Tasks:      resTasks,
+		NextCursor: encodeTaskCursor(page.NextCursor),
+		PrevCursor: encodeTaskCursor(page.PrevCursor),
+		TotalCount: page.TotalCount,
 	}
 }
 
+func encodeTaskCursor(cursor *model.TaskCursor) *string {
+	if cursor == nil {
+		return nil
+	}
+	token := cursor.Encode()
+	return &token
+}
+
 func NewGetTasksResponseBodyAssignee(assignee *model.User) *GetTasksResponseBodyAssignee {
 	if assignee == nil {
 		return nil
@@ -74,23 +128,182 @@ func NewGetTasksResponseBodyAssignee(assignee *model.User) *GetTasksResponseBody
 	}
 }
 
+// SearchTasksResponseBody はタスク検索APIのレスポンスボディ
+type SearchTasksResponseBody struct {
+	Tasks []*SearchTasksResponseBodyTask `json:"tasks"`
+}
+
+type SearchTasksResponseBodyTask struct {
+	ID             uint                             `json:"id"`
+	CompanyID      uint                             `json:"company_id"`
+	ParentTaskID   *uint                            `json:"parent_task_id"`
+	CreateUserID   uint                             `json:"create_user_id"`
+	Title          string                           `json:"title"`
+	Description    string                           `json:"description"`
+	DueDate        *time.Time                       `json:"due_date"`
+	Visibility     string                           `json:"visibility"`
+	Status         string                           `json:"status"`
+	RecurrenceRule *string                          `json:"recurrence_rule"`
+	Version        uint                             `json:"version"`
+	CreatedAt      *time.Time                       `json:"created_at"`
+	UpdatedAt      *time.Time                       `json:"updated_at"`
+	Assignee       *SearchTasksResponseBodyAssignee `json:"assignee"`
+	Labels         []*SearchTasksResponseBodyLabel  `json:"labels"`
+	Progress       SearchTasksResponseBodyProgress  `json:"progress"`
+}
+
+type SearchTasksResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewSearchTasksResponseBodyLabels(labels []*model.Label) []*SearchTasksResponseBodyLabel {
+	resLabels := []*SearchTasksResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &SearchTasksResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type SearchTasksResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewSearchTasksResponseBodyProgress(progress *model.TaskProgress) SearchTasksResponseBodyProgress {
+	return SearchTasksResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
+}
+
+type SearchTasksResponseBodyAssignee struct {
+	ID        uint       `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewSearchTasksResponseBody(tasks []*model.Task) *SearchTasksResponseBody {
+	resTasks := []*SearchTasksResponseBodyTask{}
+
+	for _, task := range tasks {
+		resTasks = append(resTasks, &SearchTasksResponseBodyTask{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Assignee:       NewSearchTasksResponseBodyAssignee(task.Assignee),
+			Labels:         NewSearchTasksResponseBodyLabels(task.Labels),
+			Progress:       NewSearchTasksResponseBodyProgress(&task.Progress),
+		})
+	}
+
+	return &SearchTasksResponseBody{
+		Tasks: resTasks,
+	}
+}
+
+func NewSearchTasksResponseBodyAssignee(assignee *model.User) *SearchTasksResponseBodyAssignee {
+	if assignee == nil {
+		return nil
+	}
+
+	return &SearchTasksResponseBodyAssignee{
+		ID:        assignee.ID,
+		Username:  assignee.Username,
+		Email:     assignee.Email,
+		Role:      assignee.Role,
+		CreatedAt: assignee.CreatedAt,
+		UpdatedAt: assignee.UpdatedAt,
+	}
+}
+
 // GetTaskResponseBody はタスク取得APIのレスポンスボディ
 type GetTaskResponseBody struct {
 	Task *GetTaskResponseBodyTask `json:"task"`
 }
 
 type GetTaskResponseBodyTask struct {
-	ID           uint                         `json:"id"`
-	CompanyID    uint                         `json:"company_id"`
-	CreateUserID uint                         `json:"create_user_id"`
-	Title        string                       `json:"title"`
-	Description  string                       `json:"description"`
-	DueDate      *time.Time                   `json:"due_date"`
-	Visibility   string                       `json:"visibility"`
-	Status       string                       `json:"status"`
-	CreatedAt    *time.Time                   `json:"created_at"`
-	UpdatedAt    *time.Time                   `json:"updated_at"`
-	Assignee     *GetTaskResponseBodyAssignee `json:"assignee"`
+	ID             uint                                 `json:"id"`
+	CompanyID      uint                                 `json:"company_id"`
+	ParentTaskID   *uint                                `json:"parent_task_id"`
+	CreateUserID   uint                                 `json:"create_user_id"`
+	Title          string                               `json:"title"`
+	Description    string                               `json:"description"`
+	DueDate        *time.Time                           `json:"due_date"`
+	Visibility     string                               `json:"visibility"`
+	Status         string                               `json:"status"`
+	RecurrenceRule *string                              `json:"recurrence_rule"`
+	Version        uint                                 `json:"version"`
+	CreatedAt      *time.Time                           `json:"created_at"`
+	UpdatedAt      *time.Time                           `json:"updated_at"`
+	Assignee       *GetTaskResponseBodyAssignee         `json:"assignee"`
+	Labels         []*GetTaskResponseBodyLabel          `json:"labels"`
+	Progress       GetTaskResponseBodyProgress          `json:"progress"`
+	Blockers       []*GetTaskResponseBodyTaskDependency `json:"blockers"`
+	Dependents     []*GetTaskResponseBodyTaskDependency `json:"dependents"`
+}
+
+// GetTaskResponseBodyTaskDependency は、依存関係にあるタスクの概要
+type GetTaskResponseBodyTaskDependency struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+func NewGetTaskResponseBodyTaskDependencies(tasks []*model.Task) []*GetTaskResponseBodyTaskDependency {
+	resTasks := []*GetTaskResponseBodyTaskDependency{}
+	for _, task := range tasks {
+		resTasks = append(resTasks, &GetTaskResponseBodyTaskDependency{ID: task.ID, Title: task.Title, Status: task.Status})
+	}
+	return resTasks
+}
+
+type GetTaskResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewGetTaskResponseBodyLabels(labels []*model.Label) []*GetTaskResponseBodyLabel {
+	resLabels := []*GetTaskResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &GetTaskResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type GetTaskResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewGetTaskResponseBodyProgress(progress *model.TaskProgress) GetTaskResponseBodyProgress {
+	return GetTaskResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
 }
 
 type GetTaskResponseBodyAssignee struct {
@@ -106,17 +319,24 @@ type GetTaskResponseBodyAssignee struct {
 func NewGetTaskResponseBody(task *model.Task) *GetTaskResponseBody {
 	return &GetTaskResponseBody{
 		Task: &GetTaskResponseBodyTask{
-			ID:           task.ID,
-			CompanyID:    task.CompanyID,
-			CreateUserID: task.CreateUserId,
-			Title:        task.Title,
-			Description:  task.Description,
-			DueDate:      task.DueDate,
-			Visibility:   task.Visibility,
-			Status:       task.Status,
-			CreatedAt:    task.CreatedAt,
-			UpdatedAt:    task.UpdatedAt,
-			Assignee:     NewGetTaskResponseBodyAssignee(task.Assignee),
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Assignee:       NewGetTaskResponseBodyAssignee(task.Assignee),
+			Labels:         NewGetTaskResponseBodyLabels(task.Labels),
+			Progress:       NewGetTaskResponseBodyProgress(&task.Progress),
+			Blockers:       NewGetTaskResponseBodyTaskDependencies(task.Blockers),
+			Dependents:     NewGetTaskResponseBodyTaskDependencies(task.Dependents),
 		},
 	}
 }
@@ -143,33 +363,73 @@ type CreateTaskResponseBody struct {
 }
 
 type CreateTaskResponseBodyTask struct {
-	ID           uint       `json:"id"`
-	CompanyID    uint       `json:"company_id"`
-	CreateUserID uint       `json:"create_user_id"`
-	AssigneeID   *uint      `json:"assignee_id"`
-	Title        string     `json:"title"`
-	Description  string     `json:"description"`
-	DueDate      *time.Time `json:"due_date"`
-	Visibility   string     `json:"visibility"`
-	Status       string     `json:"status"`
-	CreatedAt    *time.Time `json:"created_at"`
-	UpdatedAt    *time.Time `json:"updated_at"`
+	ID             uint                           `json:"id"`
+	CompanyID      uint                           `json:"company_id"`
+	ParentTaskID   *uint                          `json:"parent_task_id"`
+	CreateUserID   uint                           `json:"create_user_id"`
+	AssigneeID     *uint                          `json:"assignee_id"`
+	Title          string                         `json:"title"`
+	Description    string                         `json:"description"`
+	DueDate        *time.Time                     `json:"due_date"`
+	Visibility     string                         `json:"visibility"`
+	Status         string                         `json:"status"`
+	RecurrenceRule *string                        `json:"recurrence_rule"`
+	Version        uint                           `json:"version"`
+	CreatedAt      *time.Time                     `json:"created_at"`
+	UpdatedAt      *time.Time                     `json:"updated_at"`
+	Labels         []*CreateTaskResponseBodyLabel `json:"labels"`
+	Progress       CreateTaskResponseBodyProgress `json:"progress"`
+}
+
+type CreateTaskResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewCreateTaskResponseBodyLabels(labels []*model.Label) []*CreateTaskResponseBodyLabel {
+	resLabels := []*CreateTaskResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &CreateTaskResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type CreateTaskResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewCreateTaskResponseBodyProgress(progress *model.TaskProgress) CreateTaskResponseBodyProgress {
+	return CreateTaskResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
 }
 
 func NewCreateTaskResponseBody(task *model.Task) *CreateTaskResponseBody {
 	return &CreateTaskResponseBody{
 		Task: &CreateTaskResponseBodyTask{
-			ID:           task.ID,
-			CompanyID:    task.CompanyID,
-			AssigneeID:   task.AssigneeID,
-			CreateUserID: task.CreateUserId,
-			Title:        task.Title,
-			Description:  task.Description,
-			DueDate:      task.DueDate,
-			Visibility:   task.Visibility,
-			Status:       task.Status,
-			CreatedAt:    task.CreatedAt,
-			UpdatedAt:    task.UpdatedAt,
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Labels:         NewCreateTaskResponseBodyLabels(task.Labels),
+			Progress:       NewCreateTaskResponseBodyProgress(&task.Progress),
 		},
 	}
 }
@@ -180,33 +440,323 @@ type UpdateTaskResponseBody struct {
 }
 
 type UpdateTaskResponseBodyTask struct {
-	ID           uint       `json:"id"`
-	CompanyID    uint       `json:"company_id"`
-	AssigneeID   *uint      `json:"assignee_id"`
-	CreateUserID uint       `json:"create_user_id"`
-	Title        string     `json:"title"`
-	Description  string     `json:"description"`
-	DueDate      *time.Time `json:"due_date"`
-	Visibility   string     `json:"visibility"`
-	Status       string     `json:"status"`
-	CreatedAt    *time.Time `json:"created_at"`
-	UpdatedAt    *time.Time `json:"updated_at"`
+	ID             uint                           `json:"id"`
+	CompanyID      uint                           `json:"company_id"`
+	ParentTaskID   *uint                          `json:"parent_task_id"`
+	AssigneeID     *uint                          `json:"assignee_id"`
+	CreateUserID   uint                           `json:"create_user_id"`
+	Title          string                         `json:"title"`
+	Description    string                         `json:"description"`
+	DueDate        *time.Time                     `json:"due_date"`
+	Visibility     string                         `json:"visibility"`
+	Status         string                         `json:"status"`
+	RecurrenceRule *string                        `json:"recurrence_rule"`
+	Version        uint                           `json:"version"`
+	CreatedAt      *time.Time                     `json:"created_at"`
+	UpdatedAt      *time.Time                     `json:"updated_at"`
+	Labels         []*UpdateTaskResponseBodyLabel `json:"labels"`
+	Progress       UpdateTaskResponseBodyProgress `json:"progress"`
+}
+
+type UpdateTaskResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewUpdateTaskResponseBodyLabels(labels []*model.Label) []*UpdateTaskResponseBodyLabel {
+	resLabels := []*UpdateTaskResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &UpdateTaskResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type UpdateTaskResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewUpdateTaskResponseBodyProgress(progress *model.TaskProgress) UpdateTaskResponseBodyProgress {
+	return UpdateTaskResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
 }
 
 func NewUpdateTaskResponseBody(task *model.Task) *UpdateTaskResponseBody {
 	return &UpdateTaskResponseBody{
 		Task: &UpdateTaskResponseBodyTask{
-			ID:           task.ID,
-			CompanyID:    task.CompanyID,
-			AssigneeID:   task.AssigneeID,
-			CreateUserID: task.CreateUserId,
-			Title:        task.Title,
-			Description:  task.Description,
-			DueDate:      task.DueDate,
-			Visibility:   task.Visibility,
-			Status:       task.Status,
-			CreatedAt:    task.CreatedAt,
-			UpdatedAt:    task.UpdatedAt,
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Labels:         NewUpdateTaskResponseBodyLabels(task.Labels),
+			Progress:       NewUpdateTaskResponseBodyProgress(&task.Progress),
 		},
 	}
 }
+
+// PatchTaskResponseBody はタスク部分更新APIのレスポンスボディ
+type PatchTaskResponseBody struct {
+	Task *PatchTaskResponseBodyTask `json:"task"`
+}
+
+type PatchTaskResponseBodyTask struct {
+	ID             uint                          `json:"id"`
+	CompanyID      uint                          `json:"company_id"`
+	ParentTaskID   *uint                         `json:"parent_task_id"`
+	AssigneeID     *uint                         `json:"assignee_id"`
+	CreateUserID   uint                          `json:"create_user_id"`
+	Title          string                        `json:"title"`
+	Description    string                        `json:"description"`
+	DueDate        *time.Time                    `json:"due_date"`
+	Visibility     string                        `json:"visibility"`
+	Status         string                        `json:"status"`
+	RecurrenceRule *string                       `json:"recurrence_rule"`
+	Version        uint                          `json:"version"`
+	CreatedAt      *time.Time                    `json:"created_at"`
+	UpdatedAt      *time.Time                    `json:"updated_at"`
+	Labels         []*PatchTaskResponseBodyLabel `json:"labels"`
+	Progress       PatchTaskResponseBodyProgress `json:"progress"`
+}
+
+type PatchTaskResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewPatchTaskResponseBodyLabels(labels []*model.Label) []*PatchTaskResponseBodyLabel {
+	resLabels := []*PatchTaskResponseBodyLabel{}
+	for _, label := range labels {
+		resLabels = append(resLabels, &PatchTaskResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return resLabels
+}
+
+type PatchTaskResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewPatchTaskResponseBodyProgress(progress *model.TaskProgress) PatchTaskResponseBodyProgress {
+	return PatchTaskResponseBodyProgress{
+		SubtasksDone:   progress.SubtasksDone,
+		SubtasksTotal:  progress.SubtasksTotal,
+		ChecklistDone:  progress.ChecklistDone,
+		ChecklistTotal: progress.ChecklistTotal,
+	}
+}
+
+func NewPatchTaskResponseBody(task *model.Task) *PatchTaskResponseBody {
+	return &PatchTaskResponseBody{
+		Task: &PatchTaskResponseBodyTask{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Labels:         NewPatchTaskResponseBodyLabels(task.Labels),
+			Progress:       NewPatchTaskResponseBodyProgress(&task.Progress),
+		},
+	}
+}
+
+// GetTrashedTasksResponseBody はゴミ箱のタスク一覧取得APIのレスポンスボディ
+type GetTrashedTasksResponseBody struct {
+	Tasks []*GetTrashedTasksResponseBodyTask `json:"tasks"`
+}
+
+type GetTrashedTasksResponseBodyTask struct {
+	ID             uint       `json:"id"`
+	CompanyID      uint       `json:"company_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	CreateUserID   uint       `json:"create_user_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	DueDate        *time.Time `json:"due_date"`
+	Visibility     string     `json:"visibility"`
+	Status         string     `json:"status"`
+	RecurrenceRule *string    `json:"recurrence_rule"`
+	Version        uint       `json:"version"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+}
+
+func NewGetTrashedTasksResponseBody(tasks []*model.Task) *GetTrashedTasksResponseBody {
+	resTasks := []*GetTrashedTasksResponseBodyTask{}
+
+	for _, task := range tasks {
+		var deletedAt *time.Time
+		if task.DeletedAt.Valid {
+			deletedAt = &task.DeletedAt.Time
+		}
+		resTasks = append(resTasks, &GetTrashedTasksResponseBodyTask{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			DeletedAt:      deletedAt,
+		})
+	}
+
+	return &GetTrashedTasksResponseBody{
+		Tasks: resTasks,
+	}
+}
+
+// RestoreTaskResponseBody はタスク復元APIのレスポンスボディ
+type RestoreTaskResponseBody struct {
+	Task *RestoreTaskResponseBodyTask `json:"task"`
+}
+
+type RestoreTaskResponseBodyTask struct {
+	ID             uint       `json:"id"`
+	CompanyID      uint       `json:"company_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	CreateUserID   uint       `json:"create_user_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	DueDate        *time.Time `json:"due_date"`
+	Visibility     string     `json:"visibility"`
+	Status         string     `json:"status"`
+	RecurrenceRule *string    `json:"recurrence_rule"`
+	Version        uint       `json:"version"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+func NewRestoreTaskResponseBody(task *model.Task) *RestoreTaskResponseBody {
+	return &RestoreTaskResponseBody{
+		Task: &RestoreTaskResponseBodyTask{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+		},
+	}
+}
+
+// GetSubtasksResponseBody はサブタスク一覧取得APIのレスポンスボディ
+type GetSubtasksResponseBody struct {
+	Tasks []*GetSubtasksResponseBodyTask `json:"tasks"`
+}
+
+type GetSubtasksResponseBodyTask struct {
+	ID             uint                            `json:"id"`
+	CompanyID      uint                            `json:"company_id"`
+	ParentTaskID   *uint                           `json:"parent_task_id"`
+	AssigneeID     *uint                           `json:"assignee_id"`
+	CreateUserID   uint                            `json:"create_user_id"`
+	Title          string                          `json:"title"`
+	Description    string                          `json:"description"`
+	DueDate        *time.Time                      `json:"due_date"`
+	Visibility     string                          `json:"visibility"`
+	Status         string                          `json:"status"`
+	RecurrenceRule *string                         `json:"recurrence_rule"`
+	Version        uint                            `json:"version"`
+	CreatedAt      *time.Time                      `json:"created_at"`
+	UpdatedAt      *time.Time                      `json:"updated_at"`
+	Labels         []*GetSubtasksResponseBodyLabel `json:"labels"`
+	Progress       GetSubtasksResponseBodyProgress `json:"progress"`
+}
+
+type GetSubtasksResponseBodyLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type GetSubtasksResponseBodyProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+}
+
+func NewGetSubtasksResponseBody(tasks []*model.Task) *GetSubtasksResponseBody {
+	resTasks := []*GetSubtasksResponseBodyTask{}
+
+	for _, task := range tasks {
+		resLabels := []*GetSubtasksResponseBodyLabel{}
+		for _, label := range task.Labels {
+			resLabels = append(resLabels, &GetSubtasksResponseBodyLabel{ID: label.ID, Name: label.Name, Color: label.Color})
+		}
+		resTasks = append(resTasks, &GetSubtasksResponseBodyTask{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			AssigneeID:     task.AssigneeID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			Labels:         resLabels,
+			Progress: GetSubtasksResponseBodyProgress{
+				SubtasksDone:   task.Progress.SubtasksDone,
+				SubtasksTotal:  task.Progress.SubtasksTotal,
+				ChecklistDone:  task.Progress.ChecklistDone,
+				ChecklistTotal: task.Progress.ChecklistTotal,
+			},
+		})
+	}
+
+	return &GetSubtasksResponseBody{
+		Tasks: resTasks,
+	}
+}
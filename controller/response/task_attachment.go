@@ -0,0 +1,64 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetTaskAttachmentsResponseBody は添付ファイル一覧取得APIのレスポンスボディ
+type GetTaskAttachmentsResponseBody struct {
+	Attachments []*GetTaskAttachmentsResponseBodyAttachment `json:"attachments"`
+}
+
+type GetTaskAttachmentsResponseBodyAttachment struct {
+	ID          uint       `json:"id"`
+	TaskID      uint       `json:"task_id"`
+	UserID      uint       `json:"user_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+func NewGetTaskAttachmentsResponseBody(attachments []*model.TaskAttachment) *GetTaskAttachmentsResponseBody {
+	resAttachments := []*GetTaskAttachmentsResponseBodyAttachment{}
+
+	for _, attachment := range attachments {
+		resAttachments = append(resAttachments, &GetTaskAttachmentsResponseBodyAttachment{
+			ID:          attachment.ID,
+			TaskID:      attachment.TaskID,
+			UserID:      attachment.UserID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			CreatedAt:   attachment.CreatedAt,
+		})
+	}
+
+	return &GetTaskAttachmentsResponseBody{
+		Attachments: resAttachments,
+	}
+}
+
+// CreateTaskAttachmentResponseBody は添付ファイルアップロードAPIのレスポンスボディ
+type CreateTaskAttachmentResponseBody struct {
+	ID          uint       `json:"id"`
+	TaskID      uint       `json:"task_id"`
+	UserID      uint       `json:"user_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+func NewCreateTaskAttachmentResponseBody(attachment *model.TaskAttachment) *CreateTaskAttachmentResponseBody {
+	return &CreateTaskAttachmentResponseBody{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		UserID:      attachment.UserID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
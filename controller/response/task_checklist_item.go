@@ -0,0 +1,99 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetTaskChecklistItemsResponseBody はチェックリスト取得APIのレスポンスボディ
+type GetTaskChecklistItemsResponseBody struct {
+	Items []*GetTaskChecklistItemsResponseBodyItem `json:"items"`
+}
+
+type GetTaskChecklistItemsResponseBodyItem struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id"`
+	Title     string     `json:"title"`
+	Done      bool       `json:"done"`
+	Position  int        `json:"position"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewGetTaskChecklistItemsResponseBody(items []*model.TaskChecklistItem) *GetTaskChecklistItemsResponseBody {
+	resItems := []*GetTaskChecklistItemsResponseBodyItem{}
+
+	for _, item := range items {
+		resItems = append(resItems, &GetTaskChecklistItemsResponseBodyItem{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Title:     item.Title,
+			Done:      item.Done,
+			Position:  item.Position,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return &GetTaskChecklistItemsResponseBody{
+		Items: resItems,
+	}
+}
+
+// CreateTaskChecklistItemResponseBody はチェックリストの項目追加APIのレスポンスボディ
+type CreateTaskChecklistItemResponseBody struct {
+	Item *CreateTaskChecklistItemResponseBodyItem `json:"item"`
+}
+
+type CreateTaskChecklistItemResponseBodyItem struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id"`
+	Title     string     `json:"title"`
+	Done      bool       `json:"done"`
+	Position  int        `json:"position"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewCreateTaskChecklistItemResponseBody(item *model.TaskChecklistItem) *CreateTaskChecklistItemResponseBody {
+	return &CreateTaskChecklistItemResponseBody{
+		Item: &CreateTaskChecklistItemResponseBodyItem{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Title:     item.Title,
+			Done:      item.Done,
+			Position:  item.Position,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		},
+	}
+}
+
+// UpdateTaskChecklistItemResponseBody はチェックリストの項目更新APIのレスポンスボディ
+type UpdateTaskChecklistItemResponseBody struct {
+	Item *UpdateTaskChecklistItemResponseBodyItem `json:"item"`
+}
+
+type UpdateTaskChecklistItemResponseBodyItem struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id"`
+	Title     string     `json:"title"`
+	Done      bool       `json:"done"`
+	Position  int        `json:"position"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewUpdateTaskChecklistItemResponseBody(item *model.TaskChecklistItem) *UpdateTaskChecklistItemResponseBody {
+	return &UpdateTaskChecklistItemResponseBody{
+		Item: &UpdateTaskChecklistItemResponseBodyItem{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Title:     item.Title,
+			Done:      item.Done,
+			Position:  item.Position,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		},
+	}
+}
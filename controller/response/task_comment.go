@@ -0,0 +1,102 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetTaskCommentsResponseBody はコメント一覧取得APIのレスポンスボディ
+type GetTaskCommentsResponseBody struct {
+	Comments []*GetTaskCommentsResponseBodyComment `json:"comments"`
+}
+
+type GetTaskCommentsResponseBodyComment struct {
+	ID        uint                             `json:"id"`
+	TaskID    uint                             `json:"task_id"`
+	Body      string                           `json:"body"`
+	CreatedAt *time.Time                       `json:"created_at"`
+	UpdatedAt *time.Time                       `json:"updated_at"`
+	User      *GetTaskCommentsResponseBodyUser `json:"user"`
+}
+
+type GetTaskCommentsResponseBodyUser struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+}
+
+func NewGetTaskCommentsResponseBody(comments []*model.TaskComment) *GetTaskCommentsResponseBody {
+	resComments := []*GetTaskCommentsResponseBodyComment{}
+
+	for _, comment := range comments {
+		resComment := &GetTaskCommentsResponseBodyComment{
+			ID:        comment.ID,
+			TaskID:    comment.TaskID,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+			User:      &GetTaskCommentsResponseBodyUser{ID: comment.UserID},
+		}
+		if comment.User != nil {
+			resComment.User.Username = comment.User.Username
+		}
+		resComments = append(resComments, resComment)
+	}
+
+	return &GetTaskCommentsResponseBody{
+		Comments: resComments,
+	}
+}
+
+// CreateTaskCommentResponseBody はコメント投稿APIのレスポンスボディ
+type CreateTaskCommentResponseBody struct {
+	Comment *CreateTaskCommentResponseBodyComment `json:"comment"`
+}
+
+type CreateTaskCommentResponseBodyComment struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id"`
+	UserID    uint       `json:"user_id"`
+	Body      string     `json:"body"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewCreateTaskCommentResponseBody(comment *model.TaskComment) *CreateTaskCommentResponseBody {
+	return &CreateTaskCommentResponseBody{
+		Comment: &CreateTaskCommentResponseBodyComment{
+			ID:        comment.ID,
+			TaskID:    comment.TaskID,
+			UserID:    comment.UserID,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+		},
+	}
+}
+
+// UpdateTaskCommentResponseBody はコメント編集APIのレスポンスボディ
+type UpdateTaskCommentResponseBody struct {
+	Comment *UpdateTaskCommentResponseBodyComment `json:"comment"`
+}
+
+type UpdateTaskCommentResponseBodyComment struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id"`
+	UserID    uint       `json:"user_id"`
+	Body      string     `json:"body"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewUpdateTaskCommentResponseBody(comment *model.TaskComment) *UpdateTaskCommentResponseBody {
+	return &UpdateTaskCommentResponseBody{
+		Comment: &UpdateTaskCommentResponseBodyComment{
+			ID:        comment.ID,
+			TaskID:    comment.TaskID,
+			UserID:    comment.UserID,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetTaskDependencyGraphResponseBody はタスクの依存関係のグラフ取得APIのレスポンスボディ
+type GetTaskDependencyGraphResponseBody struct {
+	Tasks        []*GetTaskDependencyGraphResponseBodyTask       `json:"tasks"`
+	Dependencies []*GetTaskDependencyGraphResponseBodyDependency `json:"dependencies"`
+}
+
+type GetTaskDependencyGraphResponseBodyTask struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+type GetTaskDependencyGraphResponseBodyDependency struct {
+	TaskID          uint `json:"task_id"`
+	BlockedByTaskID uint `json:"blocked_by_task_id"`
+}
+
+func NewGetTaskDependencyGraphResponseBody(graph *model.TaskDependencyGraph) *GetTaskDependencyGraphResponseBody {
+	resTasks := []*GetTaskDependencyGraphResponseBodyTask{}
+	for _, task := range graph.Tasks {
+		resTasks = append(resTasks, &GetTaskDependencyGraphResponseBodyTask{
+			ID:     task.ID,
+			Title:  task.Title,
+			Status: task.Status,
+		})
+	}
+
+	resDependencies := []*GetTaskDependencyGraphResponseBodyDependency{}
+	for _, dependency := range graph.Dependencies {
+		resDependencies = append(resDependencies, &GetTaskDependencyGraphResponseBodyDependency{
+			TaskID:          dependency.TaskID,
+			BlockedByTaskID: dependency.BlockedByTaskID,
+		})
+	}
+
+	return &GetTaskDependencyGraphResponseBody{
+		Tasks:        resTasks,
+		Dependencies: resDependencies,
+	}
+}
+
+// CreateTaskDependencyResponseBody はタスクの依存関係追加APIのレスポンスボディ
+type CreateTaskDependencyResponseBody struct {
+	Dependency *CreateTaskDependencyResponseBodyDependency `json:"dependency"`
+}
+
+type CreateTaskDependencyResponseBodyDependency struct {
+	TaskID          uint       `json:"task_id"`
+	BlockedByTaskID uint       `json:"blocked_by_task_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+}
+
+func NewCreateTaskDependencyResponseBody(dependency *model.TaskDependency) *CreateTaskDependencyResponseBody {
+	return &CreateTaskDependencyResponseBody{
+		Dependency: &CreateTaskDependencyResponseBodyDependency{
+			TaskID:          dependency.TaskID,
+			BlockedByTaskID: dependency.BlockedByTaskID,
+			CreatedAt:       dependency.CreatedAt,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetTaskHistoryResponseBody はタスクの変更履歴取得APIのレスポンスボディ
+type GetTaskHistoryResponseBody struct {
+	Events []*GetTaskHistoryResponseBodyEvent `json:"events"`
+}
+
+type GetTaskHistoryResponseBodyEvent struct {
+	ID        uint                                        `json:"id"`
+	TaskID    uint                                        `json:"task_id"`
+	Action    string                                      `json:"action"`
+	ByAdmin   bool                                        `json:"by_admin"`
+	Changes   map[string]GetTaskHistoryResponseBodyChange `json:"changes"`
+	CreatedAt *time.Time                                  `json:"created_at"`
+	Actor     *GetTaskHistoryResponseBodyActor            `json:"actor"`
+}
+
+type GetTaskHistoryResponseBodyChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type GetTaskHistoryResponseBodyActor struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+}
+
+func NewGetTaskHistoryResponseBody(events []*model.TaskEvent) *GetTaskHistoryResponseBody {
+	resEvents := []*GetTaskHistoryResponseBodyEvent{}
+
+	for _, event := range events {
+		changes := map[string]GetTaskHistoryResponseBodyChange{}
+		for field, change := range event.Changes {
+			changes[field] = GetTaskHistoryResponseBodyChange{From: change.From, To: change.To}
+		}
+
+		resEvent := &GetTaskHistoryResponseBodyEvent{
+			ID:        event.ID,
+			TaskID:    event.TaskID,
+			Action:    event.Action,
+			ByAdmin:   event.ByAdmin,
+			Changes:   changes,
+			CreatedAt: event.CreatedAt,
+			Actor:     &GetTaskHistoryResponseBodyActor{ID: event.ActorID},
+		}
+		if event.Actor != nil {
+			resEvent.Actor.Username = event.Actor.Username
+		}
+		resEvents = append(resEvents, resEvent)
+	}
+
+	return &GetTaskHistoryResponseBody{
+		Events: resEvents,
+	}
+}
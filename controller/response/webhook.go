@@ -0,0 +1,176 @@
+package response
+
+import (
+	"time"
+	"todo-api/model"
+)
+
+// GetWebhooksResponseBody はWebhook一覧取得APIのレスポンスボディ
+type GetWebhooksResponseBody struct {
+	Webhooks []*GetWebhooksResponseBodyWebhook `json:"webhooks"`
+}
+
+type GetWebhooksResponseBodyWebhook struct {
+	ID         uint       `json:"id"`
+	CompanyID  uint       `json:"company_id"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"event_types"`
+	Active     bool       `json:"active"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+func NewGetWebhooksResponseBody(webhooks []*model.Webhook) *GetWebhooksResponseBody {
+	resWebhooks := []*GetWebhooksResponseBodyWebhook{}
+
+	for _, webhook := range webhooks {
+		resWebhooks = append(resWebhooks, &GetWebhooksResponseBodyWebhook{
+			ID:         webhook.ID,
+			CompanyID:  webhook.CompanyID,
+			URL:        webhook.URL,
+			EventTypes: webhook.EventTypes,
+			Active:     webhook.Active,
+			CreatedAt:  webhook.CreatedAt,
+			UpdatedAt:  webhook.UpdatedAt,
+		})
+	}
+
+	return &GetWebhooksResponseBody{
+		Webhooks: resWebhooks,
+	}
+}
+
+// CreateWebhookResponseBody はWebhook作成APIのレスポンスボディ
+type CreateWebhookResponseBody struct {
+	Webhook *CreateWebhookResponseBodyWebhook `json:"webhook"`
+}
+
+type CreateWebhookResponseBodyWebhook struct {
+	ID         uint       `json:"id"`
+	CompanyID  uint       `json:"company_id"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"event_types"`
+	Active     bool       `json:"active"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+func NewCreateWebhookResponseBody(webhook *model.Webhook) *CreateWebhookResponseBody {
+	return &CreateWebhookResponseBody{
+		Webhook: &CreateWebhookResponseBodyWebhook{
+			ID:         webhook.ID,
+			CompanyID:  webhook.CompanyID,
+			URL:        webhook.URL,
+			EventTypes: webhook.EventTypes,
+			Active:     webhook.Active,
+			CreatedAt:  webhook.CreatedAt,
+			UpdatedAt:  webhook.UpdatedAt,
+		},
+	}
+}
+
+// UpdateWebhookResponseBody はWebhook更新APIのレスポンスボディ
+type UpdateWebhookResponseBody struct {
+	Webhook *UpdateWebhookResponseBodyWebhook `json:"webhook"`
+}
+
+type UpdateWebhookResponseBodyWebhook struct {
+	ID         uint       `json:"id"`
+	CompanyID  uint       `json:"company_id"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"event_types"`
+	Active     bool       `json:"active"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+func NewUpdateWebhookResponseBody(webhook *model.Webhook) *UpdateWebhookResponseBody {
+	return &UpdateWebhookResponseBody{
+		Webhook: &UpdateWebhookResponseBodyWebhook{
+			ID:         webhook.ID,
+			CompanyID:  webhook.CompanyID,
+			URL:        webhook.URL,
+			EventTypes: webhook.EventTypes,
+			Active:     webhook.Active,
+			CreatedAt:  webhook.CreatedAt,
+			UpdatedAt:  webhook.UpdatedAt,
+		},
+	}
+}
+
+// GetWebhookDeliveriesResponseBody はWebhookの配信一覧取得APIのレスポンスボディ
+type GetWebhookDeliveriesResponseBody struct {
+	Deliveries []*GetWebhookDeliveriesResponseBodyDelivery `json:"deliveries"`
+}
+
+type GetWebhookDeliveriesResponseBodyDelivery struct {
+	ID             uint       `json:"id"`
+	EventID        uint       `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Redelivery     bool       `json:"redelivery"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status"`
+	Error          *string    `json:"error"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
+func NewGetWebhookDeliveriesResponseBody(deliveries []*model.WebhookDelivery) *GetWebhookDeliveriesResponseBody {
+	resDeliveries := []*GetWebhookDeliveriesResponseBodyDelivery{}
+
+	for _, delivery := range deliveries {
+		resDeliveries = append(resDeliveries, &GetWebhookDeliveriesResponseBodyDelivery{
+			ID:             delivery.ID,
+			EventID:        delivery.WebhookEventID,
+			EventType:      webhookDeliveryEventType(delivery),
+			Redelivery:     delivery.Redelivery,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastAttemptAt:  delivery.LastAttemptAt,
+			CreatedAt:      delivery.CreatedAt,
+		})
+	}
+
+	return &GetWebhookDeliveriesResponseBody{
+		Deliveries: resDeliveries,
+	}
+}
+
+// RedeliverWebhookDeliveryResponseBody はWebhookの再送APIのレスポンスボディ
+type RedeliverWebhookDeliveryResponseBody struct {
+	Delivery *RedeliverWebhookDeliveryResponseBodyDelivery `json:"delivery"`
+}
+
+type RedeliverWebhookDeliveryResponseBodyDelivery struct {
+	ID            uint       `json:"id"`
+	EventID       uint       `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	Redelivery    bool       `json:"redelivery"`
+	Status        string     `json:"status"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+}
+
+func NewRedeliverWebhookDeliveryResponseBody(delivery *model.WebhookDelivery) *RedeliverWebhookDeliveryResponseBody {
+	return &RedeliverWebhookDeliveryResponseBody{
+		Delivery: &RedeliverWebhookDeliveryResponseBodyDelivery{
+			ID:            delivery.ID,
+			EventID:       delivery.WebhookEventID,
+			EventType:     webhookDeliveryEventType(delivery),
+			Redelivery:    delivery.Redelivery,
+			Status:        delivery.Status,
+			NextAttemptAt: delivery.NextAttemptAt,
+		},
+	}
+}
+
+func webhookDeliveryEventType(delivery *model.WebhookDelivery) string {
+	if delivery.WebhookEvent == nil {
+		return ""
+	}
+	return delivery.WebhookEvent.Type
+}
@@ -1,11 +1,13 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"todo-api/controller/request"
 	"todo-api/controller/response"
 	myErrors "todo-api/errors"
@@ -26,29 +28,42 @@ const (
 
 	// タスクのデフォルトのオフセット値
 	DEFAULT_TASK_OFFSET = 0
+
+	// JSON Merge Patch (RFC 7396) のメディアタイプ
+	MIME_APPLICATION_MERGE_PATCH_JSON = "application/merge-patch+json"
 )
 
 type TaskController interface {
 	GetTasksByAdmin(ctx echo.Context) error
 	GetTasks(ctx echo.Context) error
 	GetTask(ctx echo.Context) error
+	SearchTasks(ctx echo.Context) error
 	CreateTaskByAdmin(ctx echo.Context) error
 	CreateTask(ctx echo.Context) error
 	UpdateTaskByAdmin(ctx echo.Context) error
 	UpdateTask(ctx echo.Context) error
+	PatchTask(ctx echo.Context) error
 	DeleteTaskByAdmin(ctx echo.Context) error
 	DeleteTask(ctx echo.Context) error
+	GetTrashedTasks(ctx echo.Context) error
+	RestoreTask(ctx echo.Context) error
+	PurgeTaskByAdmin(ctx echo.Context) error
+	GetTaskHistory(ctx echo.Context) error
+	GetSubtasks(ctx echo.Context) error
 }
 
 type taskController struct {
 	validate    *validator.Validate
 	taskUseCase usecase.TaskUseCase
+	// 更新時に If-Match ヘッダーを必須とするか。false の場合、ヘッダーがなければバージョンを確認せずに更新する
+	requireIfMatch bool
 }
 
-func NewTaskController(validate *validator.Validate, taskUseCase usecase.TaskUseCase) TaskController {
+func NewTaskController(validate *validator.Validate, taskUseCase usecase.TaskUseCase, requireIfMatch bool) TaskController {
 	return &taskController{
-		validate:    validate,
-		taskUseCase: taskUseCase,
+		validate:       validate,
+		taskUseCase:    taskUseCase,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
@@ -62,25 +77,42 @@ func (c *taskController) GetTasksByAdmin(ctx echo.Context) error {
 	}
 	// リミットが最大許容値を超えないようにする
 	if limit > MAX_TASK_LIMIT {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT),
-		})
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
 	}
 	offset, err := strconv.Atoi(offsetStr)
 	if err != nil {
 		offset = DEFAULT_TASK_OFFSET
 	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+	queryParams := &request.GetTasksRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	if err := c.validate.Struct(queryParams); err != nil {
+		return validationError(ctx, err)
+	}
+	filter, err := request.NewTaskFilterFromGetTasksRequestQuery(queryParams)
+	if err != nil {
+		return err
+	}
+	pagination, err := request.NewTaskPaginationFromGetTasksRequestQuery(queryParams, filter, limit, offset)
+	if err != nil {
+		return err
+	}
 
-	tasks, err := c.taskUseCase.GetTasks(limit, offset)
+	page, err := c.taskUseCase.GetTasks(ctx.Request().Context(), filter, pagination)
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
 		}
 
 		slog.Info(fmt.Sprintf("error GetTasks: %v", err))
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		return myErrors.ErrInternal
 	}
-	return ctx.JSON(http.StatusOK, response.NewGetTasksResponseBody(tasks))
+	return ctx.JSON(http.StatusOK, response.NewGetTasksResponseBody(page))
 }
 
 func (c *taskController) GetTasks(ctx echo.Context) error {
@@ -90,7 +122,7 @@ func (c *taskController) GetTasks(ctx echo.Context) error {
 
 	companyId, err := strconv.ParseUint(companyIdStr, 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "company_id is bad request"})
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
 	}
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
@@ -98,52 +130,113 @@ func (c *taskController) GetTasks(ctx echo.Context) error {
 	}
 	// リミットが最大許容値を超えないようにする
 	if limit > MAX_TASK_LIMIT {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT),
-		})
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
 	}
 	offset, err := strconv.Atoi(offsetStr)
 	if err != nil {
 		offset = DEFAULT_TASK_OFFSET
 	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+	queryParams := &request.GetTasksRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	if err := c.validate.Struct(queryParams); err != nil {
+		return validationError(ctx, err)
+	}
+	filter, err := request.NewTaskFilterFromGetTasksRequestQuery(queryParams)
+	if err != nil {
+		return err
+	}
+	pagination, err := request.NewTaskPaginationFromGetTasksRequestQuery(queryParams, filter, limit, offset)
+	if err != nil {
+		return err
+	}
 
 	user := ctx.Get("user").(*model.User)
-	tasks, err := c.taskUseCase.GetTasksByCompanyId(uint(companyId), user.ID, limit, offset)
+	page, err := c.taskUseCase.GetTasksByCompanyId(ctx.Request().Context(), uint(companyId), user.ID, filter, pagination)
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
 		}
 
 		slog.Info(fmt.Sprintf("error GetTasks: %v", err))
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		return myErrors.ErrInternal
 	}
-	return ctx.JSON(http.StatusOK, response.NewGetTasksResponseBody(tasks))
+	return ctx.JSON(http.StatusOK, response.NewGetTasksResponseBody(page))
 }
 
 func (c *taskController) GetTask(ctx echo.Context) error {
 	id, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
 	}
 	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
 	}
 
 	user := ctx.Get("user").(*model.User)
-	task, err := c.taskUseCase.GetTask(uint(companyId), uint(id), user.ID)
+	task, err := c.taskUseCase.GetTask(ctx.Request().Context(), uint(companyId), uint(id), user.ID)
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
 		}
 
 		slog.Info(fmt.Sprintf("error GetTask: %v", err))
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get task"})
+		return myErrors.ErrInternal.WithMessage("Failed to get task")
 	}
 
+	setTaskETag(ctx, task.Version)
 	return ctx.JSON(http.StatusOK, response.NewGetTaskResponseBody(task))
 }
 
+func (c *taskController) SearchTasks(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_TASK_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_TASK_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_TASK_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	queryParams := &request.SearchTasksRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	if err := c.validate.Struct(queryParams); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	tasks, err := c.taskUseCase.SearchTasks(ctx.Request().Context(), uint(companyId), user.ID, queryParams.Q, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error SearchTasks: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewSearchTasksResponseBody(tasks))
+}
+
 func (c *taskController) CreateTaskByAdmin(ctx echo.Context) error {
 	requestBody := &request.CreateTaskByAdminRequestBody{}
 	if err := ctx.Bind(requestBody); err != nil {
@@ -152,23 +245,37 @@ func (c *taskController) CreateTaskByAdmin(ctx echo.Context) error {
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
 	}
 
 	user := ctx.Get("user").(*model.User)
 	task := request.NewTaskFromCreateTaskByAdminRequestBody(user.ID, requestBody)
-	task, err := c.taskUseCase.CreateTaskByAdmin(task)
+	task, err := c.taskUseCase.CreateTaskByAdmin(ctx.Request().Context(), task)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create task"})
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("label_ids contains unknown label")
+		}
+		if errors.Is(err, myErrors.ErrInvalidParentTask) {
+			return myErrors.ErrInvalidParentTask.WithMessage("parent_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrInvalidRecurrence) {
+			return myErrors.ErrInvalidRecurrence.WithMessage("recurrence_rule is invalid")
+		}
+		if errors.Is(err, myErrors.ErrTaskDepthExceeded) {
+			return myErrors.ErrTaskDepthExceeded.WithMessage(fmt.Sprintf("Task depth exceeds the maximum allowed value of %d", model.MAX_TASK_DEPTH))
+		}
+		slog.Info(fmt.Sprintf("error CreateTaskByAdmin: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create task")
 	}
 
+	setTaskETag(ctx, task.Version)
 	return ctx.JSON(http.StatusCreated, response.NewCreateTaskResponseBody(task))
 }
 
 func (c *taskController) CreateTask(ctx echo.Context) error {
 	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
 	}
 
 	requestBody := &request.CreateTaskRequestBody{}
@@ -178,23 +285,37 @@ func (c *taskController) CreateTask(ctx echo.Context) error {
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
 	}
 
 	user := ctx.Get("user").(*model.User)
 	task := request.NewTaskFromCreateTaskRequestBody(uint(companyId), user.ID, requestBody)
-	task, err = c.taskUseCase.CreateTask(task)
+	task, err = c.taskUseCase.CreateTask(ctx.Request().Context(), task)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create task"})
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("label_ids contains unknown label")
+		}
+		if errors.Is(err, myErrors.ErrInvalidParentTask) {
+			return myErrors.ErrInvalidParentTask.WithMessage("parent_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrInvalidRecurrence) {
+			return myErrors.ErrInvalidRecurrence.WithMessage("recurrence_rule is invalid")
+		}
+		if errors.Is(err, myErrors.ErrTaskDepthExceeded) {
+			return myErrors.ErrTaskDepthExceeded.WithMessage(fmt.Sprintf("Task depth exceeds the maximum allowed value of %d", model.MAX_TASK_DEPTH))
+		}
+		slog.Info(fmt.Sprintf("error CreateTask: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create task")
 	}
 
+	setTaskETag(ctx, task.Version)
 	return ctx.JSON(http.StatusCreated, response.NewCreateTaskResponseBody(task))
 }
 
 func (c *taskController) UpdateTaskByAdmin(ctx echo.Context) error {
 	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
 	}
 
 	requestBody := &request.UpdateTaskByAdminRequestBody{}
@@ -204,31 +325,65 @@ func (c *taskController) UpdateTaskByAdmin(ctx echo.Context) error {
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
+	}
+
+	queryParams := &request.UpdateTaskRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
 	}
 
+	version, err := ifMatchVersion(ctx, c.requireIfMatch)
+	if err != nil {
+		return err
+	}
+
+	user := ctx.Get("user").(*model.User)
 	task := request.NewTaskFromUpdateTaskByAdminRequestBody(uint(taskId), requestBody)
-	task, err = c.taskUseCase.UpdateTaskByAdmin(uint(taskId), task)
+	task, err = c.taskUseCase.UpdateTaskByAdmin(ctx.Request().Context(), uint(taskId), user.ID, task, version, queryParams.Force)
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrPreconditionFailed) {
+			return myErrors.ErrPreconditionFailed
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("task was updated by another request")
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("label_ids contains unknown label")
+		}
+		if errors.Is(err, myErrors.ErrInvalidParentTask) {
+			return myErrors.ErrInvalidParentTask.WithMessage("parent_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrInvalidRecurrence) {
+			return myErrors.ErrInvalidRecurrence.WithMessage("recurrence_rule is invalid")
+		}
+		if errors.Is(err, myErrors.ErrTaskDepthExceeded) {
+			return myErrors.ErrTaskDepthExceeded.WithMessage(fmt.Sprintf("Task depth exceeds the maximum allowed value of %d", model.MAX_TASK_DEPTH))
+		}
+		if errors.Is(err, myErrors.ErrTaskBlocked) {
+			return myErrors.ErrTaskBlocked.WithMessage("task is blocked by unfinished tasks")
 		}
 
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		slog.Info(fmt.Sprintf("error UpdateTaskByAdmin: %v", err))
+		return myErrors.ErrInternal
 	}
 
+	setTaskETag(ctx, task.Version)
 	return ctx.JSON(http.StatusOK, response.NewUpdateTaskResponseBody(task))
 }
 
 func (c *taskController) UpdateTask(ctx echo.Context) error {
 	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
 	}
 
 	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
 	}
 
 	requestBody := &request.UpdateTaskRequestBody{}
@@ -238,61 +393,361 @@ func (c *taskController) UpdateTask(ctx echo.Context) error {
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
+	}
+
+	queryParams := &request.UpdateTaskRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+
+	version, err := ifMatchVersion(ctx, c.requireIfMatch)
+	if err != nil {
+		return err
 	}
 
 	task := request.NewTaskFromUpdateTaskRequestBody(uint(taskId), uint(companyId), requestBody)
 	user := ctx.Get("user").(*model.User)
-	task, err = c.taskUseCase.UpdateTask(uint(companyId), uint(taskId), user.ID, task)
+	task, err = c.taskUseCase.UpdateTask(ctx.Request().Context(), uint(companyId), uint(taskId), user.ID, task, version, queryParams.Force)
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrPreconditionFailed) {
+			return myErrors.ErrPreconditionFailed
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("task was updated by another request")
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("label_ids contains unknown label")
+		}
+		if errors.Is(err, myErrors.ErrInvalidParentTask) {
+			return myErrors.ErrInvalidParentTask.WithMessage("parent_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrInvalidRecurrence) {
+			return myErrors.ErrInvalidRecurrence.WithMessage("recurrence_rule is invalid")
+		}
+		if errors.Is(err, myErrors.ErrTaskDepthExceeded) {
+			return myErrors.ErrTaskDepthExceeded.WithMessage(fmt.Sprintf("Task depth exceeds the maximum allowed value of %d", model.MAX_TASK_DEPTH))
+		}
+		if errors.Is(err, myErrors.ErrTaskBlocked) {
+			return myErrors.ErrTaskBlocked.WithMessage("task is blocked by unfinished tasks")
 		}
 
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		slog.Info(fmt.Sprintf("error UpdateTask: %v", err))
+		return myErrors.ErrInternal
 	}
 
+	setTaskETag(ctx, task.Version)
 	return ctx.JSON(http.StatusOK, response.NewUpdateTaskResponseBody(task))
 }
 
+// PatchTask は、JSON Merge Patch (RFC 7396) でタスクを部分更新する。
+func (c *taskController) PatchTask(ctx echo.Context) error {
+	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	contentType := ctx.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, MIME_APPLICATION_MERGE_PATCH_JSON) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return myErrors.ErrUnsupportedMediaType
+	}
+
+	requestBody := &request.PatchTaskRequestBody{}
+	if err := json.NewDecoder(ctx.Request().Body).Decode(requestBody); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("invalid request")
+	}
+
+	// Validation
+	if err := requestBody.Validate(c.validate); err != nil {
+		return validationError(ctx, err)
+	}
+
+	queryParams := &request.UpdateTaskRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+
+	version, err := ifMatchVersion(ctx, c.requireIfMatch)
+	if err != nil {
+		return err
+	}
+
+	patch := request.NewTaskPatchFromPatchTaskRequestBody(requestBody)
+	user := ctx.Get("user").(*model.User)
+	task, err := c.taskUseCase.PatchTask(ctx.Request().Context(), uint(companyId), uint(taskId), user.ID, patch, version, queryParams.Force)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrPreconditionFailed) {
+			return myErrors.ErrPreconditionFailed
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("task was updated by another request")
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("label_ids contains unknown label")
+		}
+		if errors.Is(err, myErrors.ErrInvalidParentTask) {
+			return myErrors.ErrInvalidParentTask.WithMessage("parent_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrInvalidRecurrence) {
+			return myErrors.ErrInvalidRecurrence.WithMessage("recurrence_rule is invalid")
+		}
+		if errors.Is(err, myErrors.ErrTaskDepthExceeded) {
+			return myErrors.ErrTaskDepthExceeded.WithMessage(fmt.Sprintf("Task depth exceeds the maximum allowed value of %d", model.MAX_TASK_DEPTH))
+		}
+		if errors.Is(err, myErrors.ErrTaskBlocked) {
+			return myErrors.ErrTaskBlocked.WithMessage("task is blocked by unfinished tasks")
+		}
+
+		slog.Info(fmt.Sprintf("error PatchTask: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	setTaskETag(ctx, task.Version)
+	return ctx.JSON(http.StatusOK, response.NewPatchTaskResponseBody(task))
+}
+
+// DeleteTaskByAdmin は、タスクをゴミ箱に移動する。
+// subtask_policy=cascade の場合はサブタスクもゴミ箱に移動し、指定がない場合はサブタスクがあれば削除しない。
 func (c *taskController) DeleteTaskByAdmin(ctx echo.Context) error {
 	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+
+	queryParams := &request.DeleteTaskRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	if err := c.validate.Struct(queryParams); err != nil {
+		return validationError(ctx, err)
 	}
 
-	err = c.taskUseCase.DeleteTaskByAdmin(uint(taskId))
+	user := ctx.Get("user").(*model.User)
+	err = c.taskUseCase.DeleteTaskByAdmin(ctx.Request().Context(), uint(taskId), user.ID, request.NewSubtaskPolicyFromDeleteTaskRequestQuery(queryParams))
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("task has subtasks")
 		}
 
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		slog.Info(fmt.Sprintf("error DeleteTaskByAdmin: %v", err))
+		return myErrors.ErrInternal
 	}
 
-	return ctx.JSON(http.StatusOK, nil)
+	return ctx.NoContent(http.StatusNoContent)
 }
 
+// DeleteTask は、タスクをゴミ箱に移動する。
+// subtask_policy=cascade の場合はサブタスクもゴミ箱に移動し、指定がない場合はサブタスクがあれば削除しない。
 func (c *taskController) DeleteTask(ctx echo.Context) error {
 	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, nil)
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
 	}
 
 	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, nil)
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	queryParams := &request.DeleteTaskRequestQuery{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, queryParams); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("query parameter is bad request")
+	}
+	if err := c.validate.Struct(queryParams); err != nil {
+		return validationError(ctx, err)
 	}
 
 	user := ctx.Get("user").(*model.User)
-	err = c.taskUseCase.DeleteTask(uint(companyId), uint(taskId), user.ID)
+	err = c.taskUseCase.DeleteTask(ctx.Request().Context(), uint(companyId), uint(taskId), user.ID, request.NewSubtaskPolicyFromDeleteTaskRequestQuery(queryParams))
 	if err != nil {
 		if errors.Is(err, myErrors.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrForbidden) {
+			return myErrors.ErrForbidden
 		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("task has subtasks")
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteTask: %v", err))
+		return myErrors.ErrInternal
+	}
 
-		return ctx.JSON(http.StatusInternalServerError, nil)
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *taskController) GetTrashedTasks(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_TASK_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_TASK_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_TASK_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
 	}
 
-	return ctx.JSON(http.StatusOK, nil)
+	user := ctx.Get("user").(*model.User)
+	tasks, err := c.taskUseCase.GetTrashedTasks(ctx.Request().Context(), uint(companyId), user.ID, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTrashedTasks: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTrashedTasksResponseBody(tasks))
+}
+
+func (c *taskController) RestoreTask(ctx echo.Context) error {
+	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	task, err := c.taskUseCase.RestoreTask(ctx.Request().Context(), uint(companyId), uint(taskId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrForbidden) {
+			return myErrors.ErrForbidden
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("parent task is in trash")
+		}
+
+		slog.Info(fmt.Sprintf("error RestoreTask: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	setTaskETag(ctx, task.Version)
+	return ctx.JSON(http.StatusOK, response.NewRestoreTaskResponseBody(task))
+}
+
+func (c *taskController) PurgeTaskByAdmin(ctx echo.Context) error {
+	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+
+	err = c.taskUseCase.PurgeTaskByAdmin(ctx.Request().Context(), uint(taskId))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error PurgeTaskByAdmin: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *taskController) GetTaskHistory(ctx echo.Context) error {
+	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_TASK_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_TASK_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_TASK_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	events, err := c.taskUseCase.GetTaskHistory(ctx.Request().Context(), uint(companyId), uint(taskId), user.ID, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTaskHistory: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTaskHistoryResponseBody(events))
+}
+
+// GetSubtasks は、直下のサブタスクの一覧を取得する。
+func (c *taskController) GetSubtasks(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_TASK_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_TASK_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_TASK_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	tasks, err := c.taskUseCase.GetSubtasks(ctx.Request().Context(), companyId, taskId, user.ID, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetSubtasks: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetSubtasksResponseBody(tasks))
 }
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// 添付ファイルの最大サイズ(25MiB)
+	MAX_TASK_ATTACHMENT_SIZE = 25 << 20
+
+	// multipart のヘッダーなど、ファイル以外のリクエストボディに許容するサイズ
+	MAX_TASK_ATTACHMENT_OVERHEAD = 1 << 20
+)
+
+type TaskAttachmentController interface {
+	GetTaskAttachments(ctx echo.Context) error
+	GetTaskAttachment(ctx echo.Context) error
+	CreateTaskAttachment(ctx echo.Context) error
+	DeleteTaskAttachment(ctx echo.Context) error
+}
+
+type taskAttachmentController struct {
+	taskAttachmentUseCase usecase.TaskAttachmentUseCase
+}
+
+func NewTaskAttachmentController(taskAttachmentUseCase usecase.TaskAttachmentUseCase) TaskAttachmentController {
+	return &taskAttachmentController{
+		taskAttachmentUseCase: taskAttachmentUseCase,
+	}
+}
+
+func (c *taskAttachmentController) GetTaskAttachments(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	user := ctx.Get("user").(*model.User)
+	attachments, err := c.taskAttachmentUseCase.GetTaskAttachments(ctx.Request().Context(), companyId, taskId, user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTaskAttachments: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTaskAttachmentsResponseBody(attachments))
+}
+
+// GetTaskAttachment は、添付ファイルをダウンロードする。Range リクエストにも対応する。
+func (c *taskAttachmentController) GetTaskAttachment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	attachmentId, err := strconv.ParseUint(ctx.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("attachment_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	attachment, file, err := c.taskAttachmentUseCase.OpenTaskAttachment(ctx.Request().Context(), companyId, taskId, uint(attachmentId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTaskAttachment: %v", err))
+		return myErrors.ErrInternal
+	}
+	defer file.Close()
+
+	// 保存時に判定した Content-Type を返し、ブラウザに内容から推測させない
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, attachment.ContentType)
+	header.Set(echo.HeaderContentDisposition, contentDispositionAttachment(attachment.FileName))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	modTime := time.Time{}
+	if attachment.CreatedAt != nil {
+		modTime = *attachment.CreatedAt
+	}
+	http.ServeContent(ctx.Response(), ctx.Request(), attachment.FileName, modTime, file)
+	return nil
+}
+
+// CreateTaskAttachment は、multipart/form-data の file フィールドのファイルを添付する。
+func (c *taskAttachmentController) CreateTaskAttachment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := ctx.Request()
+	req.Body = http.MaxBytesReader(ctx.Response(), req.Body, MAX_TASK_ATTACHMENT_SIZE+MAX_TASK_ATTACHMENT_OVERHEAD)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return myErrors.ErrPayloadTooLarge.WithMessage(fmt.Sprintf("file size exceeds the maximum allowed size of %d bytes", MAX_TASK_ATTACHMENT_SIZE))
+		}
+		return myErrors.ErrBadRequest.WithMessage("file is required")
+	}
+	if fileHeader.Size > MAX_TASK_ATTACHMENT_SIZE {
+		return myErrors.ErrPayloadTooLarge.WithMessage(fmt.Sprintf("file size exceeds the maximum allowed size of %d bytes", MAX_TASK_ATTACHMENT_SIZE))
+	}
+	if fileHeader.Size == 0 {
+		return myErrors.ErrBadRequest.WithMessage("file is empty")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		slog.Info(fmt.Sprintf("error CreateTaskAttachment: %v", err))
+		return myErrors.ErrInternal
+	}
+	defer file.Close()
+
+	user := ctx.Get("user").(*model.User)
+	attachment, err := c.taskAttachmentUseCase.UploadTaskAttachment(req.Context(), companyId, taskId, user.ID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrQuotaExceeded) {
+			return myErrors.ErrQuotaExceeded.WithMessage("attachment quota exceeded")
+		}
+		if errors.Is(err, myErrors.ErrUnsupportedMediaType) {
+			return myErrors.ErrUnsupportedMediaType
+		}
+
+		slog.Info(fmt.Sprintf("error CreateTaskAttachment: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to upload attachment")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateTaskAttachmentResponseBody(attachment))
+}
+
+func (c *taskAttachmentController) DeleteTaskAttachment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	attachmentId, err := strconv.ParseUint(ctx.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("attachment_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	err = c.taskAttachmentUseCase.DeleteTaskAttachment(ctx.Request().Context(), companyId, taskId, uint(attachmentId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrForbidden) {
+			return myErrors.ErrForbidden
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteTaskAttachment: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// contentDispositionAttachment は、RFC 6266 の形式でダウンロード用の Content-Disposition を作成する。
+func contentDispositionAttachment(fileName string) string {
+	return "attachment; filename*=UTF-8''" + strings.ReplaceAll(url.QueryEscape(fileName), "+", "%20")
+}
@@ -0,0 +1,388 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// nopReadSeekCloser はテスト用の io.ReadSeekCloser
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func TestTaskAttachmentController_GetTaskAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskAttachmentUseCase(ctrl)
+	taskAttachmentController := NewTaskAttachmentController(mockUseCase)
+
+	attachments := []*model.TaskAttachment{{ID: 4, TaskID: 2, UserID: 3, FileName: "a.png", ContentType: "image/png", Size: 10}}
+
+	testCases := []struct {
+		name           string
+		taskID         string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskAttachments(gomock.Any(), uint(1), uint(2), uint(3)).Return(attachments, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTaskAttachmentsResponseBody(attachments),
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:   "Not found",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskAttachments(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:   "Internal server error",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskAttachments(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/tasks/"+tc.taskID+"/attachments", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskAttachmentController.GetTaskAttachments(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskAttachmentController_GetTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskAttachmentUseCase(ctrl)
+	taskAttachmentController := NewTaskAttachmentController(mockUseCase)
+
+	content := []byte("%PDF-1.4 sample")
+	attachment := &model.TaskAttachment{ID: 4, TaskID: 2, UserID: 3, FileName: "見積 書.pdf", ContentType: "application/pdf", Size: int64(len(content))}
+
+	testCases := []struct {
+		name            string
+		attachmentID    string
+		rangeHeader     string
+		mockFunc        func()
+		expectedStatus  int
+		expectedContent []byte
+	}{
+		{
+			name:         "Success",
+			attachmentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().OpenTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).
+					Return(attachment, nopReadSeekCloser{bytes.NewReader(content)}, nil).Times(1)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedContent: content,
+		},
+		{
+			name:         "Range request",
+			attachmentID: "4",
+			rangeHeader:  "bytes=5-7",
+			mockFunc: func() {
+				mockUseCase.EXPECT().OpenTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).
+					Return(attachment, nopReadSeekCloser{bytes.NewReader(content)}, nil).Times(1)
+			},
+			expectedStatus:  http.StatusPartialContent,
+			expectedContent: content[5:8],
+		},
+		{
+			name:           "Invalid attachment ID",
+			attachmentID:   "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "Not found",
+			attachmentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().OpenTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).
+					Return(nil, nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/tasks/2/attachments/"+tc.attachmentID, nil)
+			if tc.rangeHeader != "" {
+				req.Header.Set("Range", tc.rangeHeader)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "attachment_id")
+			ctx.SetParamValues("1", "2", tc.attachmentID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskAttachmentController.GetTaskAttachment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedContent != nil {
+				assert.Equal(t, tc.expectedContent, rec.Body.Bytes())
+				assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+				assert.Equal(t, "attachment; filename*=UTF-8''%E8%A6%8B%E7%A9%8D%20%E6%9B%B8.pdf", rec.Header().Get(echo.HeaderContentDisposition))
+			}
+		})
+	}
+}
+
+func TestTaskAttachmentController_CreateTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskAttachmentUseCase(ctrl)
+	taskAttachmentController := NewTaskAttachmentController(mockUseCase)
+
+	content := []byte("\x89PNG\r\n\x1a\nimage")
+	attachment := &model.TaskAttachment{ID: 4, TaskID: 2, UserID: 3, FileName: "a.png", ContentType: "image/png", Size: int64(len(content))}
+
+	testCases := []struct {
+		name           string
+		fieldName      string
+		content        []byte
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			fieldName: "file",
+			content:   content,
+			mockFunc: func() {
+				mockUseCase.EXPECT().UploadTaskAttachment(gomock.Any(), uint(1), uint(2), uint(3), "a.png", int64(len(content)), gomock.Any()).
+					Return(attachment, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateTaskAttachmentResponseBody(attachment),
+		},
+		{
+			name:           "Missing file",
+			fieldName:      "other",
+			content:        content,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "file is required"),
+		},
+		{
+			name:           "Empty file",
+			fieldName:      "file",
+			content:        []byte{},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "file is empty"),
+		},
+		{
+			name:      "Quota exceeded",
+			fieldName: "file",
+			content:   content,
+			mockFunc: func() {
+				mockUseCase.EXPECT().UploadTaskAttachment(gomock.Any(), uint(1), uint(2), uint(3), "a.png", int64(len(content)), gomock.Any()).
+					Return(nil, myErrors.ErrQuotaExceeded).Times(1)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   problem(http.StatusRequestEntityTooLarge, "quota_exceeded", "attachment quota exceeded"),
+		},
+		{
+			name:      "Unsupported media type",
+			fieldName: "file",
+			content:   content,
+			mockFunc: func() {
+				mockUseCase.EXPECT().UploadTaskAttachment(gomock.Any(), uint(1), uint(2), uint(3), "a.png", int64(len(content)), gomock.Any()).
+					Return(nil, myErrors.ErrUnsupportedMediaType).Times(1)
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   problem(http.StatusUnsupportedMediaType, "unsupported_media_type", "unsupported media type"),
+		},
+		{
+			name:      "Not found",
+			fieldName: "file",
+			content:   content,
+			mockFunc: func() {
+				mockUseCase.EXPECT().UploadTaskAttachment(gomock.Any(), uint(1), uint(2), uint(3), "a.png", int64(len(content)), gomock.Any()).
+					Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Internal server error",
+			fieldName: "file",
+			content:   content,
+			mockFunc: func() {
+				mockUseCase.EXPECT().UploadTaskAttachment(gomock.Any(), uint(1), uint(2), uint(3), "a.png", int64(len(content)), gomock.Any()).
+					Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to upload attachment"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, err := writer.CreateFormFile(tc.fieldName, "a.png")
+			if err != nil {
+				t.Fatalf("failed to create form file: %v", err)
+			}
+			part.Write(tc.content)
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/tasks/2/attachments", body)
+			req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", "2")
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskAttachmentController.CreateTaskAttachment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskAttachmentController_DeleteTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskAttachmentUseCase(ctrl)
+	taskAttachmentController := NewTaskAttachmentController(mockUseCase)
+
+	testCases := []struct {
+		name           string
+		attachmentID   string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:         "Success",
+			attachmentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid attachment ID",
+			attachmentID:   "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "attachment_id is bad request"),
+		},
+		{
+			name:         "Forbidden",
+			attachmentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrForbidden).Times(1)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   problem(http.StatusForbidden, "forbidden", "forbidden"),
+		},
+		{
+			name:         "Not found",
+			attachmentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskAttachment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/1/tasks/2/attachments/"+tc.attachmentID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "attachment_id")
+			ctx.SetParamValues("1", "2", tc.attachmentID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskAttachmentController.DeleteTaskAttachment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type TaskChecklistItemController interface {
+	GetTaskChecklistItems(ctx echo.Context) error
+	CreateTaskChecklistItem(ctx echo.Context) error
+	UpdateTaskChecklistItem(ctx echo.Context) error
+	DeleteTaskChecklistItem(ctx echo.Context) error
+}
+
+type taskChecklistItemController struct {
+	validate                 *validator.Validate
+	taskChecklistItemUseCase usecase.TaskChecklistItemUseCase
+}
+
+func NewTaskChecklistItemController(validate *validator.Validate, taskChecklistItemUseCase usecase.TaskChecklistItemUseCase) TaskChecklistItemController {
+	return &taskChecklistItemController{
+		validate:                 validate,
+		taskChecklistItemUseCase: taskChecklistItemUseCase,
+	}
+}
+
+func (c *taskChecklistItemController) GetTaskChecklistItems(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	user := ctx.Get("user").(*model.User)
+	items, err := c.taskChecklistItemUseCase.GetTaskChecklistItems(ctx.Request().Context(), companyId, taskId, user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTaskChecklistItems: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTaskChecklistItemsResponseBody(items))
+}
+
+func (c *taskChecklistItemController) CreateTaskChecklistItem(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	requestBody := &request.CreateTaskChecklistItemRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	item, err := c.taskChecklistItemUseCase.CreateTaskChecklistItem(ctx.Request().Context(), companyId, taskId, user.ID, requestBody.Title)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrQuotaExceeded) {
+			return myErrors.ErrConflict.WithMessage(fmt.Sprintf("checklist cannot have more than %d items", model.MAX_TASK_CHECKLIST_ITEMS))
+		}
+
+		slog.Info(fmt.Sprintf("error CreateTaskChecklistItem: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create checklist item")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateTaskChecklistItemResponseBody(item))
+}
+
+func (c *taskChecklistItemController) UpdateTaskChecklistItem(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	itemId, err := strconv.ParseUint(ctx.Param("item_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("item_id is bad request")
+	}
+
+	requestBody := &request.UpdateTaskChecklistItemRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	item, err := c.taskChecklistItemUseCase.UpdateTaskChecklistItem(ctx.Request().Context(), companyId, taskId, uint(itemId), user.ID, requestBody.Title, requestBody.Done)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateTaskChecklistItem: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateTaskChecklistItemResponseBody(item))
+}
+
+func (c *taskChecklistItemController) DeleteTaskChecklistItem(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	itemId, err := strconv.ParseUint(ctx.Param("item_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("item_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	err = c.taskChecklistItemUseCase.DeleteTaskChecklistItem(ctx.Request().Context(), companyId, taskId, uint(itemId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteTaskChecklistItem: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskChecklistItemController_GetTaskChecklistItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskChecklistItemUseCase(ctrl)
+	validate := NewValidator()
+	taskChecklistItemController := NewTaskChecklistItemController(validate, mockUseCase)
+
+	items := []*model.TaskChecklistItem{{ID: 4, TaskID: 2, Title: "item", Done: true, Position: 1}}
+
+	testCases := []struct {
+		name           string
+		taskID         string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskChecklistItems(gomock.Any(), uint(1), uint(2), uint(3)).Return(items, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTaskChecklistItemsResponseBody(items),
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:   "Not found",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskChecklistItems(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/tasks/"+tc.taskID+"/checklist", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskChecklistItemController.GetTaskChecklistItems(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskChecklistItemController_CreateTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskChecklistItemUseCase(ctrl)
+	validate := NewValidator()
+	taskChecklistItemController := NewTaskChecklistItemController(validate, mockUseCase)
+
+	item := &model.TaskChecklistItem{ID: 4, TaskID: 2, Title: "item", Position: 1}
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.CreateTaskChecklistItemRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: &request.CreateTaskChecklistItemRequestBody{Title: "item"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(3), "item").Return(item, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateTaskChecklistItemResponseBody(item),
+		},
+		{
+			name:           "Validation error",
+			requestBody:    &request.CreateTaskChecklistItemRequestBody{Title: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Limit reached",
+			requestBody: &request.CreateTaskChecklistItemRequestBody{Title: "item"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(3), "item").Return(nil, myErrors.ErrQuotaExceeded).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "checklist cannot have more than 100 items"),
+		},
+		{
+			name:        "Internal server error",
+			requestBody: &request.CreateTaskChecklistItemRequestBody{Title: "item"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(3), "item").Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create checklist item"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/tasks/2/checklist", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", "2")
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskChecklistItemController.CreateTaskChecklistItem(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskChecklistItemController_UpdateTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskChecklistItemUseCase(ctrl)
+	validate := NewValidator()
+	taskChecklistItemController := NewTaskChecklistItemController(validate, mockUseCase)
+
+	item := &model.TaskChecklistItem{ID: 4, TaskID: 2, Title: "updated", Done: true, Position: 1}
+
+	testCases := []struct {
+		name           string
+		itemID         string
+		requestBody    *request.UpdateTaskChecklistItemRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			itemID:      "4",
+			requestBody: &request.UpdateTaskChecklistItemRequestBody{Title: "updated", Done: true},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(4), uint(3), "updated", true).Return(item, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateTaskChecklistItemResponseBody(item),
+		},
+		{
+			name:           "Invalid item ID",
+			itemID:         "invalid",
+			requestBody:    &request.UpdateTaskChecklistItemRequestBody{Title: "updated", Done: true},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "item_id is bad request"),
+		},
+		{
+			name:        "Not found",
+			itemID:      "4",
+			requestBody: &request.UpdateTaskChecklistItemRequestBody{Title: "updated"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(4), uint(3), "updated", false).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/companies/1/tasks/2/checklist/"+tc.itemID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "item_id")
+			ctx.SetParamValues("1", "2", tc.itemID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskChecklistItemController.UpdateTaskChecklistItem(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskChecklistItemController_DeleteTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskChecklistItemUseCase(ctrl)
+	validate := NewValidator()
+	taskChecklistItemController := NewTaskChecklistItemController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name: "Not found",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskChecklistItem(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/1/tasks/2/checklist/4", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "item_id")
+			ctx.SetParamValues("1", "2", "4")
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskChecklistItemController.DeleteTaskChecklistItem(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// コメントのMAX取得制限数
+	MAX_TASK_COMMENT_LIMIT = 100
+
+	// コメントのデフォルトの取得制限数
+	DEFAULT_TASK_COMMENT_LIMIT = 20
+
+	// コメントのデフォルトのオフセット値
+	DEFAULT_TASK_COMMENT_OFFSET = 0
+)
+
+type TaskCommentController interface {
+	GetTaskComments(ctx echo.Context) error
+	CreateTaskComment(ctx echo.Context) error
+	UpdateTaskComment(ctx echo.Context) error
+	DeleteTaskComment(ctx echo.Context) error
+}
+
+type taskCommentController struct {
+	validate           *validator.Validate
+	taskCommentUseCase usecase.TaskCommentUseCase
+}
+
+func NewTaskCommentController(validate *validator.Validate, taskCommentUseCase usecase.TaskCommentUseCase) TaskCommentController {
+	return &taskCommentController{
+		validate:           validate,
+		taskCommentUseCase: taskCommentUseCase,
+	}
+}
+
+func (c *taskCommentController) GetTaskComments(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_TASK_COMMENT_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_TASK_COMMENT_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_TASK_COMMENT_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_TASK_COMMENT_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	comments, err := c.taskCommentUseCase.GetTaskComments(ctx.Request().Context(), companyId, taskId, user.ID, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetTaskComments: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTaskCommentsResponseBody(comments))
+}
+
+func (c *taskCommentController) CreateTaskComment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	requestBody := &request.CreateTaskCommentRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	comment, err := c.taskCommentUseCase.CreateTaskComment(ctx.Request().Context(), companyId, taskId, user.ID, requestBody.Body)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error CreateTaskComment: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create comment")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateTaskCommentResponseBody(comment))
+}
+
+func (c *taskCommentController) UpdateTaskComment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	commentId, err := strconv.ParseUint(ctx.Param("comment_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("comment_id is bad request")
+	}
+
+	requestBody := &request.UpdateTaskCommentRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	comment, err := c.taskCommentUseCase.UpdateTaskComment(ctx.Request().Context(), companyId, taskId, uint(commentId), user.ID, requestBody.Body)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrForbidden) {
+			return myErrors.ErrForbidden
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateTaskComment: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateTaskCommentResponseBody(comment))
+}
+
+func (c *taskCommentController) DeleteTaskComment(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	commentId, err := strconv.ParseUint(ctx.Param("comment_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("comment_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	err = c.taskCommentUseCase.DeleteTaskComment(ctx.Request().Context(), companyId, taskId, uint(commentId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrForbidden) {
+			return myErrors.ErrForbidden
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteTaskComment: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// parseCompanyIdAndTaskId は、パスパラメータから会社IDとタスクIDを取得する。
+func parseCompanyIdAndTaskId(ctx echo.Context) (uint, uint, error) {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return 0, 0, myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	taskId, err := strconv.ParseUint(ctx.Param("task_id"), 10, 64)
+	if err != nil {
+		return 0, 0, myErrors.ErrBadRequest.WithMessage("task_id is bad request")
+	}
+	return uint(companyId), uint(taskId), nil
+}
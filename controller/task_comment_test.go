@@ -0,0 +1,374 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskCommentController_GetTaskComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskCommentUseCase(ctrl)
+	validate := NewValidator()
+	taskCommentController := NewTaskCommentController(validate, mockUseCase)
+
+	comments := []*model.TaskComment{{ID: 4, TaskID: 2, UserID: 3, Body: "comment", User: &model.User{ID: 3, Username: "user"}}}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		taskID         string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			taskID:    "2",
+			query:     "limit=50&offset=10",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskComments(gomock.Any(), uint(1), uint(2), uint(3), 50, 10).Return(comments, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTaskCommentsResponseBody(comments),
+		},
+		{
+			name:           "Invalid task ID",
+			companyID:      "1",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			companyID:      "1",
+			taskID:         "2",
+			query:          "limit=101",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 100"),
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskComments(gomock.Any(), uint(1), uint(2), uint(3), DEFAULT_TASK_COMMENT_LIMIT, DEFAULT_TASK_COMMENT_OFFSET).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskComments(gomock.Any(), uint(1), uint(2), uint(3), DEFAULT_TASK_COMMENT_LIMIT, DEFAULT_TASK_COMMENT_OFFSET).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID+"/comments?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues(tc.companyID, tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskCommentController.GetTaskComments(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskCommentController_CreateTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskCommentUseCase(ctrl)
+	validate := NewValidator()
+	taskCommentController := NewTaskCommentController(validate, mockUseCase)
+
+	comment := &model.TaskComment{ID: 4, TaskID: 2, UserID: 3, Body: "comment"}
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.CreateTaskCommentRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: &request.CreateTaskCommentRequestBody{Body: "comment"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskComment(gomock.Any(), uint(1), uint(2), uint(3), "comment").Return(comment, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateTaskCommentResponseBody(comment),
+		},
+		{
+			name:           "Validation error",
+			requestBody:    &request.CreateTaskCommentRequestBody{Body: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Task not visible",
+			requestBody: &request.CreateTaskCommentRequestBody{Body: "comment"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskComment(gomock.Any(), uint(1), uint(2), uint(3), "comment").Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:        "Internal server error",
+			requestBody: &request.CreateTaskCommentRequestBody{Body: "comment"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskComment(gomock.Any(), uint(1), uint(2), uint(3), "comment").Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create comment"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/tasks/2/comments", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", "2")
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskCommentController.CreateTaskComment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskCommentController_UpdateTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskCommentUseCase(ctrl)
+	validate := NewValidator()
+	taskCommentController := NewTaskCommentController(validate, mockUseCase)
+
+	comment := &model.TaskComment{ID: 4, TaskID: 2, UserID: 3, Body: "edited"}
+
+	testCases := []struct {
+		name           string
+		commentID      string
+		requestBody    *request.UpdateTaskCommentRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			commentID:   "4",
+			requestBody: &request.UpdateTaskCommentRequestBody{Body: "edited"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3), "edited").Return(comment, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewUpdateTaskCommentResponseBody(comment),
+		},
+		{
+			name:           "Invalid comment ID",
+			commentID:      "invalid",
+			requestBody:    &request.UpdateTaskCommentRequestBody{Body: "edited"},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "comment_id is bad request"),
+		},
+		{
+			name:           "Validation error",
+			commentID:      "4",
+			requestBody:    &request.UpdateTaskCommentRequestBody{Body: ""},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Forbidden",
+			commentID:   "4",
+			requestBody: &request.UpdateTaskCommentRequestBody{Body: "edited"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3), "edited").Return(nil, myErrors.ErrForbidden).Times(1)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   problem(http.StatusForbidden, "forbidden", "forbidden"),
+		},
+		{
+			name:        "Not found",
+			commentID:   "4",
+			requestBody: &request.UpdateTaskCommentRequestBody{Body: "edited"},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3), "edited").Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/companies/1/tasks/2/comments/"+tc.commentID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "comment_id")
+			ctx.SetParamValues("1", "2", tc.commentID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskCommentController.UpdateTaskComment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskCommentController_DeleteTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskCommentUseCase(ctrl)
+	validate := NewValidator()
+	taskCommentController := NewTaskCommentController(validate, mockUseCase)
+
+	testCases := []struct {
+		name           string
+		commentID      string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			commentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid comment ID",
+			commentID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "comment_id is bad request"),
+		},
+		{
+			name:      "Forbidden",
+			commentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrForbidden).Times(1)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   problem(http.StatusForbidden, "forbidden", "forbidden"),
+		},
+		{
+			name:      "Not found",
+			commentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Internal server error",
+			commentID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskComment(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/1/tasks/2/comments/"+tc.commentID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "comment_id")
+			ctx.SetParamValues("1", "2", tc.commentID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskCommentController.DeleteTaskComment(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
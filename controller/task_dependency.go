@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type TaskDependencyController interface {
+	GetTaskDependencyGraph(ctx echo.Context) error
+	CreateTaskDependency(ctx echo.Context) error
+	DeleteTaskDependency(ctx echo.Context) error
+}
+
+type taskDependencyController struct {
+	validate              *validator.Validate
+	taskDependencyUseCase usecase.TaskDependencyUseCase
+}
+
+func NewTaskDependencyController(validate *validator.Validate, taskDependencyUseCase usecase.TaskDependencyUseCase) TaskDependencyController {
+	return &taskDependencyController{
+		validate:              validate,
+		taskDependencyUseCase: taskDependencyUseCase,
+	}
+}
+
+// GetTaskDependencyGraph は、会社のタスクの依存関係のグラフを返す。
+func (c *taskDependencyController) GetTaskDependencyGraph(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	graph, err := c.taskDependencyUseCase.GetTaskDependencyGraph(ctx.Request().Context(), uint(companyId), user.ID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetTaskDependencyGraph: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetTaskDependencyGraphResponseBody(graph))
+}
+
+func (c *taskDependencyController) CreateTaskDependency(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+
+	requestBody := &request.CreateTaskDependencyRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	user := ctx.Get("user").(*model.User)
+	dependency, err := c.taskDependencyUseCase.CreateTaskDependency(ctx.Request().Context(), companyId, taskId, requestBody.BlockedByTaskID, user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("blocked_by_task_id is invalid")
+		}
+		if errors.Is(err, myErrors.ErrConflict) {
+			return myErrors.ErrConflict.WithMessage("dependency already exists")
+		}
+		if errors.Is(err, myErrors.ErrDependencyCycle) {
+			return myErrors.ErrDependencyCycle.WithMessage("dependency would create a cycle")
+		}
+
+		slog.Info(fmt.Sprintf("error CreateTaskDependency: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create dependency")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateTaskDependencyResponseBody(dependency))
+}
+
+func (c *taskDependencyController) DeleteTaskDependency(ctx echo.Context) error {
+	companyId, taskId, err := parseCompanyIdAndTaskId(ctx)
+	if err != nil {
+		return err
+	}
+	blockedByTaskId, err := strconv.ParseUint(ctx.Param("blocked_by_task_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("blocked_by_task_id is bad request")
+	}
+
+	user := ctx.Get("user").(*model.User)
+	err = c.taskDependencyUseCase.DeleteTaskDependency(ctx.Request().Context(), companyId, taskId, uint(blockedByTaskId), user.ID)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteTaskDependency: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
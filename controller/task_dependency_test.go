@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskDependencyController_GetTaskDependencyGraph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskDependencyUseCase(ctrl)
+	validate := NewValidator()
+	taskDependencyController := NewTaskDependencyController(validate, mockUseCase)
+
+	graph := &model.TaskDependencyGraph{
+		Tasks:        []*model.Task{{ID: 2, Title: "Task 2", Status: "pending"}, {ID: 4, Title: "Task 4", Status: "done"}},
+		Dependencies: []*model.TaskDependency{{TaskID: 2, BlockedByTaskID: 4, CompanyID: 1}},
+	}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskDependencyGraph(gomock.Any(), uint(1), uint(3)).Return(graph, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"tasks": []map[string]interface{}{
+					{"id": 2, "title": "Task 2", "status": "pending"},
+					{"id": 4, "title": "Task 4", "status": "done"},
+				},
+				"dependencies": []map[string]interface{}{
+					{"task_id": 2, "blocked_by_task_id": 4},
+				},
+			},
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskDependencyGraph(gomock.Any(), uint(1), uint(3)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks/dependency-graph", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskDependencyController.GetTaskDependencyGraph(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskDependencyController_CreateTaskDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskDependencyUseCase(ctrl)
+	validate := NewValidator()
+	taskDependencyController := NewTaskDependencyController(validate, mockUseCase)
+
+	dependency := &model.TaskDependency{TaskID: 2, BlockedByTaskID: 4, CompanyID: 1}
+
+	testCases := []struct {
+		name           string
+		requestBody    *request.CreateTaskDependencyRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			requestBody: &request.CreateTaskDependencyRequestBody{BlockedByTaskID: 4},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(dependency, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateTaskDependencyResponseBody(dependency),
+		},
+		{
+			name:           "Validation error",
+			requestBody:    &request.CreateTaskDependencyRequestBody{},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Not found",
+			requestBody: &request.CreateTaskDependencyRequestBody{BlockedByTaskID: 4},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:        "Invalid blocker",
+			requestBody: &request.CreateTaskDependencyRequestBody{BlockedByTaskID: 4},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil, myErrors.ErrInvalidArgument).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_argument", "blocked_by_task_id is invalid"),
+		},
+		{
+			name:        "Already exists",
+			requestBody: &request.CreateTaskDependencyRequestBody{BlockedByTaskID: 4},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "dependency already exists"),
+		},
+		{
+			name:        "Cycle",
+			requestBody: &request.CreateTaskDependencyRequestBody{BlockedByTaskID: 4},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil, myErrors.ErrDependencyCycle).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "dependency_cycle", "dependency would create a cycle"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/tasks/2/dependencies", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", "2")
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskDependencyController.CreateTaskDependency(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskDependencyController_DeleteTaskDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskDependencyUseCase(ctrl)
+	validate := NewValidator()
+	taskDependencyController := NewTaskDependencyController(validate, mockUseCase)
+
+	testCases := []struct {
+		name            string
+		blockedByTaskID string
+		mockFunc        func()
+		expectedStatus  int
+		expectedBody    interface{}
+	}{
+		{
+			name:            "Success",
+			blockedByTaskID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:            "Invalid blocked_by_task_id",
+			blockedByTaskID: "invalid",
+			mockFunc:        func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedBody:    problem(http.StatusBadRequest, "bad_request", "blocked_by_task_id is bad request"),
+		},
+		{
+			name:            "Not found",
+			blockedByTaskID: "4",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTaskDependency(gomock.Any(), uint(1), uint(2), uint(4), uint(3)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/1/tasks/2/dependencies/"+tc.blockedByTaskID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id", "blocked_by_task_id")
+			ctx.SetParamValues("1", "2", tc.blockedByTaskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskDependencyController.DeleteTaskDependency(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
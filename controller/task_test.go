@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,10 +16,10 @@ import (
 	mock_usecase "todo-api/mock/usecase"
 	"todo-api/model"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestTaskController_GetTasksByAdmin(t *testing.T) {
@@ -26,8 +27,8 @@ func TestTaskController_GetTasksByAdmin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
@@ -78,11 +79,11 @@ func TestTaskController_GetTasksByAdmin(t *testing.T) {
 						Assignee:    nil,
 					},
 				}
-				mockUseCase.EXPECT().GetTasks(10, 0).Return(mockTasks, nil).Times(1)
+				mockUseCase.EXPECT().GetTasks(gomock.Any(), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(&model.TaskPage{Tasks: mockTasks}, nil).Times(1)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: response.NewGetTasksResponseBody(
-				[]*model.Task{
+				&model.TaskPage{Tasks: []*model.Task{
 					{
 						ID:          1,
 						CompanyID:   1,
@@ -117,7 +118,7 @@ func TestTaskController_GetTasksByAdmin(t *testing.T) {
 						UpdatedAt:   &[]time.Time{time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
 						Assignee:    nil,
 					},
-				},
+				}},
 			),
 		},
 		{
@@ -126,24 +127,40 @@ func TestTaskController_GetTasksByAdmin(t *testing.T) {
 			offset:         "0",
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Limit exceeds the maximum allowed value of 20"},
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 20"),
+		},
+		{
+			name:           "Negative limit",
+			limit:          "-1",
+			offset:         "0",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit must be at least 1 and offset must not be negative"),
+		},
+		{
+			name:           "Negative offset",
+			limit:          "10",
+			offset:         "-1",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit must be at least 1 and offset must not be negative"),
 		},
 		{
 			name:   "NotFound",
 			limit:  "10",
 			offset: "0",
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTasks(10, 0).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockUseCase.EXPECT().GetTasks(gomock.Any(), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
 			name:   "InternalServerError",
 			limit:  "10",
 			offset: "0",
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTasks(10, 0).Return(nil, errors.New("some error")).Times(1)
+				mockUseCase.EXPECT().GetTasks(gomock.Any(), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
@@ -159,12 +176,13 @@ func TestTaskController_GetTasksByAdmin(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.GetTasksByAdmin(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := taskController.GetTasksByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -175,8 +193,8 @@ func TestTaskController_GetTasks(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
@@ -184,6 +202,7 @@ func TestTaskController_GetTasks(t *testing.T) {
 		userID         uint
 		limit          string
 		offset         string
+		query          string
 		mockFunc       func()
 		expectedStatus int
 		expectedBody   interface{}
@@ -231,11 +250,11 @@ func TestTaskController_GetTasks(t *testing.T) {
 						Assignee:    nil,
 					},
 				}
-				mockUseCase.EXPECT().GetTasksByCompanyId(uint(1), uint(2), 10, 0).Return(mockTasks, nil).Times(1)
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(&model.TaskPage{Tasks: mockTasks}, nil).Times(1)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: response.NewGetTasksResponseBody(
-				[]*model.Task{
+				&model.TaskPage{Tasks: []*model.Task{
 					{
 						ID:          1,
 						CompanyID:   1,
@@ -270,9 +289,127 @@ func TestTaskController_GetTasks(t *testing.T) {
 						UpdatedAt:   &[]time.Time{time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
 						Assignee:    nil,
 					},
-				},
+				}},
 			),
 		},
+		{
+			name:      "Success with filter and sort",
+			companyID: "1",
+			userID:    2,
+			limit:     "10",
+			offset:    "0",
+			query:     "&status=done&assignee_id=3&overdue=true&due_before=2024-01-01T00:00:00Z&sort=due_date,-created_at",
+			mockFunc: func() {
+				status := "done"
+				assigneeId := uint(3)
+				dueBefore := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+				filter := &model.TaskFilter{
+					Status:     &status,
+					AssigneeID: &assigneeId,
+					DueBefore:  &dueBefore,
+					Overdue:    true,
+					Sort:       []model.TaskSort{{Field: "due_date"}, {Field: "created_at", Desc: true}},
+				}
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), filter, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(&model.TaskPage{Tasks: []*model.Task{}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTasksResponseBody(&model.TaskPage{Tasks: []*model.Task{}}),
+		},
+		{
+			name:      "Success with labels",
+			companyID: "1",
+			userID:    2,
+			limit:     "10",
+			offset:    "0",
+			query:     "&labels=bug,%20urgent,bug&label_match=all",
+			mockFunc: func() {
+				filter := &model.TaskFilter{Labels: []string{"bug", "urgent"}, LabelMatch: model.LABEL_MATCH_ALL}
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), filter, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(&model.TaskPage{Tasks: []*model.Task{}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTasksResponseBody(&model.TaskPage{Tasks: []*model.Task{}}),
+		},
+		{
+			name:           "BadRequest - Invalid label_match",
+			companyID:      "1",
+			userID:         2,
+			limit:          "10",
+			offset:         "0",
+			query:          "&labels=bug&label_match=some",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:           "BadRequest - Invalid status",
+			companyID:      "1",
+			userID:         2,
+			limit:          "10",
+			offset:         "0",
+			query:          "&status=unknown",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:      "Success with cursor and without total count",
+			companyID: "1",
+			userID:    2,
+			limit:     "10",
+			offset:    "5",
+			query:     "&total_count=false&cursor=" + model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, &model.TaskFilter{}, &model.Task{ID: 5}).Encode(),
+			mockFunc: func() {
+				pagination := &model.TaskPagination{
+					Limit:  10,
+					Cursor: model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, &model.TaskFilter{}, &model.Task{ID: 5}),
+				}
+				page := &model.TaskPage{
+					Tasks:      []*model.Task{{ID: 6}},
+					NextCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, &model.TaskFilter{}, &model.Task{ID: 6}),
+					PrevCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, &model.TaskFilter{}, &model.Task{ID: 6}),
+				}
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), &model.TaskFilter{}, pagination).Return(page, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: response.NewGetTasksResponseBody(&model.TaskPage{
+				Tasks:      []*model.Task{{ID: 6}},
+				NextCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, &model.TaskFilter{}, &model.Task{ID: 6}),
+				PrevCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, &model.TaskFilter{}, &model.Task{ID: 6}),
+			}),
+		},
+		{
+			name:           "BadRequest - Invalid cursor",
+			companyID:      "1",
+			userID:         2,
+			limit:          "10",
+			offset:         "0",
+			query:          "&cursor=invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "cursor is invalid"),
+		},
+		{
+			name:           "BadRequest - Cursor for another sort",
+			companyID:      "1",
+			userID:         2,
+			limit:          "10",
+			offset:         "0",
+			query:          "&sort=title&cursor=" + model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, &model.TaskFilter{}, &model.Task{ID: 5}).Encode(),
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "cursor is invalid"),
+		},
+		{
+			name:           "BadRequest - Sort field not allowed",
+			companyID:      "1",
+			userID:         2,
+			limit:          "10",
+			offset:         "0",
+			query:          "&sort=password_hash",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "sort field \"password_hash\" is not allowed"),
+		},
 		{
 			name:           "BadRequest - Invalid CompanyID",
 			companyID:      "invalid",
@@ -281,7 +418,7 @@ func TestTaskController_GetTasks(t *testing.T) {
 			offset:         "0",
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "company_id is bad request"},
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
 		},
 		{
 			name:           "Limit exceeds max",
@@ -291,7 +428,17 @@ func TestTaskController_GetTasks(t *testing.T) {
 			offset:         "0",
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Limit exceeds the maximum allowed value of 20"},
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 20"),
+		},
+		{
+			name:           "Negative limit",
+			companyID:      "1",
+			userID:         2,
+			limit:          "-1",
+			offset:         "0",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit must be at least 1 and offset must not be negative"),
 		},
 		{
 			name:      "NotFound",
@@ -300,10 +447,10 @@ func TestTaskController_GetTasks(t *testing.T) {
 			limit:     "10",
 			offset:    "0",
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTasksByCompanyId(uint(1), uint(2), 10, 0).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
 			name:      "InternalServerError",
@@ -312,7 +459,7 @@ func TestTaskController_GetTasks(t *testing.T) {
 			limit:     "10",
 			offset:    "0",
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTasksByCompanyId(uint(1), uint(2), 10, 0).Return(nil, errors.New("some error")).Times(1)
+				mockUseCase.EXPECT().GetTasksByCompanyId(gomock.Any(), uint(1), uint(2), &model.TaskFilter{}, &model.TaskPagination{Limit: 10, WithTotalCount: true}).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
@@ -322,7 +469,7 @@ func TestTaskController_GetTasks(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks?limit="+tc.limit+"&offset="+tc.offset, nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks?limit="+tc.limit+"&offset="+tc.offset+tc.query, nil)
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 			ctx.SetParamNames("company_id")
@@ -331,12 +478,13 @@ func TestTaskController_GetTasks(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.GetTasks(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := taskController.GetTasks(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -347,8 +495,8 @@ func TestTaskController_GetTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
@@ -385,8 +533,9 @@ func TestTaskController_GetTask(t *testing.T) {
 						CreatedAt:    &[]time.Time{time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
 						UpdatedAt:    &[]time.Time{time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
 					},
+					Blockers: []*model.Task{{ID: 4, Title: "Blocker", Status: "in_progress"}},
 				}
-				mockUseCase.EXPECT().GetTask(uint(1), uint(2), uint(3)).Return(task, nil).Times(1)
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(task, nil).Times(1)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: &response.GetTaskResponseBody{
@@ -400,6 +549,9 @@ func TestTaskController_GetTask(t *testing.T) {
 					Status:      "pending",
 					CreatedAt:   &[]time.Time{time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
 					UpdatedAt:   &[]time.Time{time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					Labels:      []*response.GetTaskResponseBodyLabel{},
+					Blockers:    []*response.GetTaskResponseBodyTaskDependency{{ID: 4, Title: "Blocker", Status: "in_progress"}},
+					Dependents:  []*response.GetTaskResponseBodyTaskDependency{},
 					Assignee: &response.GetTaskResponseBodyAssignee{
 						ID:           11,
 						Username:     "user 11",
@@ -418,12 +570,10 @@ func TestTaskController_GetTask(t *testing.T) {
 			taskID:    "2",
 			userID:    3,
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTask(uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody: map[string]string{
-				"error": "not found",
-			},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
 			name:           "Invalid task ID",
@@ -449,12 +599,10 @@ func TestTaskController_GetTask(t *testing.T) {
 			taskID:    "2",
 			userID:    3,
 			mockFunc: func() {
-				mockUseCase.EXPECT().GetTask(uint(1), uint(2), uint(3)).Return(nil, errors.New("some error")).Times(1)
+				mockUseCase.EXPECT().GetTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody: map[string]string{
-				"error": "Failed to get task",
-			},
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to get task"),
 		},
 	}
 
@@ -470,13 +618,106 @@ func TestTaskController_GetTask(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.GetTask(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
+			if err := taskController.GetTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
 
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_SearchTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			query:     "q=%E8%AD%B0%E4%BA%8B%E9%8C%B2&limit=5",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SearchTasks(gomock.Any(), uint(1), uint(2), "議事録", 5, 0).
+					Return([]*model.Task{{ID: 3, CompanyID: 1, Title: "議事録を書く", Visibility: "company", Status: "pending"}}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: response.NewSearchTasksResponseBody(
+				[]*model.Task{{ID: 3, CompanyID: 1, Title: "議事録を書く", Visibility: "company", Status: "pending"}},
+			),
+		},
+		{
+			name:           "BadRequest - Missing query",
+			companyID:      "1",
+			query:          "",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:           "BadRequest - Invalid CompanyID",
+			companyID:      "invalid",
+			query:          "q=test",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:      "NotFound",
+			companyID: "1",
+			query:     "q=test",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SearchTasks(gomock.Any(), uint(1), uint(2), "test", 10, 0).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "InternalServerError",
+			companyID: "1",
+			query:     "q=test",
+			mockFunc: func() {
+				mockUseCase.EXPECT().SearchTasks(gomock.Any(), uint(1), uint(2), "test", 10, 0).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks/search?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+			ctx.Set("user", &model.User{ID: 2})
+
+			tc.mockFunc()
+
+			if err := taskController.SearchTasks(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -487,8 +728,8 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
@@ -511,7 +752,7 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().CreateTaskByAdmin(&model.Task{
+				mockUseCase.EXPECT().CreateTaskByAdmin(gomock.Any(), &model.Task{
 					CompanyID:    1,
 					CreateUserId: 2,
 					Title:        "New Task",
@@ -544,6 +785,7 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 					AssigneeID:   &[]uint{1}[0],
 					Visibility:   "company",
 					Status:       "pending",
+					Labels:       []*response.CreateTaskResponseBodyLabel{},
 				},
 			},
 		},
@@ -561,7 +803,7 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 			},
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Key: 'CreateTaskByAdminRequestBody.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			expectedBody:   validationProblem(validationField("title", "required", "title is a required field")),
 		},
 		{
 			name:   "Internal server error",
@@ -576,7 +818,7 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().CreateTaskByAdmin(&model.Task{
+				mockUseCase.EXPECT().CreateTaskByAdmin(gomock.Any(), &model.Task{
 					CompanyID:    1,
 					CreateUserId: 2,
 					Title:        "New Task",
@@ -588,7 +830,7 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 				}).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   map[string]string{"error": "Failed to create task"},
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create task"),
 		},
 	}
 
@@ -607,12 +849,13 @@ func TestTaskController_CreateTaskByAdmin(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.CreateTaskByAdmin(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := taskController.CreateTaskByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -623,8 +866,8 @@ func TestTaskController_CreateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
@@ -648,7 +891,7 @@ func TestTaskController_CreateTask(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().CreateTask(&model.Task{
+				mockUseCase.EXPECT().CreateTask(gomock.Any(), &model.Task{
 					CompanyID:    1,
 					CreateUserId: 2,
 					Title:        "New Task",
@@ -681,6 +924,7 @@ func TestTaskController_CreateTask(t *testing.T) {
 					AssigneeID:   &[]uint{1}[0],
 					Visibility:   "company",
 					Status:       "pending",
+					Labels:       []*response.CreateTaskResponseBodyLabel{},
 				},
 			},
 		},
@@ -714,7 +958,82 @@ func TestTaskController_CreateTask(t *testing.T) {
 			},
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Key: 'CreateTaskRequestBody.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			expectedBody:   validationProblem(validationField("title", "required", "title is a required field")),
+		},
+		{
+			name:      "Invalid parent task",
+			companyID: "1",
+			userID:    2,
+			requestBody: &request.CreateTaskRequestBody{
+				Title:        "Subtask",
+				Description:  "Subtask description",
+				ParentTaskID: &[]uint{5}[0],
+				Visibility:   "company",
+				Status:       "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTask(gomock.Any(), &model.Task{
+					CompanyID:    1,
+					ParentTaskID: &[]uint{5}[0],
+					CreateUserId: 2,
+					Title:        "Subtask",
+					Description:  "Subtask description",
+					Visibility:   "company",
+					Status:       "pending",
+				}).Return(nil, myErrors.ErrInvalidParentTask).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_parent_task", "parent_task_id is invalid"),
+		},
+		{
+			name:      "Invalid recurrence",
+			companyID: "1",
+			userID:    2,
+			requestBody: &request.CreateTaskRequestBody{
+				Title:          "Task",
+				Description:    "Task description",
+				Visibility:     "company",
+				Status:         "pending",
+				RecurrenceRule: &[]string{"FREQ=YEARLY"}[0],
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTask(gomock.Any(), &model.Task{
+					CompanyID:      1,
+					CreateUserId:   2,
+					Title:          "Task",
+					Description:    "Task description",
+					Visibility:     "company",
+					Status:         "pending",
+					RecurrenceRule: &[]string{"FREQ=YEARLY"}[0],
+				}).Return(nil, myErrors.ErrInvalidRecurrence).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_recurrence", "recurrence_rule is invalid"),
+		},
+		{
+			name:      "Task depth exceeded",
+			companyID: "1",
+			userID:    2,
+			requestBody: &request.CreateTaskRequestBody{
+				Title:        "Subtask",
+				Description:  "Subtask description",
+				ParentTaskID: &[]uint{5}[0],
+				Visibility:   "company",
+				Status:       "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateTask(gomock.Any(), &model.Task{
+					CompanyID:    1,
+					ParentTaskID: &[]uint{5}[0],
+					CreateUserId: 2,
+					Title:        "Subtask",
+					Description:  "Subtask description",
+					Visibility:   "company",
+					Status:       "pending",
+				}).Return(nil, myErrors.ErrTaskDepthExceeded).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "task_depth_exceeded", "Task depth exceeds the maximum allowed value of 5"),
 		},
 		{
 			name:      "Internal server error",
@@ -729,7 +1048,7 @@ func TestTaskController_CreateTask(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().CreateTask(&model.Task{
+				mockUseCase.EXPECT().CreateTask(gomock.Any(), &model.Task{
 					CompanyID:    1,
 					CreateUserId: 2,
 					Title:        "New Task",
@@ -741,7 +1060,7 @@ func TestTaskController_CreateTask(t *testing.T) {
 				}).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   map[string]string{"error": "Failed to create task"},
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create task"),
 		},
 	}
 
@@ -762,12 +1081,13 @@ func TestTaskController_CreateTask(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.CreateTask(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := taskController.CreateTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -778,11 +1098,14 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+	ifMatchRequiredTaskController := NewTaskController(validate, mockUseCase, true)
 
 	testCases := []struct {
 		name           string
+		requireIfMatch bool
+		ifMatch        string
 		taskID         string
 		requestBody    *request.UpdateTaskByAdminRequestBody
 		mockFunc       func()
@@ -790,8 +1113,9 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 		expectedBody   interface{}
 	}{
 		{
-			name:   "Success",
-			taskID: "1",
+			name:    "Success",
+			ifMatch: `"1"`,
+			taskID:  "1",
 			requestBody: &request.UpdateTaskByAdminRequestBody{
 				Title:       "Updated Task",
 				Description: "Updated task description",
@@ -801,7 +1125,7 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTaskByAdmin(uint(1), &model.Task{
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
 					ID:          1,
 					Title:       "Updated Task",
 					Description: "Updated task description",
@@ -809,7 +1133,7 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(&model.Task{
+				}, &[]uint{1}[0], false).Return(&model.Task{
 					ID:          1,
 					CompanyID:   1,
 					Title:       "Updated Task",
@@ -831,12 +1155,14 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
+					Labels:      []*response.UpdateTaskResponseBodyLabel{},
 				},
 			},
 		},
 		{
-			name:   "Invalid task ID",
-			taskID: "invalid",
+			name:    "Invalid task ID",
+			ifMatch: `"1"`,
+			taskID:  "invalid",
 			requestBody: &request.UpdateTaskByAdminRequestBody{
 				Title:       "Updated Task",
 				Description: "Updated task description",
@@ -849,8 +1175,9 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 			expectedBody:   nil,
 		},
 		{
-			name:   "Validation Error",
-			taskID: "1",
+			name:    "Validation Error",
+			ifMatch: `"1"`,
+			taskID:  "1",
 			requestBody: &request.UpdateTaskByAdminRequestBody{
 				Title:       "",
 				Description: "Updated task description",
@@ -861,11 +1188,12 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 			},
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Key: 'UpdateTaskByAdminRequestBody.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			expectedBody:   validationProblem(validationField("title", "required", "title is a required field")),
 		},
 		{
-			name:   "Not found",
-			taskID: "1",
+			name:    "Not found",
+			ifMatch: `"1"`,
+			taskID:  "1",
 			requestBody: &request.UpdateTaskByAdminRequestBody{
 				Title:       "Updated Task",
 				Description: "Updated task description",
@@ -874,7 +1202,7 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 				Visibility:  "company",
 				Status:      "pending",
 			}, mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTaskByAdmin(uint(1), &model.Task{
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
 					ID:          1,
 					Title:       "Updated Task",
 					Description: "Updated task description",
@@ -882,77 +1210,182 @@ func TestTaskController_UpdateTaskByAdmin(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(nil, myErrors.ErrNotFound).Times(1)
+				}, &[]uint{1}[0], false).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
-			name:   "Internal server error",
+			name:   "Success - without If-Match",
 			taskID: "1",
 			requestBody: &request.UpdateTaskByAdminRequestBody{
 				Title:       "Updated Task",
 				Description: "Updated task description",
-				DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-				AssigneeID:  &[]uint{1}[0],
 				Visibility:  "company",
 				Status:      "pending",
-			}, mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTaskByAdmin(uint(1), &model.Task{
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
 					ID:          1,
 					Title:       "Updated Task",
 					Description: "Updated task description",
-					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(nil, errors.New("some error")).Times(1)
+				}, nil, false).Return(&model.Task{ID: 1, CompanyID: 1, Title: "Updated Task"}, nil).Times(1)
 			},
-			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   nil,
+			expectedStatus: http.StatusOK,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			e := echo.New()
-			reqBody, err := json.Marshal(tc.requestBody)
-			if err != nil {
-				t.Fatalf("failed to marshal request body: %v", err)
-			}
-			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/tasks/"+tc.taskID, bytes.NewBuffer(reqBody))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-			rec := httptest.NewRecorder()
-			ctx := e.NewContext(req, rec)
-			ctx.SetParamNames("task_id")
-			ctx.SetParamValues(tc.taskID)
-
-			tc.mockFunc()
-
-			if assert.NoError(t, taskController.UpdateTaskByAdmin(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
-			}
-		})
-	}
-}
-
-func TestTaskController_UpdateTask(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
-
-	testCases := []struct {
-		name           string
-		companyID      string
-		taskID         string
-		userID         uint
+		{
+			name:           "Precondition required",
+			requireIfMatch: true,
+			taskID:         "1",
+			requestBody: &request.UpdateTaskByAdminRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   problem(http.StatusPreconditionRequired, "precondition_required", "If-Match header is required"),
+		},
+		{
+			name:    "Precondition failed - weak ETag",
+			ifMatch: `W/"1"`,
+			taskID:  "1",
+			requestBody: &request.UpdateTaskByAdminRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   problem(http.StatusPreconditionFailed, "precondition_failed", "precondition failed"),
+		},
+		{
+			name:    "Precondition failed - stale version",
+			ifMatch: `"1"`,
+			taskID:  "1",
+			requestBody: &request.UpdateTaskByAdminRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
+					ID:          1,
+					Title:       "Updated Task",
+					Description: "Updated task description",
+					Visibility:  "company",
+					Status:      "pending",
+				}, &[]uint{1}[0], false).Return(nil, myErrors.ErrPreconditionFailed).Times(1)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   problem(http.StatusPreconditionFailed, "precondition_failed", "precondition failed"),
+		},
+		{
+			// If-Match がない場合、他の更新との競合は 409 とする
+			name:   "Conflict - concurrent update without If-Match",
+			taskID: "1",
+			requestBody: &request.UpdateTaskByAdminRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
+					ID:          1,
+					Title:       "Updated Task",
+					Description: "Updated task description",
+					Visibility:  "company",
+					Status:      "pending",
+				}, nil, false).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "task was updated by another request"),
+		},
+		{
+			name:    "Internal server error",
+			ifMatch: `"1"`,
+			taskID:  "1",
+			requestBody: &request.UpdateTaskByAdminRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+				AssigneeID:  &[]uint{1}[0],
+				Visibility:  "company",
+				Status:      "pending",
+			}, mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTaskByAdmin(gomock.Any(), uint(1), uint(99), &model.Task{
+					ID:          1,
+					Title:       "Updated Task",
+					Description: "Updated task description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "company",
+					Status:      "pending",
+				}, &[]uint{1}[0], false).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/tasks/"+tc.taskID, bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.ifMatch != "" {
+				req.Header.Set(HEADER_IF_MATCH, tc.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("task_id")
+			ctx.SetParamValues(tc.taskID)
+			ctx.Set("user", &model.User{ID: 99, Role: model.USER_ROLE_SYSTEM_OPERATOR})
+
+			tc.mockFunc()
+
+			c := taskController
+			if tc.requireIfMatch {
+				c = ifMatchRequiredTaskController
+			}
+			if err := c.UpdateTaskByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_UpdateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+	ifMatchRequiredTaskController := NewTaskController(validate, mockUseCase, true)
+
+	testCases := []struct {
+		name           string
+		requireIfMatch bool
+		ifMatch        string
+		companyID      string
+		taskID         string
+		userID         uint
 		requestBody    *request.UpdateTaskRequestBody
 		mockFunc       func()
 		expectedStatus int
@@ -960,6 +1393,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 	}{
 		{
 			name:      "Success",
+			ifMatch:   `"1"`,
 			companyID: "1",
 			taskID:    "1",
 			userID:    3,
@@ -972,7 +1406,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 				Status:      "pending",
 			},
 			mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTask(uint(1), uint(1), uint(3), &model.Task{
+				mockUseCase.EXPECT().UpdateTask(gomock.Any(), uint(1), uint(1), uint(3), &model.Task{
 					ID:          1,
 					CompanyID:   1,
 					Title:       "Updated Task",
@@ -981,7 +1415,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(&model.Task{
+				}, &[]uint{1}[0], false).Return(&model.Task{
 					ID:          1,
 					CompanyID:   1,
 					Title:       "Updated Task",
@@ -1003,11 +1437,77 @@ func TestTaskController_UpdateTask(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
+					Labels:      []*response.UpdateTaskResponseBodyLabel{},
 				},
 			},
 		},
+		{
+			name:      "Success - without If-Match",
+			companyID: "1",
+			taskID:    "1",
+			userID:    3,
+			requestBody: &request.UpdateTaskRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTask(gomock.Any(), uint(1), uint(1), uint(3), &model.Task{
+					ID:          1,
+					CompanyID:   1,
+					Title:       "Updated Task",
+					Description: "Updated task description",
+					Visibility:  "company",
+					Status:      "pending",
+				}, nil, false).Return(&model.Task{ID: 1, CompanyID: 1, Title: "Updated Task"}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Precondition required",
+			requireIfMatch: true,
+			companyID:      "1",
+			taskID:         "1",
+			userID:         3,
+			requestBody: &request.UpdateTaskRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   problem(http.StatusPreconditionRequired, "precondition_required", "If-Match header is required"),
+		},
+		{
+			name:      "Precondition failed - stale version",
+			ifMatch:   `"1"`,
+			companyID: "1",
+			taskID:    "1",
+			userID:    3,
+			requestBody: &request.UpdateTaskRequestBody{
+				Title:       "Updated Task",
+				Description: "Updated task description",
+				Visibility:  "company",
+				Status:      "pending",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().UpdateTask(gomock.Any(), uint(1), uint(1), uint(3), &model.Task{
+					ID:          1,
+					CompanyID:   1,
+					Title:       "Updated Task",
+					Description: "Updated task description",
+					Visibility:  "company",
+					Status:      "pending",
+				}, &[]uint{1}[0], false).Return(nil, myErrors.ErrPreconditionFailed).Times(1)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   problem(http.StatusPreconditionFailed, "precondition_failed", "precondition failed"),
+		},
 		{
 			name:      "Invalid task ID",
+			ifMatch:   `"1"`,
 			companyID: "1",
 			taskID:    "invalid",
 			userID:    3,
@@ -1024,6 +1524,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 		},
 		{
 			name:      "Invalid company ID",
+			ifMatch:   `"1"`,
 			companyID: "invalid",
 			taskID:    "1",
 			userID:    3,
@@ -1040,6 +1541,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 		},
 		{
 			name:      "Validation Error",
+			ifMatch:   `"1"`,
 			companyID: "1",
 			taskID:    "1",
 			userID:    3,
@@ -1053,10 +1555,11 @@ func TestTaskController_UpdateTask(t *testing.T) {
 			},
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Key: 'UpdateTaskRequestBody.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			expectedBody:   validationProblem(validationField("title", "required", "title is a required field")),
 		},
 		{
 			name:      "Not found",
+			ifMatch:   `"1"`,
 			companyID: "1",
 			taskID:    "1",
 			userID:    3,
@@ -1068,7 +1571,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 				Visibility:  "company",
 				Status:      "pending",
 			}, mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTask(uint(1), uint(1), uint(3), &model.Task{
+				mockUseCase.EXPECT().UpdateTask(gomock.Any(), uint(1), uint(1), uint(3), &model.Task{
 					ID:          1,
 					CompanyID:   1,
 					Title:       "Updated Task",
@@ -1077,13 +1580,14 @@ func TestTaskController_UpdateTask(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(nil, myErrors.ErrNotFound).Times(1)
+				}, &[]uint{1}[0], false).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
 			name:      "Internal server error",
+			ifMatch:   `"1"`,
 			companyID: "1",
 			taskID:    "1",
 			userID:    3,
@@ -1095,7 +1599,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 				Visibility:  "company",
 				Status:      "pending",
 			}, mockFunc: func() {
-				mockUseCase.EXPECT().UpdateTask(uint(1), uint(1), uint(3), &model.Task{
+				mockUseCase.EXPECT().UpdateTask(gomock.Any(), uint(1), uint(1), uint(3), &model.Task{
 					ID:          1,
 					CompanyID:   1,
 					Title:       "Updated Task",
@@ -1104,7 +1608,7 @@ func TestTaskController_UpdateTask(t *testing.T) {
 					AssigneeID:  &[]uint{1}[0],
 					Visibility:  "company",
 					Status:      "pending",
-				}).Return(nil, errors.New("some error")).Times(1)
+				}, &[]uint{1}[0], false).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
@@ -1120,6 +1624,9 @@ func TestTaskController_UpdateTask(t *testing.T) {
 			}
 			req := httptest.NewRequest(http.MethodPut, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID, bytes.NewBuffer(reqBody))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.ifMatch != "" {
+				req.Header.Set(HEADER_IF_MATCH, tc.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 			ctx.SetParamNames("company_id", "task_id")
@@ -1128,108 +1635,269 @@ func TestTaskController_UpdateTask(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.UpdateTask(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			c := taskController
+			if tc.requireIfMatch {
+				c = ifMatchRequiredTaskController
+			}
+			if err := c.UpdateTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
 }
 
-func TestTaskController_DeleteTaskByAdmin(t *testing.T) {
+func TestTaskController_PatchTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+	ifMatchRequiredTaskController := NewTaskController(validate, mockUseCase, true)
 
+	status := "done"
 	testCases := []struct {
 		name           string
-		companyID      string
-		taskID         string
+		requireIfMatch bool
+		query          string
+		ifMatch        string
+		contentType    string
+		requestBody    string
 		mockFunc       func()
 		expectedStatus int
+		expectedETag   string
 		expectedBody   interface{}
 	}{
 		{
-			name:      "Success",
-			companyID: "1",
-			taskID:    "2",
+			name:        "Success",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done","due_date":null}`,
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTaskByAdmin(uint(2)).Return(nil).Times(1)
+				patch := &model.TaskPatch{
+					Status:  model.Optional[string]{Set: true, Value: status},
+					DueDate: model.Optional[*time.Time]{Set: true},
+				}
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), patch, &[]uint{1}[0], false).
+					Return(&model.Task{ID: 3, CompanyID: 1, Title: "Task 3", Visibility: "company", Status: status, Version: 2}, nil).
+					Times(1)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   nil,
+			expectedETag:   `"2"`,
+			expectedBody:   response.NewPatchTaskResponseBody(&model.Task{ID: 3, CompanyID: 1, Title: "Task 3", Visibility: "company", Status: status, Version: 2}),
 		},
 		{
-			name:           "Invalid task ID",
-			companyID:      "1",
-			taskID:         "invalid",
+			name:        "Success - application/json and assignee",
+			ifMatch:     `"1"`,
+			contentType: echo.MIMEApplicationJSON,
+			requestBody: `{"assignee_id":5}`,
+			mockFunc: func() {
+				patch := &model.TaskPatch{
+					AssigneeID: model.Optional[*uint]{Set: true, Value: &[]uint{5}[0]},
+				}
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), patch, &[]uint{1}[0], false).
+					Return(&model.Task{ID: 3, AssigneeID: &[]uint{5}[0]}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewPatchTaskResponseBody(&model.Task{ID: 3, AssigneeID: &[]uint{5}[0]}),
+		},
+		{
+			name:        "Success - wildcard If-Match",
+			ifMatch:     "*",
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"title":"Task 3"}`,
+			mockFunc: func() {
+				patch := &model.TaskPatch{Title: model.Optional[string]{Set: true, Value: "Task 3"}}
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), patch, nil, false).
+					Return(&model.Task{ID: 3, Title: "Task 3", Version: 5}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+			expectedBody:   response.NewPatchTaskResponseBody(&model.Task{ID: 3, Title: "Task 3", Version: 5}),
+		},
+		{
+			name:        "Success - without If-Match",
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
+			mockFunc: func() {
+				patch := &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: status}}
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), patch, nil, false).
+					Return(&model.Task{ID: 3, Status: status, Version: 2}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:           "Precondition required",
+			requireIfMatch: true,
+			contentType:    MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody:    `{"status":"done"}`,
 			mockFunc:       func() {},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   problem(http.StatusPreconditionRequired, "precondition_required", "If-Match header is required"),
+		},
+		{
+			name:        "Precondition failed - stale version",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
+			mockFunc: func() {
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), gomock.Any(), &[]uint{1}[0], false).Return(nil, myErrors.ErrPreconditionFailed).Times(1)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   problem(http.StatusPreconditionFailed, "precondition_failed", "precondition failed"),
+		},
+		{
+			name:           "BadRequest - Null title",
+			ifMatch:        `"1"`,
+			contentType:    MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody:    `{"title":null}`,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "title cannot be null"),
+		},
+		{
+			name:           "BadRequest - Invalid status",
+			ifMatch:        `"1"`,
+			contentType:    MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody:    `{"status":"unknown"}`,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
 			expectedBody:   nil,
 		},
 		{
-			name:      "Not found",
-			companyID: "1",
-			taskID:    "2",
+			name:           "BadRequest - Not an object",
+			ifMatch:        `"1"`,
+			contentType:    MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody:    `["status"]`,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "invalid request"),
+		},
+		{
+			name:           "UnsupportedMediaType",
+			ifMatch:        `"1"`,
+			contentType:    echo.MIMETextPlain,
+			requestBody:    `{"status":"done"}`,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   problem(http.StatusUnsupportedMediaType, "unsupported_media_type", "unsupported media type"),
+		},
+		{
+			name:        "NotFound",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTaskByAdmin(uint(2)).Return(myErrors.ErrNotFound).Times(1)
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), gomock.Any(), &[]uint{1}[0], false).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
-			name:      "Internal server error",
-			companyID: "1",
-			taskID:    "1",
+			name:        "InternalServerError",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTaskByAdmin(uint(1)).Return(errors.New("internal error")).Times(1)
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), gomock.Any(), &[]uint{1}[0], false).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
 		},
+		{
+			name:        "Conflict - blocked by unfinished tasks",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
+			mockFunc: func() {
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), gomock.Any(), &[]uint{1}[0], false).Return(nil, myErrors.ErrTaskBlocked).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "task_blocked", "task is blocked by unfinished tasks"),
+		},
+		{
+			name:        "Success - forced",
+			query:       "force=true",
+			ifMatch:     `"1"`,
+			contentType: MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody: `{"status":"done"}`,
+			mockFunc: func() {
+				mockUseCase.EXPECT().PatchTask(gomock.Any(), uint(1), uint(3), uint(2), gomock.Any(), &[]uint{1}[0], true).
+					Return(&model.Task{ID: 3, Status: status, Version: 2}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedBody:   response.NewPatchTaskResponseBody(&model.Task{ID: 3, Status: status, Version: 2}),
+		},
+		{
+			name:           "BadRequest - Invalid force",
+			query:          "force=maybe",
+			ifMatch:        `"1"`,
+			contentType:    MIME_APPLICATION_MERGE_PATCH_JSON,
+			requestBody:    `{"status":"done"}`,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "query parameter is bad request"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/tasks/"+tc.taskID, nil)
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/companies/1/tasks/3?"+tc.query, strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+			if tc.ifMatch != "" {
+				req.Header.Set(HEADER_IF_MATCH, tc.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 			ctx.SetParamNames("company_id", "task_id")
-			ctx.SetParamValues(tc.companyID, tc.taskID)
+			ctx.SetParamValues("1", "3")
+			ctx.Set("user", &model.User{ID: 2})
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.DeleteTaskByAdmin(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			c := taskController
+			if tc.requireIfMatch {
+				c = ifMatchRequiredTaskController
+			}
+			if err := c.PatchTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedETag != "" {
+				assert.Equal(t, tc.expectedETag, rec.Header().Get(HEADER_ETAG))
+			}
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
 }
 
-func TestTaskController_DeleteTask(t *testing.T) {
+func TestTaskController_DeleteTaskByAdmin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
-	validate := validator.New()
-	taskController := NewTaskController(validate, mockUseCase)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
 
 	testCases := []struct {
 		name           string
 		companyID      string
 		taskID         string
-		userID         uint
 		mockFunc       func()
 		expectedStatus int
 		expectedBody   interface{}
@@ -1238,49 +1906,36 @@ func TestTaskController_DeleteTask(t *testing.T) {
 			name:      "Success",
 			companyID: "1",
 			taskID:    "2",
-			userID:    3,
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTask(uint(1), uint(2), uint(3)).Return(nil).Times(1)
+				mockUseCase.EXPECT().DeleteTaskByAdmin(gomock.Any(), uint(2), uint(99), model.SUBTASK_DELETE_POLICY_BLOCK).Return(nil).Times(1)
 			},
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusNoContent,
 			expectedBody:   nil,
 		},
 		{
 			name:           "Invalid task ID",
 			companyID:      "1",
 			taskID:         "invalid",
-			userID:         3,
-			mockFunc:       func() {},
-			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   nil,
-		},
-		{
-			name:           "Invalid company ID",
-			companyID:      "invalid",
-			taskID:         "2",
-			userID:         3,
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   nil,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
 		},
 		{
 			name:      "Not found",
 			companyID: "1",
 			taskID:    "2",
-			userID:    3,
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTask(uint(1), uint(2), uint(3)).Return(myErrors.ErrNotFound).Times(1)
+				mockUseCase.EXPECT().DeleteTaskByAdmin(gomock.Any(), uint(2), uint(99), model.SUBTASK_DELETE_POLICY_BLOCK).Return(myErrors.ErrNotFound).Times(1)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   map[string]string{"error": "not found"},
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
 		},
 		{
 			name:      "Internal server error",
 			companyID: "1",
-			taskID:    "2",
-			userID:    3,
+			taskID:    "1",
 			mockFunc: func() {
-				mockUseCase.EXPECT().DeleteTask(uint(1), uint(2), uint(3)).Return(errors.New("internal error")).Times(1)
+				mockUseCase.EXPECT().DeleteTaskByAdmin(gomock.Any(), uint(1), uint(99), model.SUBTASK_DELETE_POLICY_BLOCK).Return(errors.New("internal error")).Times(1)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   nil,
@@ -1290,7 +1945,146 @@ func TestTaskController_DeleteTask(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID, nil)
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/tasks/"+tc.taskID, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues(tc.companyID, tc.taskID)
+			ctx.Set("user", &model.User{ID: 99, Role: model.USER_ROLE_SYSTEM_OPERATOR})
+
+			tc.mockFunc()
+
+			if err := taskController.DeleteTaskByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_DeleteTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		taskID         string
+		userID         uint
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_BLOCK).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid task ID",
+			companyID:      "1",
+			taskID:         "invalid",
+			userID:         3,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			taskID:         "2",
+			userID:         3,
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_BLOCK).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Forbidden",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_BLOCK).Return(myErrors.ErrForbidden).Times(1)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   problem(http.StatusForbidden, "forbidden", "forbidden"),
+		},
+		{
+			name:      "Success - cascade",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			query:     "?subtask_policy=cascade",
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_CASCADE).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid subtask_policy",
+			companyID:      "1",
+			taskID:         "2",
+			userID:         3,
+			query:          "?subtask_policy=orphan",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:      "Conflict - has subtasks",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_BLOCK).Return(myErrors.ErrConflict).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problem(http.StatusConflict, "conflict", "task has subtasks"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			taskID:    "2",
+			userID:    3,
+			mockFunc: func() {
+				mockUseCase.EXPECT().DeleteTask(gomock.Any(), uint(1), uint(2), uint(3), model.SUBTASK_DELETE_POLICY_BLOCK).Return(errors.New("internal error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID+tc.query, nil)
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 			ctx.SetParamNames("company_id", "task_id")
@@ -1299,12 +2093,484 @@ func TestTaskController_DeleteTask(t *testing.T) {
 
 			tc.mockFunc()
 
-			if assert.NoError(t, taskController.DeleteTask(ctx)) {
-				assert.Equal(t, tc.expectedStatus, rec.Code)
-				if tc.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tc.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := taskController.DeleteTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_GetTrashedTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	trashedTasks := []*model.Task{{ID: 2, Title: "task", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			query:     "limit=10&offset=0",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTrashedTasks(gomock.Any(), uint(1), uint(3), 10, 0).Return(trashedTasks, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   response.NewGetTrashedTasksResponseBody(trashedTasks),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			companyID:      "1",
+			query:          "limit=21",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 20"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTrashedTasks(gomock.Any(), uint(1), uint(3), DEFAULT_TASK_LIMIT, DEFAULT_TASK_OFFSET).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks/trash?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskController.GetTrashedTasks(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_RestoreTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	restoredTask := &model.Task{ID: 2, Title: "task", Version: 4}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		taskID         string
+		mockFunc       func()
+		expectedStatus int
+		expectedETag   string
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RestoreTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(restoredTask, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedBody:   response.NewRestoreTaskResponseBody(restoredTask),
+		},
+		{
+			name:           "Invalid task ID",
+			companyID:      "1",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RestoreTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Forbidden",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RestoreTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrForbidden).Times(1)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   problem(http.StatusForbidden, "forbidden", "forbidden"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RestoreTask(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID+"/restore", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues(tc.companyID, tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskController.RestoreTask(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedETag, rec.Header().Get(HEADER_ETAG))
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_PurgeTaskByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	testCases := []struct {
+		name           string
+		taskID         string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().PurgeTaskByAdmin(gomock.Any(), uint(2)).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:   "Not found",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().PurgeTaskByAdmin(gomock.Any(), uint(2)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:   "Internal server error",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().PurgeTaskByAdmin(gomock.Any(), uint(2)).Return(errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/tasks/"+tc.taskID+"/purge", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("task_id")
+			ctx.SetParamValues(tc.taskID)
+
+			tc.mockFunc()
+
+			if err := taskController.PurgeTaskByAdmin(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_GetTaskHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	events := []*model.TaskEvent{{
+		ID:      5,
+		TaskID:  2,
+		ActorID: 9,
+		Action:  model.TASK_EVENT_ACTION_UPDATE,
+		ByAdmin: true,
+		Changes: map[string]model.TaskFieldChange{"status": {From: "pending", To: "done"}},
+		Actor:   &model.User{ID: 9, Username: "operator"},
+	}}
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		taskID         string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			companyID: "1",
+			taskID:    "2",
+			query:     "limit=5&offset=5",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskHistory(gomock.Any(), uint(1), uint(2), uint(3), 5, 5).Return(events, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"events": []map[string]interface{}{{
+					"id":         5,
+					"task_id":    2,
+					"action":     "update",
+					"by_admin":   true,
+					"changes":    map[string]interface{}{"status": map[string]interface{}{"from": "pending", "to": "done"}},
+					"created_at": nil,
+					"actor":      map[string]interface{}{"id": 9, "username": "operator"},
+				}},
+			},
+		},
+		{
+			name:           "Invalid task ID",
+			companyID:      "1",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			companyID:      "1",
+			taskID:         "2",
+			query:          "limit=21",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 20"),
+		},
+		{
+			name:      "Not found",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskHistory(gomock.Any(), uint(1), uint(2), uint(3), DEFAULT_TASK_LIMIT, DEFAULT_TASK_OFFSET).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:      "Internal server error",
+			companyID: "1",
+			taskID:    "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetTaskHistory(gomock.Any(), uint(1), uint(2), uint(3), DEFAULT_TASK_LIMIT, DEFAULT_TASK_OFFSET).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/"+tc.companyID+"/tasks/"+tc.taskID+"/history?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues(tc.companyID, tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskController.GetTaskHistory(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskController_GetSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockTaskUseCase(ctrl)
+	validate := NewValidator()
+	taskController := NewTaskController(validate, mockUseCase, false)
+
+	subtasks := []*model.Task{{
+		ID:           4,
+		CompanyID:    1,
+		ParentTaskID: &[]uint{2}[0],
+		CreateUserId: 3,
+		Title:        "Subtask",
+		Visibility:   "company",
+		Status:       "in_progress",
+		Version:      1,
+		Progress:     model.TaskProgress{SubtasksDone: 1, SubtasksTotal: 2, ChecklistDone: 0, ChecklistTotal: 3},
+	}}
+
+	testCases := []struct {
+		name           string
+		taskID         string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:   "Success",
+			taskID: "2",
+			query:  "limit=5&offset=5",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetSubtasks(gomock.Any(), uint(1), uint(2), uint(3), 5, 5).Return(subtasks, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"tasks": []map[string]interface{}{{
+					"id":              4,
+					"company_id":      1,
+					"parent_task_id":  2,
+					"assignee_id":     nil,
+					"create_user_id":  3,
+					"title":           "Subtask",
+					"description":     "",
+					"due_date":        nil,
+					"visibility":      "company",
+					"status":          "in_progress",
+					"recurrence_rule": nil,
+					"version":         1,
+					"created_at":      nil,
+					"updated_at":      nil,
+					"labels":          []interface{}{},
+					"progress": map[string]interface{}{
+						"subtasks_done":   1,
+						"subtasks_total":  2,
+						"checklist_done":  0,
+						"checklist_total": 3,
+					},
+				}},
+			},
+		},
+		{
+			name:           "Invalid task ID",
+			taskID:         "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "task_id is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			taskID:         "2",
+			query:          "limit=21",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 20"),
+		},
+		{
+			name:   "Not found",
+			taskID: "2",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetSubtasks(gomock.Any(), uint(1), uint(2), uint(3), DEFAULT_TASK_LIMIT, DEFAULT_TASK_OFFSET).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/tasks/"+tc.taskID+"/subtasks?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "task_id")
+			ctx.SetParamValues("1", tc.taskID)
+			ctx.Set("user", &model.User{ID: 3})
+
+			tc.mockFunc()
+
+			if err := taskController.GetSubtasks(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
 	"todo-api/controller/request"
 	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/model"
 	"todo-api/usecase"
 
 	"github.com/go-playground/validator/v10"
@@ -12,6 +16,7 @@ import (
 
 type UserController interface {
 	CreateUser(ctx echo.Context) error
+	CreateUserByAdmin(ctx echo.Context) error
 }
 
 type userController struct {
@@ -26,26 +31,42 @@ func NewUserController(validate *validator.Validate, userUseCase usecase.UserUse
 	}
 }
 
+// CreateUser は、ユーザを登録する。認証なしで誰でも登録できるため、ロールは常に一般ユーザとし、会社には所属させない。
+// 会社へは、会社の管理者がメンバーとして追加する
 func (c *userController) CreateUser(ctx echo.Context) error {
 	var requestBody request.CreateUserRequestBody
 	if err := ctx.Bind(&requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+		return myErrors.ErrBadRequest.WithMessage("invalid request")
 	}
 
 	// Validation
 	if err := c.validate.Struct(requestBody); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return validationError(ctx, err)
 	}
 
-	createdUser, err := c.userUseCase.CreateUser(
-		requestBody.Username,
-		requestBody.Email,
-		requestBody.Password,
-		requestBody.Role,
-		requestBody.CompanyIds,
-	)
+	return c.createUser(ctx, requestBody.Username, requestBody.Email, requestBody.Password, model.USER_ROLE_USER, nil)
+}
+
+// CreateUserByAdmin は、システムオペレーターがロールを指定してユーザを登録する
+func (c *userController) CreateUserByAdmin(ctx echo.Context) error {
+	var requestBody request.CreateUserByAdminRequestBody
+	if err := ctx.Bind(&requestBody); err != nil {
+		return myErrors.ErrBadRequest.WithMessage("invalid request")
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	return c.createUser(ctx, requestBody.Username, requestBody.Email, requestBody.Password, requestBody.Role, requestBody.CompanyIds)
+}
+
+func (c *userController) createUser(ctx echo.Context, username, email, password, role string, companyIds []uint) error {
+	createdUser, err := c.userUseCase.CreateUser(ctx.Request().Context(), username, email, password, role, companyIds)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "could not create user"})
+		slog.Info(fmt.Sprintf("error CreateUser: %v", err))
+		return myErrors.ErrInternal.WithMessage("could not create user")
 	}
 
 	return ctx.JSON(http.StatusCreated, response.NewCreateUserResponseBody(createdUser))
@@ -13,7 +13,6 @@ import (
 	mock_usecase "todo-api/mock/usecase"
 	"todo-api/model"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -24,14 +23,14 @@ func TestUserController_CreateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUseCase := mock_usecase.NewMockUserUseCase(ctrl)
-	validate := validator.New()
+	validate := controller.NewValidator()
 	userController := controller.NewUserController(validate, mockUseCase)
 
 	e := echo.New()
 
 	tests := []struct {
 		name           string
-		requestBody    request.CreateUserRequestBody
+		requestBody    interface{}
 		mockFunc       func()
 		expectedStatus int
 		expectedBody   interface{}
@@ -39,19 +38,72 @@ func TestUserController_CreateUser(t *testing.T) {
 		{
 			name: "Success",
 			requestBody: request.CreateUserRequestBody{
-				Username:   "testuser",
-				Email:      "test@example.com",
-				Password:   "password",
-				Role:       "user",
-				CompanyIds: []uint{1, 2},
+				Username: "testuser",
+				Email:    "test@example.com",
+				Password: "password",
 			},
 			mockFunc: func() {
 				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
 					"testuser",
 					"test@example.com",
 					"password",
 					"user",
-					[]uint{1, 2},
+					nil,
+				).Return(&model.User{ID: 1, Username: "testuser"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: &response.CreateUserResponseBody{
+				User: &response.CreateUserResponseBodyUser{
+					ID:       1,
+					Username: "testuser",
+				},
+			},
+		},
+		{
+			name: "Role is ignored",
+			requestBody: map[string]interface{}{
+				"username": "testuser",
+				"email":    "test@example.com",
+				"password": "password",
+				"role":     "system_operator",
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
+					"testuser",
+					"test@example.com",
+					"password",
+					model.USER_ROLE_USER,
+					nil,
+				).Return(&model.User{ID: 1, Username: "testuser", Role: model.USER_ROLE_USER}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: &response.CreateUserResponseBody{
+				User: &response.CreateUserResponseBodyUser{
+					ID:       1,
+					Username: "testuser",
+					Role:     model.USER_ROLE_USER,
+				},
+			},
+		},
+		{
+			// 誰でも登録できるため、任意の会社に所属させることはできない
+			name: "Company ids are ignored",
+			requestBody: map[string]interface{}{
+				"username":    "testuser",
+				"email":       "test@example.com",
+				"password":    "password",
+				"company_ids": []uint{1},
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
+					"testuser",
+					"test@example.com",
+					"password",
+					model.USER_ROLE_USER,
+					nil,
 				).Return(&model.User{ID: 1, Username: "testuser"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
@@ -68,32 +120,45 @@ func TestUserController_CreateUser(t *testing.T) {
 				Username: "",
 				Email:    "test@example.com",
 				Password: "password",
-				Role:     "user",
 			},
 			mockFunc:       func() {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   map[string]string{"error": "Key: 'CreateUserRequestBody.Username' Error:Field validation for 'Username' failed on the 'required' tag\nKey: 'CreateUserRequestBody.CompanyIds' Error:Field validation for 'CompanyIds' failed on the 'required' tag"},
+			expectedBody: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Bad Request",
+				"status": http.StatusBadRequest,
+				"code":   "validation_failed",
+				"detail": "request is invalid",
+				"errors": []map[string]string{
+					{"field": "username", "rule": "required", "message": "username is a required field"},
+				},
+			},
 		},
 		{
 			name: "UseCase Error",
 			requestBody: request.CreateUserRequestBody{
-				Username:   "testuser",
-				Email:      "test@example.com",
-				Password:   "password",
-				Role:       "user",
-				CompanyIds: []uint{1, 2},
+				Username: "testuser",
+				Email:    "test@example.com",
+				Password: "password",
 			},
 			mockFunc: func() {
 				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
 					"testuser",
 					"test@example.com",
 					"password",
 					"user",
-					[]uint{1, 2},
+					nil,
 				).Return(nil, errors.New("some error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   map[string]string{"error": "could not create user"},
+			expectedBody: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Internal Server Error",
+				"status": http.StatusInternalServerError,
+				"code":   "internal_server_error",
+				"detail": "could not create user",
+			},
 		},
 	}
 
@@ -107,12 +172,132 @@ func TestUserController_CreateUser(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 
-			if assert.NoError(t, userController.CreateUser(ctx)) {
-				assert.Equal(t, tt.expectedStatus, rec.Code)
-				if tt.expectedBody != nil {
-					expectedJSON, _ := json.Marshal(tt.expectedBody)
-					assert.JSONEq(t, string(expectedJSON), rec.Body.String())
-				}
+			if err := userController.CreateUser(ctx); err != nil {
+				controller.HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tt.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserController_CreateUserByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockUserUseCase(ctrl)
+	validate := controller.NewValidator()
+	userController := controller.NewUserController(validate, mockUseCase)
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    request.CreateUserByAdminRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name: "Success",
+			requestBody: request.CreateUserByAdminRequestBody{
+				Username:   "operator",
+				Email:      "operator@example.com",
+				Password:   "password",
+				Role:       "system_operator",
+				CompanyIds: []uint{1},
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
+					"operator",
+					"operator@example.com",
+					"password",
+					model.USER_ROLE_SYSTEM_OPERATOR,
+					[]uint{1},
+				).Return(&model.User{ID: 1, Username: "operator", Role: model.USER_ROLE_SYSTEM_OPERATOR}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: &response.CreateUserResponseBody{
+				User: &response.CreateUserResponseBodyUser{
+					ID:       1,
+					Username: "operator",
+					Role:     model.USER_ROLE_SYSTEM_OPERATOR,
+				},
+			},
+		},
+		{
+			name: "Validation Error",
+			requestBody: request.CreateUserByAdminRequestBody{
+				Username:   "operator",
+				Email:      "operator@example.com",
+				Password:   "password",
+				Role:       "admin",
+				CompanyIds: []uint{1},
+			},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Bad Request",
+				"status": http.StatusBadRequest,
+				"code":   "validation_failed",
+				"detail": "request is invalid",
+				"errors": []map[string]interface{}{
+					{"field": "role", "rule": "oneof", "message": "role must be one of [system_operator user]", "params": []string{"system_operator", "user"}},
+				},
+			},
+		},
+		{
+			name: "UseCase Error",
+			requestBody: request.CreateUserByAdminRequestBody{
+				Username:   "operator",
+				Email:      "operator@example.com",
+				Password:   "password",
+				Role:       "user",
+				CompanyIds: []uint{1},
+			},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateUser(
+					gomock.Any(),
+					"operator",
+					"operator@example.com",
+					"password",
+					model.USER_ROLE_USER,
+					[]uint{1},
+				).Return(nil, errors.New("some error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Internal Server Error",
+				"status": http.StatusInternalServerError,
+				"code":   "internal_server_error",
+				"detail": "could not create user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/admin/users", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			if err := userController.CreateUserByAdmin(ctx); err != nil {
+				controller.HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tt.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
 			}
 		})
 	}
@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	myErrors "todo-api/errors"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	jaTranslations "github.com/go-playground/validator/v10/translations/ja"
+	"github.com/labstack/echo/v4"
+)
+
+// 検証エラーのメッセージに対応する言語。Accept-Language で対応する言語が指定されていない場合は英語とする
+var validationTranslator = ut.New(en.New(), en.New(), ja.New())
+
+// 言語ごとの翻訳。validator の標準の翻訳にないルールと、翻訳がないルールのメッセージ (invalid) を加える
+var (
+	enValidationTranslator = newOverridingTranslator("en", map[string]string{
+		"http_url": "{0} must be a valid HTTP URL",
+		"timezone": "{0} must be a valid time zone",
+		"invalid":  "{0} is invalid",
+	})
+	jaValidationTranslator = newOverridingTranslator("ja", map[string]string{
+		"http_url": "{0}は正しいHTTPのURLでなければなりません",
+		"timezone": "{0}は正しいタイムゾーンでなければなりません",
+		"invalid":  "{0}は正しくありません",
+	})
+)
+
+// overridingTranslator は、既にある翻訳を上書きして登録する Translator。
+// validator の標準の翻訳は既にある翻訳を上書きしないため、validator を作成するたびに同じ翻訳に登録できるようにする
+type overridingTranslator struct {
+	ut.Translator
+	// messages は、validator の標準の翻訳にないルールのメッセージ
+	messages map[string]string
+}
+
+func newOverridingTranslator(locale string, messages map[string]string) *overridingTranslator {
+	translator, _ := validationTranslator.GetTranslator(locale)
+	return &overridingTranslator{Translator: translator, messages: messages}
+}
+
+func (t *overridingTranslator) Add(key interface{}, text string, _ bool) error {
+	return t.Translator.Add(key, text, true)
+}
+
+func (t *overridingTranslator) AddCardinal(key interface{}, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddCardinal(key, text, rule, true)
+}
+
+func (t *overridingTranslator) AddOrdinal(key interface{}, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddOrdinal(key, text, rule, true)
+}
+
+func (t *overridingTranslator) AddRange(key interface{}, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddRange(key, text, rule, true)
+}
+
+// register は、validate に標準の翻訳にないルールのメッセージを登録する
+func (t *overridingTranslator) register(validate *validator.Validate) error {
+	for tag, message := range t.messages {
+		if err := validate.RegisterTranslation(tag, t, func(trans ut.Translator) error {
+			return trans.Add(tag, message, true)
+		}, translateField); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateField は、検証に失敗したルールのメッセージを、項目名を埋め込んで返す
+func translateField(trans ut.Translator, fieldError validator.FieldError) string {
+	message, err := trans.T(fieldError.Tag(), fieldError.Field())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return message
+}
+
+// NewValidator は、リクエストボディとクエリパラメータの検証に使う validator を作成する。
+// 検証エラーの項目名は JSON (クエリパラメータは query タグ) の名前とし、メッセージは英語と日本語に翻訳する。
+// 翻訳の登録に失敗するのはプログラムの誤りのため、panic する。
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(fieldName)
+
+	if err := enTranslations.RegisterDefaultTranslations(validate, enValidationTranslator); err != nil {
+		panic(err)
+	}
+	if err := jaTranslations.RegisterDefaultTranslations(validate, jaValidationTranslator); err != nil {
+		panic(err)
+	}
+	for _, translator := range []*overridingTranslator{enValidationTranslator, jaValidationTranslator} {
+		if err := translator.register(validate); err != nil {
+			panic(err)
+		}
+	}
+	return validate
+}
+
+// fieldName は、検証エラーの項目名として、json、query、param タグの名前を返す。タグがない場合はフィールド名とする
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// validationError は、入力値の検証のエラーを、検証に失敗した項目を含むエラーにする。
+// メッセージはリクエストの Accept-Language の言語に翻訳する。
+// validator 以外のエラーは、その内容を説明とする不正なリクエストのエラーにする。
+func validationError(ctx echo.Context, err error) error {
+	fields, ok := validationFieldErrors(requestTranslator(ctx.Request()), err)
+	if !ok {
+		return myErrors.ErrBadRequest.WithMessage(err.Error())
+	}
+	return myErrors.NewValidationError(fields)
+}
+
+// validationFieldErrors は、validator のエラーを、translator の言語のメッセージを持つ項目ごとのエラーにする。
+// validator 以外のエラーの場合は false を返す。
+func validationFieldErrors(translator ut.Translator, err error) ([]*myErrors.FieldError, bool) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, false
+	}
+
+	fields := []*myErrors.FieldError{}
+	for _, fieldError := range validationErrors {
+		// 名前空間の先頭は構造体の名前のため除く (例: CreateTaskRequestBody.title → title)
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		// 翻訳がないルールは、Translate が validator の英語のメッセージを返す
+		message := fieldError.Translate(translator)
+		if message == fieldError.Error() {
+			message, _ = translator.T("invalid", fieldError.Field())
+		}
+		var params []string
+		if fieldError.Param() != "" {
+			params = strings.Fields(fieldError.Param())
+		}
+		fields = append(fields, &myErrors.FieldError{
+			Field:   field,
+			Rule:    fieldError.Tag(),
+			Message: message,
+			Params:  params,
+		})
+	}
+	return fields, true
+}
+
+// requestTranslator は、リクエストの Accept-Language で最も優先される、対応している言語の翻訳を返す
+func requestTranslator(req *http.Request) ut.Translator {
+	for _, tag := range acceptLanguages(req.Header.Get("Accept-Language")) {
+		switch strings.ToLower(tag) {
+		case "en":
+			return enValidationTranslator
+		case "ja":
+			return jaValidationTranslator
+		}
+	}
+	return enValidationTranslator
+}
+
+// acceptLanguages は、Accept-Language ヘッダの言語を優先される順に返す。
+// 地域を指定した言語 (例: ja-JP) の後には、地域を除いた言語 (例: ja) を加える。
+func acceptLanguages(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+	var languages []language
+	for _, value := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(value, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		// q=0 は、その言語を受け付けないことを示す
+		if quality <= 0 {
+			continue
+		}
+		languages = append(languages, language{tag: tag, quality: quality})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := []string{}
+	for _, language := range languages {
+		tag := strings.ReplaceAll(language.tag, "-", "_")
+		tags = append(tags, tag)
+		if base, _, ok := strings.Cut(tag, "_"); ok {
+			tags = append(tags, base)
+		}
+	}
+	return tags
+}
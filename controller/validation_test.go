@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todo-api/controller/request"
+	myErrors "todo-api/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	validate := NewValidator()
+	enabled := true
+	// title のみが足りないリクエストボディ
+	requestBody := &request.CreateTaskRequestBody{Description: "description", Visibility: "company", Status: "pending"}
+
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		requestBody    interface{}
+		expectedFields []*myErrors.FieldError
+	}{
+		{
+			name:        "English by default",
+			requestBody: requestBody,
+			expectedFields: []*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+			},
+		},
+		{
+			name:           "Japanese",
+			acceptLanguage: "ja",
+			requestBody:    requestBody,
+			expectedFields: []*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "titleは必須フィールドです"},
+			},
+		},
+		{
+			name:           "Japanese with region",
+			acceptLanguage: "ja-JP,en;q=0.5",
+			requestBody:    requestBody,
+			expectedFields: []*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "titleは必須フィールドです"},
+			},
+		},
+		{
+			name:           "Preferred by quality",
+			acceptLanguage: "ja;q=0.3,en-US;q=0.8",
+			requestBody:    requestBody,
+			expectedFields: []*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+			},
+		},
+		{
+			name:           "Unsupported language",
+			acceptLanguage: "fr-FR,de;q=0.9",
+			requestBody:    requestBody,
+			expectedFields: []*myErrors.FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+			},
+		},
+		{
+			name:           "Params",
+			acceptLanguage: "ja",
+			requestBody:    &request.CreateCompanyUserRequestBody{UserID: 1, Role: "admin"},
+			expectedFields: []*myErrors.FieldError{
+				{Field: "role", Rule: "oneof", Message: "roleは[owner manager member viewer]のうちのいずれかでなければなりません", Params: []string{"owner", "manager", "member", "viewer"}},
+			},
+		},
+		{
+			name:        "Nested field",
+			requestBody: &request.UpdateNotificationPreferencesRequestBody{Preferences: []*request.UpdateNotificationPreferencesRequestBodyPreference{{Type: "task_assigned", Enabled: &enabled}, {Type: "task_assigned"}}},
+			expectedFields: []*myErrors.FieldError{
+				{Field: "preferences[1].enabled", Rule: "required", Message: "enabled is a required field"},
+			},
+		},
+		{
+			name:           "Rule without default translation",
+			acceptLanguage: "ja",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "ftp://example.com", Secret: "0123456789abcdef", EventTypes: []string{"task.created"}},
+			expectedFields: []*myErrors.FieldError{
+				{Field: "url", Rule: "http_url", Message: "urlは正しいHTTPのURLでなければなりません"},
+			},
+		},
+		{
+			name:           "Query parameter",
+			acceptLanguage: "en",
+			requestBody:    &request.SearchTasksRequestQuery{},
+			expectedFields: []*myErrors.FieldError{
+				{Field: "q", Rule: "required", Message: "q is a required field"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			ctx := e.NewContext(req, httptest.NewRecorder())
+
+			err := validationError(ctx, validate.Struct(tc.requestBody))
+
+			var appErr *myErrors.Error
+			if assert.ErrorAs(t, err, &appErr) {
+				assert.Equal(t, myErrors.ErrValidation.Code, appErr.Code)
+				assert.Equal(t, tc.expectedFields, appErr.Fields)
+			}
+		})
+	}
+}
+
+func TestValidationError_NotValidatorError(t *testing.T) {
+	e := echo.New()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+	err := validationError(ctx, errors.New("title cannot be null"))
+
+	assert.ErrorIs(t, err, myErrors.ErrBadRequest)
+	assert.Equal(t, "title cannot be null", err.Error())
+}
+
+func TestAcceptLanguages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{name: "Empty", header: "", expected: []string{}},
+		{name: "Single", header: "ja", expected: []string{"ja"}},
+		{name: "Region", header: "en-US", expected: []string{"en_US", "en"}},
+		{name: "Quality", header: "en;q=0.5, ja-JP, fr;q=0.8", expected: []string{"ja_JP", "ja", "fr", "en"}},
+		{name: "Wildcard and not acceptable", header: "*, en;q=0, ja;q=0.1", expected: []string{"ja"}},
+		{name: "Invalid quality", header: "en;q=high, ja", expected: []string{"ja"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, acceptLanguages(tc.header))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	"todo-api/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// Webhookの配信のMAX取得制限数
+	MAX_WEBHOOK_DELIVERY_LIMIT = 100
+
+	// Webhookの配信のデフォルトの取得制限数
+	DEFAULT_WEBHOOK_DELIVERY_LIMIT = 20
+
+	// Webhookの配信のデフォルトのオフセット値
+	DEFAULT_WEBHOOK_DELIVERY_OFFSET = 0
+)
+
+type WebhookController interface {
+	GetWebhooks(ctx echo.Context) error
+	CreateWebhook(ctx echo.Context) error
+	UpdateWebhook(ctx echo.Context) error
+	DeleteWebhook(ctx echo.Context) error
+	GetWebhookDeliveries(ctx echo.Context) error
+	RedeliverWebhookDelivery(ctx echo.Context) error
+}
+
+type webhookController struct {
+	validate       *validator.Validate
+	webhookUseCase usecase.WebhookUseCase
+}
+
+func NewWebhookController(validate *validator.Validate, webhookUseCase usecase.WebhookUseCase) WebhookController {
+	return &webhookController{
+		validate:       validate,
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+func (c *webhookController) GetWebhooks(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	webhooks, err := c.webhookUseCase.GetWebhooks(ctx.Request().Context(), uint(companyId))
+	if err != nil {
+		slog.Info(fmt.Sprintf("error GetWebhooks: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetWebhooksResponseBody(webhooks))
+}
+
+func (c *webhookController) CreateWebhook(ctx echo.Context) error {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+
+	requestBody := &request.CreateWebhookRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	webhook, err := c.webhookUseCase.CreateWebhook(ctx.Request().Context(), request.NewWebhookFromCreateWebhookRequestBody(uint(companyId), requestBody))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("url must point to a public address")
+		}
+
+		slog.Info(fmt.Sprintf("error CreateWebhook: %v", err))
+		return myErrors.ErrInternal.WithMessage("Failed to create webhook")
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewCreateWebhookResponseBody(webhook))
+}
+
+func (c *webhookController) UpdateWebhook(ctx echo.Context) error {
+	companyId, webhookId, err := parseCompanyIdAndWebhookId(ctx)
+	if err != nil {
+		return err
+	}
+
+	requestBody := &request.UpdateWebhookRequestBody{}
+	if err := ctx.Bind(requestBody); err != nil {
+		return err
+	}
+
+	// Validation
+	if err := c.validate.Struct(requestBody); err != nil {
+		return validationError(ctx, err)
+	}
+
+	webhook, err := c.webhookUseCase.UpdateWebhook(ctx.Request().Context(), request.NewWebhookFromUpdateWebhookRequestBody(webhookId, companyId, requestBody))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+		if errors.Is(err, myErrors.ErrInvalidArgument) {
+			return myErrors.ErrInvalidArgument.WithMessage("url must point to a public address")
+		}
+
+		slog.Info(fmt.Sprintf("error UpdateWebhook: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewUpdateWebhookResponseBody(webhook))
+}
+
+func (c *webhookController) DeleteWebhook(ctx echo.Context) error {
+	companyId, webhookId, err := parseCompanyIdAndWebhookId(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.webhookUseCase.DeleteWebhook(ctx.Request().Context(), companyId, webhookId)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error DeleteWebhook: %v", err))
+		return myErrors.ErrInternal
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *webhookController) GetWebhookDeliveries(ctx echo.Context) error {
+	companyId, webhookId, err := parseCompanyIdAndWebhookId(ctx)
+	if err != nil {
+		return err
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil {
+		limit = DEFAULT_WEBHOOK_DELIVERY_LIMIT
+	}
+	// リミットが最大許容値を超えないようにする
+	if limit > MAX_WEBHOOK_DELIVERY_LIMIT {
+		return myErrors.ErrBadRequest.WithMessage(fmt.Sprintf("Limit exceeds the maximum allowed value of %d", MAX_WEBHOOK_DELIVERY_LIMIT))
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil {
+		offset = DEFAULT_WEBHOOK_DELIVERY_OFFSET
+	}
+	// 負の値では取得範囲を決められないため拒否する
+	if limit < 1 || offset < 0 {
+		return myErrors.ErrBadRequest.WithMessage("Limit must be at least 1 and offset must not be negative")
+	}
+
+	deliveries, err := c.webhookUseCase.GetWebhookDeliveries(ctx.Request().Context(), companyId, webhookId, limit, offset)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error GetWebhookDeliveries: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusOK, response.NewGetWebhookDeliveriesResponseBody(deliveries))
+}
+
+// RedeliverWebhookDelivery は、配信したイベントの再送を受け付ける。送信は非同期で行うため 202 を返す。
+func (c *webhookController) RedeliverWebhookDelivery(ctx echo.Context) error {
+	companyId, webhookId, err := parseCompanyIdAndWebhookId(ctx)
+	if err != nil {
+		return err
+	}
+	deliveryId, err := strconv.ParseUint(ctx.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return myErrors.ErrBadRequest.WithMessage("delivery_id is bad request")
+	}
+
+	delivery, err := c.webhookUseCase.RedeliverWebhookDelivery(ctx.Request().Context(), companyId, webhookId, uint(deliveryId))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return myErrors.ErrNotFound
+		}
+
+		slog.Info(fmt.Sprintf("error RedeliverWebhookDelivery: %v", err))
+		return myErrors.ErrInternal
+	}
+	return ctx.JSON(http.StatusAccepted, response.NewRedeliverWebhookDeliveryResponseBody(delivery))
+}
+
+func parseCompanyIdAndWebhookId(ctx echo.Context) (uint, uint, error) {
+	companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
+	if err != nil {
+		return 0, 0, myErrors.ErrBadRequest.WithMessage("company_id is bad request")
+	}
+	webhookId, err := strconv.ParseUint(ctx.Param("webhook_id"), 10, 64)
+	if err != nil {
+		return 0, 0, myErrors.ErrBadRequest.WithMessage("webhook_id is bad request")
+	}
+	return uint(companyId), uint(webhookId), nil
+}
@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todo-api/controller/request"
+	"todo-api/controller/response"
+	myErrors "todo-api/errors"
+	mock_usecase "todo-api/mock/usecase"
+	"todo-api/model"
+	"todo-api/webhook"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookController_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockWebhookUseCase(ctrl)
+	validate := NewValidator()
+	webhookController := NewWebhookController(validate, mockUseCase)
+
+	inactive := false
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		companyID      string
+		requestBody    *request.CreateWebhookRequestBody
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:        "Success",
+			companyID:   "1",
+			requestBody: &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created", "task.updated"}},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateWebhook(gomock.Any(), &model.Webhook{CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created", "task.updated"}, Active: true}).
+					Return(&model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created", "task.updated"}, Active: true, CreatedAt: &createdAt, UpdatedAt: &createdAt}, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			// シークレットはレスポンスに含めない
+			expectedBody: map[string]interface{}{
+				"webhook": map[string]interface{}{
+					"id": 2, "company_id": 1, "url": "https://example.com/hook", "event_types": []string{"task.created", "task.updated"},
+					"active": true, "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:        "Success - inactive",
+			companyID:   "1",
+			requestBody: &request.CreateWebhookRequestBody{URL: "http://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.deleted"}, Active: &inactive},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateWebhook(gomock.Any(), &model.Webhook{CompanyID: 1, URL: "http://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.deleted"}, Active: false}).
+					Return(&model.Webhook{ID: 2, CompanyID: 1, URL: "http://example.com/hook", EventTypes: []string{"task.deleted"}}, nil).Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   response.NewCreateWebhookResponseBody(&model.Webhook{ID: 2, CompanyID: 1, URL: "http://example.com/hook", EventTypes: []string{"task.deleted"}}),
+		},
+		{
+			name:           "Invalid company ID",
+			companyID:      "invalid",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created"}},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "company_id is bad request"),
+		},
+		{
+			name:           "Invalid URL",
+			companyID:      "1",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "ftp://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created"}},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Private destination",
+			companyID:   "1",
+			requestBody: &request.CreateWebhookRequestBody{URL: "http://169.254.169.254/latest", Secret: "secret-secret-secret", EventTypes: []string{"task.created"}},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrInvalidArgument.Wrap(webhook.ErrDestinationNotAllowed)).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "invalid_argument", "url must point to a public address"),
+		},
+		{
+			name:           "Short secret",
+			companyID:      "1",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret", EventTypes: []string{"task.created"}},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Invalid event type",
+			companyID:      "1",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.archived"}},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:           "Duplicate event types",
+			companyID:      "1",
+			requestBody:    &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created", "task.created"}},
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name:        "Internal server error",
+			companyID:   "1",
+			requestBody: &request.CreateWebhookRequestBody{URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{"task.created"}},
+			mockFunc: func() {
+				mockUseCase.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   problem(http.StatusInternalServerError, "internal_server_error", "Failed to create webhook"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			reqBody, err := json.Marshal(tc.requestBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request body: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/"+tc.companyID+"/webhooks", bytes.NewBuffer(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id")
+			ctx.SetParamValues(tc.companyID)
+
+			tc.mockFunc()
+
+			if err := webhookController.CreateWebhook(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookController_GetWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockWebhookUseCase(ctrl)
+	validate := NewValidator()
+	webhookController := NewWebhookController(validate, mockUseCase)
+
+	responseStatus := 500
+	errorMessage := "unexpected response status: 500"
+	lastAttemptAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	nextAttemptAt := time.Date(2024, time.January, 1, 0, 1, 0, 0, time.UTC)
+	deliveries := []*model.WebhookDelivery{
+		{
+			ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_PENDING, Attempts: 1,
+			NextAttemptAt: &nextAttemptAt, LastAttemptAt: &lastAttemptAt, ResponseStatus: &responseStatus, Error: &errorMessage, CreatedAt: &lastAttemptAt,
+			WebhookEvent: &model.WebhookEvent{ID: 4, Type: model.WEBHOOK_EVENT_TYPE_TASK_CREATED},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		webhookID      string
+		query          string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:      "Success",
+			webhookID: "2",
+			query:     "",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetWebhookDeliveries(gomock.Any(), uint(1), uint(2), DEFAULT_WEBHOOK_DELIVERY_LIMIT, DEFAULT_WEBHOOK_DELIVERY_OFFSET).Return(deliveries, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"deliveries": []map[string]interface{}{
+					{
+						"id": 3, "event_id": 4, "event_type": "task.created", "redelivery": false, "status": "pending", "attempts": 1,
+						"response_status": 500, "error": "unexpected response status: 500",
+						"next_attempt_at": "2024-01-01T00:01:00Z", "last_attempt_at": "2024-01-01T00:00:00Z", "created_at": "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+		{
+			name:           "Invalid webhook ID",
+			webhookID:      "invalid",
+			query:          "",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "webhook_id is bad request"),
+		},
+		{
+			name:           "Limit exceeds maximum",
+			webhookID:      "2",
+			query:          "?limit=101",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "Limit exceeds the maximum allowed value of 100"),
+		},
+		{
+			name:      "Webhook not found",
+			webhookID: "2",
+			query:     "?limit=10&offset=10",
+			mockFunc: func() {
+				mockUseCase.EXPECT().GetWebhookDeliveries(gomock.Any(), uint(1), uint(2), 10, 10).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/companies/1/webhooks/"+tc.webhookID+"/deliveries"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "webhook_id")
+			ctx.SetParamValues("1", tc.webhookID)
+
+			tc.mockFunc()
+
+			if err := webhookController.GetWebhookDeliveries(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookController_RedeliverWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mock_usecase.NewMockWebhookUseCase(ctrl)
+	validate := NewValidator()
+	webhookController := NewWebhookController(validate, mockUseCase)
+
+	nextAttemptAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		deliveryID     string
+		mockFunc       func()
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			name:       "Success",
+			deliveryID: "3",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RedeliverWebhookDelivery(gomock.Any(), uint(1), uint(2), uint(3)).Return(&model.WebhookDelivery{
+					ID: 5, WebhookID: 2, WebhookEventID: 4, Redelivery: true, Status: model.WEBHOOK_DELIVERY_STATUS_PENDING, NextAttemptAt: &nextAttemptAt,
+					WebhookEvent: &model.WebhookEvent{ID: 4, Type: model.WEBHOOK_EVENT_TYPE_TASK_UPDATED},
+				}, nil).Times(1)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]interface{}{
+				"delivery": map[string]interface{}{
+					"id": 5, "event_id": 4, "event_type": "task.updated", "redelivery": true, "status": "pending", "next_attempt_at": "2024-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:           "Invalid delivery ID",
+			deliveryID:     "invalid",
+			mockFunc:       func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problem(http.StatusBadRequest, "bad_request", "delivery_id is bad request"),
+		},
+		{
+			name:       "Delivery not found",
+			deliveryID: "3",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RedeliverWebhookDelivery(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problem(http.StatusNotFound, "not_found", "not found"),
+		},
+		{
+			name:       "Internal server error",
+			deliveryID: "3",
+			mockFunc: func() {
+				mockUseCase.EXPECT().RedeliverWebhookDelivery(gomock.Any(), uint(1), uint(2), uint(3)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/companies/1/webhooks/2/deliveries/"+tc.deliveryID+"/redeliver", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("company_id", "webhook_id", "delivery_id")
+			ctx.SetParamValues("1", "2", tc.deliveryID)
+
+			tc.mockFunc()
+
+			if err := webhookController.RedeliverWebhookDelivery(ctx); err != nil {
+				HTTPErrorHandler(err, ctx)
+			}
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				expectedJSON, _ := json.Marshal(tc.expectedBody)
+				assert.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
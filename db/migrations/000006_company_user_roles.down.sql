@@ -0,0 +1,3 @@
+UPDATE users SET role = 'admin' WHERE role = 'system_operator';
+
+ALTER TABLE company_users DROP COLUMN role;
\ No newline at end of file
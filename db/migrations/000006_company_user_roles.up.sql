@@ -0,0 +1,7 @@
+ALTER TABLE company_users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member' AFTER user_id;
+
+-- 既存の所属ユーザはこれまで全ての操作が可能だったため、オーナーとして移行する
+UPDATE company_users SET role = 'owner';
+
+-- 全テナントを管理するユーザは、会社のロールとは独立したシステムオペレーターとする
+UPDATE users SET role = 'system_operator' WHERE role = 'admin';
\ No newline at end of file
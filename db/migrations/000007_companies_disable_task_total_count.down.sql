@@ -0,0 +1 @@
+ALTER TABLE companies DROP COLUMN disable_task_total_count;
\ No newline at end of file
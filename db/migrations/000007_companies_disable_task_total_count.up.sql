@@ -0,0 +1 @@
+ALTER TABLE companies ADD COLUMN disable_task_total_count BOOLEAN NOT NULL DEFAULT FALSE AFTER name;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP INDEX ft_tasks_title_description;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE companies DROP COLUMN trash_retention_days;
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+ALTER TABLE tasks DROP INDEX idx_tasks_deleted_at, DROP COLUMN deleted_at;
\ No newline at end of file
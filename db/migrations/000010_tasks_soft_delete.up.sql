@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME NULL AFTER updated_at, ADD INDEX idx_tasks_deleted_at (deleted_at);
+
+ALTER TABLE companies ADD COLUMN trash_retention_days INT UNSIGNED NOT NULL DEFAULT 30 AFTER disable_task_total_count;
\ No newline at end of file
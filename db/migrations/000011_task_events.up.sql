@@ -0,0 +1,14 @@
+CREATE TABLE task_events (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    company_id INT NOT NULL,
+    actor_id INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    by_admin BOOLEAN NOT NULL DEFAULT FALSE,
+    changes JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users (id),
+    INDEX (task_id, created_at)
+);
\ No newline at end of file
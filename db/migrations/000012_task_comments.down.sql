@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_comments;
\ No newline at end of file
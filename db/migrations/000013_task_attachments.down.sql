@@ -0,0 +1,3 @@
+ALTER TABLE companies DROP COLUMN attachment_quota_bytes;
+
+DROP TABLE IF EXISTS task_attachments;
\ No newline at end of file
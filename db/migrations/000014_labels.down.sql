@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_labels;
+DROP TABLE IF EXISTS labels;
\ No newline at end of file
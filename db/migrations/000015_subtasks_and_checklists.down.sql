@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_checklist_items;
+
+ALTER TABLE tasks DROP FOREIGN KEY fk_tasks_parent_task_id, DROP COLUMN parent_task_id;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE task_dependencies (
+    task_id INT NOT NULL,
+    blocked_by_task_id INT NOT NULL,
+    company_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, blocked_by_task_id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_by_task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    INDEX (blocked_by_task_id),
+    INDEX (company_id)
+);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_recurrence_next_due_at,
+    DROP COLUMN recurrence_next_due_at,
+    DROP COLUMN recurrence_index,
+    DROP COLUMN recurrence_start_at,
+    DROP COLUMN recurrence_rule;
+
+ALTER TABLE companies DROP COLUMN time_zone;
\ No newline at end of file
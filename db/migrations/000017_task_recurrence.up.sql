@@ -0,0 +1,7 @@
+ALTER TABLE companies ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' AFTER name;
+
+ALTER TABLE tasks ADD COLUMN recurrence_rule VARCHAR(255) NULL AFTER status,
+    ADD COLUMN recurrence_start_at DATETIME NULL AFTER recurrence_rule,
+    ADD COLUMN recurrence_index INT NOT NULL DEFAULT 0 AFTER recurrence_start_at,
+    ADD COLUMN recurrence_next_due_at DATETIME NULL AFTER recurrence_index,
+    ADD INDEX idx_tasks_recurrence_next_due_at (recurrence_next_due_at);
\ No newline at end of file
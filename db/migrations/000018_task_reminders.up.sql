@@ -0,0 +1,8 @@
+CREATE TABLE task_reminders (
+    task_id INT NOT NULL,
+    due_date DATETIME NOT NULL,
+    window_seconds INT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, due_date, window_seconds),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,94 @@
+package errors
+
+// Kind は、エラーの分類。HTTP のレスポンスのステータスコードは分類で決まる
+type Kind int
+
+const (
+	// 内部エラー (500)
+	KindInternal Kind = iota
+	// リクエストの形式や値が不正 (400)
+	KindBadRequest
+	// 入力値の検証に失敗 (400)
+	KindValidation
+	// 認証されていない (401)
+	KindUnauthorized
+	// 権限がない (403)
+	KindForbidden
+	// リソースが見つからない (404)
+	KindNotFound
+	// リソースの状態と競合する (409)
+	KindConflict
+	// 更新の前提条件を満たさない (412)
+	KindPreconditionFailed
+	// 更新の前提条件が指定されていない (428)
+	KindPreconditionRequired
+	// 容量の上限を超える (413)
+	KindPayloadTooLarge
+	// 許可されていない形式 (415)
+	KindUnsupportedMediaType
+	// 一時的に処理できない (503)
+	KindUnavailable
+)
+
+// Error は、アプリケーションのエラー。
+// Code は機械可読なコードで、Code が同じエラーは errors.Is で一致する。Message は利用者向けの説明とする。
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	// Fields は、入力値の検証に失敗した項目
+	Fields []*FieldError
+	// Err は、原因となったエラー。利用者には返さない
+	Err error
+}
+
+// FieldError は、入力値の検証に失敗した1つの項目。
+// Field は JSON の項目名、Rule は失敗した検証のルール、Params はルールの引数 (例: max=255 の 255) とする
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+	Params  []string
+}
+
+// New は、kind に分類されるエラーを作成する
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NewValidationError は、fields の項目の入力値の検証に失敗したことを示すエラーを作成する
+func NewValidationError(fields []*FieldError) *Error {
+	e := ErrValidation.WithMessage("request is invalid")
+	e.Fields = fields
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage は、利用者向けの説明を message にしたエラーを返す
+func (e *Error) WithMessage(message string) *Error {
+	copied := *e
+	copied.Message = message
+	return &copied
+}
+
+// Wrap は、原因となったエラーを err にしたエラーを返す
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
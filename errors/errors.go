@@ -1,11 +1,72 @@
 package errors
 
-import "errors"
-
 var (
 	// データベースエラー
-	ErrDb = errors.New("db error")
+	ErrDb = New(KindInternal, "db_error", "db error")
+
+	// 内部エラー
+	ErrInternal = New(KindInternal, "internal_server_error", "internal server error")
+
+	// 一時的に処理できないことを示すエラー
+	ErrUnavailable = New(KindUnavailable, "service_unavailable", "service unavailable")
+
+	// リクエストの処理が時間の上限を超えたことを示すエラー
+	ErrTimeout = New(KindUnavailable, "timeout", "request timed out")
+
+	// リクエストの形式が不正であることを示すエラー
+	ErrBadRequest = New(KindBadRequest, "bad_request", "bad request")
+
+	// 入力値の検証に失敗したことを示すエラー
+	ErrValidation = New(KindValidation, "validation_failed", "validation failed")
+
+	// 認証されていないことを示すエラー
+	ErrUnauthorized = New(KindUnauthorized, "unauthorized", "unauthorized")
 
 	// リソースが見つからなかったことを示すエラー
-	ErrNotFound = errors.New("not found")
+	ErrNotFound = New(KindNotFound, "not_found", "not found")
+
+	// ユーザ名またはパスワードが正しくないことを示すエラー
+	ErrInvalidCredentials = New(KindUnauthorized, "invalid_credentials", "invalid credentials")
+
+	// トークンが不正、期限切れ、または失効済みであることを示すエラー
+	ErrInvalidToken = New(KindUnauthorized, "invalid_token", "invalid token")
+
+	// リソースが既に存在することを示すエラー
+	ErrConflict = New(KindConflict, "conflict", "conflict")
+
+	// 操作する権限がないことを示すエラー
+	ErrForbidden = New(KindForbidden, "forbidden", "forbidden")
+
+	// 引数が業務ルールに違反していることを示すエラー
+	ErrInvalidArgument = New(KindBadRequest, "invalid_argument", "invalid argument")
+
+	// 更新対象のバージョンが一致しないことを示すエラー
+	ErrPreconditionFailed = New(KindPreconditionFailed, "precondition_failed", "precondition failed")
+
+	// 更新の前提とするバージョンが指定されていないことを示すエラー
+	ErrPreconditionRequired = New(KindPreconditionRequired, "precondition_required", "precondition required")
+
+	// 容量の上限を超えることを示すエラー
+	ErrQuotaExceeded = New(KindPayloadTooLarge, "quota_exceeded", "quota exceeded")
+
+	// リクエストの大きさが上限を超えることを示すエラー
+	ErrPayloadTooLarge = New(KindPayloadTooLarge, "payload_too_large", "payload too large")
+
+	// 許可されていないファイル形式であることを示すエラー
+	ErrUnsupportedMediaType = New(KindUnsupportedMediaType, "unsupported_media_type", "unsupported media type")
+
+	// 親タスクに指定できないタスクであることを示すエラー
+	ErrInvalidParentTask = New(KindBadRequest, "invalid_parent_task", "invalid parent task")
+
+	// タスクの階層が深さの上限を超えることを示すエラー
+	ErrTaskDepthExceeded = New(KindBadRequest, "task_depth_exceeded", "task depth exceeded")
+
+	// 未完了のタスクに妨げられているタスクを完了にしようとしたことを示すエラー
+	ErrTaskBlocked = New(KindConflict, "task_blocked", "task blocked")
+
+	// 繰り返しのルールが不正、または繰り返しに必要な期限がないことを示すエラー
+	ErrInvalidRecurrence = New(KindBadRequest, "invalid_recurrence", "invalid recurrence")
+
+	// 依存関係が循環することを示すエラー
+	ErrDependencyCycle = New(KindConflict, "dependency_cycle", "dependency cycle")
 )
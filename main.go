@@ -3,12 +3,21 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
+	_ "time/tzdata"
 	"todo-api/config"
+	"todo-api/middleware"
+	"todo-api/realtime"
+	"todo-api/repository"
 	"todo-api/routes"
+	"todo-api/usecase"
+	"todo-api/webhook"
+	"todo-api/worker"
 
 	"github.com/labstack/echo/v4"
 )
@@ -17,12 +26,93 @@ func main() {
 	slog.Info("starting server")
 
 	db := config.InitDB()
+	attachmentStorage := config.InitStorage()
+	taskNotifier := config.InitNotifier()
+	webhookSender := webhook.NewHTTPSender(webhook.DEFAULT_HTTP_SENDER_TIMEOUT)
+	broker := realtime.NewHub(realtime.NewLocalTransport(), realtime.DEFAULT_REPLAY_BUFFER_SIZE)
+
+	// リクエストの処理時間の上限は REQUEST_TIMEOUT (例: 15s) で変更できる。0 の場合は上限を設けない
+	requestTimeout, err := time.ParseDuration(os.Getenv("REQUEST_TIMEOUT"))
+	if err != nil || requestTimeout < 0 {
+		requestTimeout = middleware.DEFAULT_REQUEST_TIMEOUT
+	}
+
+	// タスクの更新で If-Match ヘッダーを必須とするかは REQUIRE_IF_MATCH (例: true) で変更できる。既定では必須としない
+	requireIfMatch, err := strconv.ParseBool(os.Getenv("REQUIRE_IF_MATCH"))
+	if err != nil {
+		requireIfMatch = false
+	}
 
 	e := echo.New()
-	routes.RegisterRoutes(e, db)
+	routes.RegisterRoutes(e, db, attachmentStorage, webhookSender, broker, requestTimeout, requireIfMatch)
+
+	// 停止時に終わらなかったリクエストのクエリを中断できるよう、リクエストのコンテキストの元をキャンセルできるようにする
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	e.Server.BaseContext = func(net.Listener) context.Context {
+		return requestCtx
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
+
+	taskUseCase := usecase.NewTaskUseCase(
+		repository.NewTaskRepository(db),
+		repository.NewTaskSearchRepository(db),
+		repository.NewTaskEventRepository(db),
+		repository.NewLabelRepository(db),
+		repository.NewTaskDependencyRepository(db),
+		repository.NewCompanyRepository(db),
+		repository.NewCompanyUserRepository(db),
+		repository.NewNotificationRepository(db),
+		broker,
+		attachmentStorage,
+	)
+
+	// ゴミ箱のタスクの削除間隔は TASK_TRASH_SWEEP_INTERVAL (例: 30m) で変更できる
+	sweepInterval, err := time.ParseDuration(os.Getenv("TASK_TRASH_SWEEP_INTERVAL"))
+	if err != nil || sweepInterval <= 0 {
+		sweepInterval = worker.DEFAULT_TASK_TRASH_SWEEP_INTERVAL
+	}
+	go worker.NewTaskTrashSweeper(taskUseCase, sweepInterval).Run(ctx)
+
+	// 繰り返しのタスクの作成間隔は TASK_RECURRENCE_INTERVAL、事前に作成する期間は TASK_RECURRENCE_HORIZON (例: 72h) で変更できる
+	recurrenceInterval, err := time.ParseDuration(os.Getenv("TASK_RECURRENCE_INTERVAL"))
+	if err != nil || recurrenceInterval <= 0 {
+		recurrenceInterval = worker.DEFAULT_TASK_RECURRENCE_INTERVAL
+	}
+	recurrenceHorizon, err := time.ParseDuration(os.Getenv("TASK_RECURRENCE_HORIZON"))
+	if err != nil || recurrenceHorizon < 0 {
+		recurrenceHorizon = worker.DEFAULT_TASK_RECURRENCE_HORIZON
+	}
+	go worker.NewTaskRecurrenceScheduler(taskUseCase, recurrenceInterval, recurrenceHorizon).Run(ctx)
+
+	// リマインダーの確認間隔は TASK_REMINDER_INTERVAL、期限の何時間前に送るかは TASK_REMINDER_WINDOWS (例: 48h,3h) で変更できる
+	reminderInterval, err := time.ParseDuration(os.Getenv("TASK_REMINDER_INTERVAL"))
+	if err != nil || reminderInterval <= 0 {
+		reminderInterval = worker.DEFAULT_TASK_REMINDER_INTERVAL
+	}
+	reminderWindows, err := worker.ParseTaskReminderWindows(os.Getenv("TASK_REMINDER_WINDOWS"))
+	if err != nil {
+		reminderWindows = worker.DEFAULT_TASK_REMINDER_WINDOWS
+	}
+	taskReminderUseCase := usecase.NewTaskReminderUseCase(
+		repository.NewTaskReminderRepository(db),
+		repository.NewCompanyRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewNotificationRepository(db),
+		taskNotifier,
+	)
+	go worker.NewTaskReminderScheduler(taskReminderUseCase, reminderInterval, reminderWindows).Run(ctx)
+
+	// Webhook の配信の確認間隔は WEBHOOK_DISPATCH_INTERVAL (例: 30s) で変更できる
+	webhookInterval, err := time.ParseDuration(os.Getenv("WEBHOOK_DISPATCH_INTERVAL"))
+	if err != nil || webhookInterval <= 0 {
+		webhookInterval = worker.DEFAULT_WEBHOOK_DISPATCH_INTERVAL
+	}
+	webhookUseCase := usecase.NewWebhookUseCase(repository.NewWebhookRepository(db), webhookSender)
+	go worker.NewWebhookDispatcher(webhookUseCase, webhookInterval).Run(ctx)
+
 	// Start server
 	go func() {
 		if err := e.Start(":8080"); err != nil && err != http.ErrServerClosed {
@@ -32,9 +122,18 @@ func main() {
 
 	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
 	<-ctx.Done()
+	// イベントの配信中の接続は終了しないため、先に購読を終了させる
+	broker.Close()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := e.Shutdown(ctx); err != nil {
-		e.Logger.Fatal(err)
+		// 時間内に終わらなかったリクエストは、コンテキストをキャンセルしてクエリを中断させ、
+		// トランザクションがロールバックされるのを少し待ってから終了する
+		cancelRequests()
+		abortCtx, cancelAbort := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancelAbort()
+		if err := e.Shutdown(abortCtx); err != nil {
+			e.Logger.Fatal(err)
+		}
 	}
 }
@@ -1,54 +1,115 @@
 package middleware
 
 import (
-	"net/http"
+	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	myErrors "todo-api/errors"
 	"todo-api/repository"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
 )
 
+// WebSocket の接続時に、Sec-WebSocket-Protocol ヘッダでアクセストークンを送る場合のプレフィックス
+const WEBSOCKET_TOKEN_PROTOCOL_PREFIX = "todo-api.bearer."
+
 // 認証ミドルウェア
-func Auth(userRepository repository.UserRepository) echo.MiddlewareFunc {
+// アクセストークンに紐づくログインセッションが失効している場合は拒否する
+func Auth(
+	userRepository repository.UserRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+) echo.MiddlewareFunc {
+	return authenticate(userRepository, refreshTokenRepository, authorizationToken)
+}
+
+// WebSocket の接続用の認証ミドルウェア
+// ブラウザの WebSocket は Authorization ヘッダを送れないため、Sec-WebSocket-Protocol ヘッダの
+// "todo-api.bearer.<アクセストークン>" からもアクセストークンを受け取る。トークンの検証は Auth と同じ
+func WebSocketAuth(
+	userRepository repository.UserRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+) echo.MiddlewareFunc {
+	return authenticate(userRepository, refreshTokenRepository, func(ctx echo.Context) string {
+		if tokenString := authorizationToken(ctx); tokenString != "" {
+			return tokenString
+		}
+		for _, protocol := range strings.Split(ctx.Request().Header.Get("Sec-WebSocket-Protocol"), ",") {
+			protocol = strings.TrimSpace(protocol)
+			if strings.HasPrefix(protocol, WEBSOCKET_TOKEN_PROTOCOL_PREFIX) {
+				return strings.TrimPrefix(protocol, WEBSOCKET_TOKEN_PROTOCOL_PREFIX)
+			}
+		}
+		return ""
+	})
+}
+
+// authorizationToken は、Authorization ヘッダからアクセストークンを取得する
+func authorizationToken(ctx echo.Context) string {
+	// "Bearer " プレフィックスを削除
+	return strings.TrimPrefix(ctx.Request().Header.Get("Authorization"), "Bearer ")
+}
+
+// authenticate は、tokenFunc で取得したアクセストークンを検証し、ユーザとログインセッションをコンテキストに設定する
+func authenticate(
+	userRepository repository.UserRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	tokenFunc func(ctx echo.Context) string,
+) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
-			tokenString := ctx.Request().Header.Get("Authorization")
+			tokenString := tokenFunc(ctx)
 			if tokenString == "" {
-				return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing token"})
+				return myErrors.ErrUnauthorized.WithMessage("Missing token")
 			}
 
-			// "Bearer " プレフィックスを削除
-			tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
 			// JWT シークレットの取得
 			jwtSecret := os.Getenv("JWT_SECRET")
 			if jwtSecret == "" {
-				return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "JWT Secret not found"})
+				return myErrors.ErrInternal.WithMessage("JWT Secret not found")
 			}
 
 			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+					return nil, myErrors.ErrUnauthorized.WithMessage("Invalid token")
 				}
 				return []byte(jwtSecret), nil
 			})
 
 			if err != nil || !token.Valid {
-				return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token"})
+				return myErrors.ErrUnauthorized.WithMessage("Invalid token")
 			}
 
 			claims, ok := token.Claims.(jwt.MapClaims)
 			if !ok || !token.Valid {
-				return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token claims"})
+				return myErrors.ErrUnauthorized.WithMessage("Invalid token claims")
+			}
+			id, ok := claims["id"].(float64)
+			if !ok {
+				return myErrors.ErrUnauthorized.WithMessage("Invalid token claims")
 			}
-			id := claims["id"].(float64)
-			user, err := userRepository.GetUser(uint(id))
+			sessionId, ok := claims["sid"].(string)
+			if !ok {
+				return myErrors.ErrUnauthorized.WithMessage("Invalid token claims")
+			}
+
+			active, err := refreshTokenRepository.ExistsActiveRefreshToken(ctx.Request().Context(), sessionId)
+			if err != nil {
+				slog.Info(fmt.Sprintf("error Auth: %v", err))
+				return myErrors.ErrInternal.WithMessage("Failed to retrieve session")
+			}
+			if !active {
+				return myErrors.ErrUnauthorized.WithMessage("Session has been revoked")
+			}
+
+			user, err := userRepository.GetUser(ctx.Request().Context(), uint(id))
 			if err != nil {
-				return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve user"})
+				slog.Info(fmt.Sprintf("error Auth: %v", err))
+				return myErrors.ErrInternal.WithMessage("Failed to retrieve user")
 			}
 			ctx.Set("user", user)
+			ctx.Set("session_id", sessionId)
 
 			return next(ctx)
 		}
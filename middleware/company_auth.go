@@ -2,7 +2,8 @@ package middleware
 
 import (
 	"errors"
-	"net/http"
+	"fmt"
+	"log/slog"
 	"strconv"
 	myErrors "todo-api/errors"
 	"todo-api/model"
@@ -13,22 +14,40 @@ import (
 
 // Companyに関する認証ミドルウェア
 // ログインしているユーザがCompanyに所属していない場合、not found
+// 所属している場合、メンバー情報(ロールを含む)を "company_user" としてコンテキストに設定する
 func CompanyAuth(companyUserRepository repository.CompanyUserRepository) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			companyId, err := strconv.ParseUint(ctx.Param("company_id"), 10, 64)
 			if err != nil {
-				return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "company_id is bad request"})
+				return myErrors.ErrBadRequest.WithMessage("company_id is bad request")
 			}
 			user := ctx.Get("user").(*model.User)
 
-			_, err = companyUserRepository.GetCompanyUser(uint(companyId), uint(user.ID))
+			companyUser, err := companyUserRepository.GetCompanyUser(ctx.Request().Context(), uint(companyId), uint(user.ID))
 			if err != nil {
 				if errors.Is(err, myErrors.ErrNotFound) {
-					return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+					return myErrors.ErrNotFound
 				}
 
-				return ctx.JSON(http.StatusBadRequest, nil)
+				slog.Info(fmt.Sprintf("error CompanyAuth: %v", err))
+				return myErrors.ErrInternal
+			}
+			ctx.Set("company_user", companyUser)
+
+			return next(ctx)
+		}
+	}
+}
+
+// Companyのロールに関する認可ミドルウェア
+// CompanyAuth の後に使用し、ロールで action が許可されていない場合、forbidden
+func CompanyPermission(action model.Action) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			companyUser := ctx.Get("company_user").(*model.CompanyUser)
+			if !companyUser.Can(action) {
+				return myErrors.ErrForbidden
 			}
 
 			return next(ctx)
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// システムオペレーターチェックミドルウェア
+// 会社のロールに関わらず、システムオペレーター以外は拒否する
+func SystemOperatorAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			user := ctx.Get("user").(*model.User)
+			if !user.IsSystemOperator() {
+				return myErrors.ErrForbidden
+			}
+
+			return next(ctx)
+		}
+	}
+}
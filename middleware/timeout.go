@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// リクエストの処理時間の上限の既定値
+const DEFAULT_REQUEST_TIMEOUT = 10 * time.Second
+
+// リクエストの処理時間の上限の設定
+type TimeoutConfig struct {
+	// Routes にないルートの上限。0 の場合は上限を設けない
+	Default time.Duration
+	// ルートごとの上限。キーはメソッドとパスのパターン (例: "GET /api/v1/companies/:company_id/tasks/search")。0 の場合は上限を設けない
+	Routes map[string]time.Duration
+}
+
+// リクエストのコンテキストに処理時間の上限を設定するミドルウェア
+// 上限を超えるとデータベースへのクエリなどが中断され、ハンドラがエラーを返した場合は timeout とする
+// ルートのパスを使うため、ルーティングの後 (グループのミドルウェア) に適用する
+func Timeout(config TimeoutConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			timeout, ok := config.Routes[ctx.Request().Method+" "+ctx.Path()]
+			if !ok {
+				timeout = config.Default
+			}
+			if timeout <= 0 {
+				return next(ctx)
+			}
+
+			requestCtx, cancel := context.WithTimeout(ctx.Request().Context(), timeout)
+			defer cancel()
+			ctx.SetRequest(ctx.Request().WithContext(requestCtx))
+
+			err := next(ctx)
+			if err != nil && errors.Is(requestCtx.Err(), context.DeadlineExceeded) {
+				slog.Info(fmt.Sprintf("error Timeout %s %s: %v", ctx.Request().Method, ctx.Path(), err))
+				return myErrors.ErrTimeout
+			}
+			return err
+		}
+	}
+}
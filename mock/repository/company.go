@@ -10,6 +10,7 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
 	model "todo-api/model"
 
@@ -39,17 +40,92 @@ func (m *MockCompanyRepository) EXPECT() *MockCompanyRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateCompany mocks base method.
+func (m *MockCompanyRepository) CreateCompany(ctx context.Context, company *model.Company) (*model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompany", ctx, company)
+	ret0, _ := ret[0].(*model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompany indicates an expected call of CreateCompany.
+func (mr *MockCompanyRepositoryMockRecorder) CreateCompany(ctx, company any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockCompanyRepository)(nil).CreateCompany), ctx, company)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockCompanyRepository) DeleteCompany(ctx context.Context, id uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockCompanyRepositoryMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockCompanyRepository)(nil).DeleteCompany), ctx, id)
+}
+
+// GetCompanies mocks base method.
+func (m *MockCompanyRepository) GetCompanies(ctx context.Context, limit, offset int) ([]*model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanies", ctx, limit, offset)
+	ret0, _ := ret[0].([]*model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanies indicates an expected call of GetCompanies.
+func (mr *MockCompanyRepositoryMockRecorder) GetCompanies(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanies", reflect.TypeOf((*MockCompanyRepository)(nil).GetCompanies), ctx, limit, offset)
+}
+
+// GetCompaniesByUserId mocks base method.
+func (m *MockCompanyRepository) GetCompaniesByUserId(ctx context.Context, userId uint) ([]*model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompaniesByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompaniesByUserId indicates an expected call of GetCompaniesByUserId.
+func (mr *MockCompanyRepositoryMockRecorder) GetCompaniesByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesByUserId", reflect.TypeOf((*MockCompanyRepository)(nil).GetCompaniesByUserId), ctx, userId)
+}
+
 // GetCompany mocks base method.
-func (m *MockCompanyRepository) GetCompany(id uint) (*model.Company, error) {
+func (m *MockCompanyRepository) GetCompany(ctx context.Context, id uint) (*model.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCompany", id)
+	ret := m.ctrl.Call(m, "GetCompany", ctx, id)
 	ret0, _ := ret[0].(*model.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCompany indicates an expected call of GetCompany.
-func (mr *MockCompanyRepositoryMockRecorder) GetCompany(id any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) GetCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockCompanyRepository)(nil).GetCompany), ctx, id)
+}
+
+// UpdateCompany mocks base method.
+func (m *MockCompanyRepository) UpdateCompany(ctx context.Context, company *model.Company) (*model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", ctx, company)
+	ret0, _ := ret[0].(*model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockCompanyRepositoryMockRecorder) UpdateCompany(ctx, company any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockCompanyRepository)(nil).GetCompany), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockCompanyRepository)(nil).UpdateCompany), ctx, company)
 }
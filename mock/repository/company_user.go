@@ -10,6 +10,7 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
 	model "todo-api/model"
 
@@ -39,32 +40,91 @@ func (m *MockCompanyUserRepository) EXPECT() *MockCompanyUserRepositoryMockRecor
 	return m.recorder
 }
 
+// CountCompanyUsersByRole mocks base method.
+func (m *MockCompanyUserRepository) CountCompanyUsersByRole(ctx context.Context, companyId uint, role string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCompanyUsersByRole", ctx, companyId, role)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCompanyUsersByRole indicates an expected call of CountCompanyUsersByRole.
+func (mr *MockCompanyUserRepositoryMockRecorder) CountCompanyUsersByRole(ctx, companyId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCompanyUsersByRole", reflect.TypeOf((*MockCompanyUserRepository)(nil).CountCompanyUsersByRole), ctx, companyId, role)
+}
+
 // CreateCompanyUsers mocks base method.
-func (m *MockCompanyUserRepository) CreateCompanyUsers(companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error) {
+func (m *MockCompanyUserRepository) CreateCompanyUsers(ctx context.Context, companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCompanyUsers", companyUsers)
+	ret := m.ctrl.Call(m, "CreateCompanyUsers", ctx, companyUsers)
 	ret0, _ := ret[0].([]*model.CompanyUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCompanyUsers indicates an expected call of CreateCompanyUsers.
-func (mr *MockCompanyUserRepositoryMockRecorder) CreateCompanyUsers(companyUsers any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) CreateCompanyUsers(ctx, companyUsers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyUsers", reflect.TypeOf((*MockCompanyUserRepository)(nil).CreateCompanyUsers), companyUsers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyUsers", reflect.TypeOf((*MockCompanyUserRepository)(nil).CreateCompanyUsers), ctx, companyUsers)
+}
+
+// DeleteCompanyUser mocks base method.
+func (m *MockCompanyUserRepository) DeleteCompanyUser(ctx context.Context, companyId, userId uint, reassigneeId *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompanyUser", ctx, companyId, userId, reassigneeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompanyUser indicates an expected call of DeleteCompanyUser.
+func (mr *MockCompanyUserRepositoryMockRecorder) DeleteCompanyUser(ctx, companyId, userId, reassigneeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompanyUser", reflect.TypeOf((*MockCompanyUserRepository)(nil).DeleteCompanyUser), ctx, companyId, userId, reassigneeId)
 }
 
 // GetCompanyUser mocks base method.
-func (m *MockCompanyUserRepository) GetCompanyUser(companyId, userId uint) (*model.CompanyUser, error) {
+func (m *MockCompanyUserRepository) GetCompanyUser(ctx context.Context, companyId, userId uint) (*model.CompanyUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCompanyUser", companyId, userId)
+	ret := m.ctrl.Call(m, "GetCompanyUser", ctx, companyId, userId)
 	ret0, _ := ret[0].(*model.CompanyUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCompanyUser indicates an expected call of GetCompanyUser.
-func (mr *MockCompanyUserRepositoryMockRecorder) GetCompanyUser(companyId, userId any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) GetCompanyUser(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUser", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetCompanyUser), ctx, companyId, userId)
+}
+
+// GetCompanyUsers mocks base method.
+func (m *MockCompanyUserRepository) GetCompanyUsers(ctx context.Context, companyId uint) ([]*model.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyUsers", ctx, companyId)
+	ret0, _ := ret[0].([]*model.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyUsers indicates an expected call of GetCompanyUsers.
+func (mr *MockCompanyUserRepositoryMockRecorder) GetCompanyUsers(ctx, companyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUsers", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetCompanyUsers), ctx, companyId)
+}
+
+// UpdateCompanyUser mocks base method.
+func (m *MockCompanyUserRepository) UpdateCompanyUser(ctx context.Context, companyUser *model.CompanyUser) (*model.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompanyUser", ctx, companyUser)
+	ret0, _ := ret[0].(*model.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompanyUser indicates an expected call of UpdateCompanyUser.
+func (mr *MockCompanyUserRepositoryMockRecorder) UpdateCompanyUser(ctx, companyUser any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUser", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetCompanyUser), companyId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanyUser", reflect.TypeOf((*MockCompanyUserRepository)(nil).UpdateCompanyUser), ctx, companyUser)
 }
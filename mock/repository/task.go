@@ -10,7 +10,9 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 	model "todo-api/model"
 
 	gomock "go.uber.org/mock/gomock"
@@ -39,120 +41,344 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountTasks mocks base method.
+func (m *MockTaskRepository) CountTasks(ctx context.Context, filter *model.TaskFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasks", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasks indicates an expected call of CountTasks.
+func (mr *MockTaskRepositoryMockRecorder) CountTasks(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasks", reflect.TypeOf((*MockTaskRepository)(nil).CountTasks), ctx, filter)
+}
+
+// CountTasksByCompanyId mocks base method.
+func (m *MockTaskRepository) CountTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksByCompanyId", ctx, companyId, createUserId, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksByCompanyId indicates an expected call of CountTasksByCompanyId.
+func (mr *MockTaskRepositoryMockRecorder) CountTasksByCompanyId(ctx, companyId, createUserId, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksByCompanyId", reflect.TypeOf((*MockTaskRepository)(nil).CountTasksByCompanyId), ctx, companyId, createUserId, filter)
+}
+
 // CreateTask mocks base method.
-func (m *MockTaskRepository) CreateTask(task *model.Task) (*model.Task, error) {
+func (m *MockTaskRepository) CreateTask(ctx context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", task)
+	ret := m.ctrl.Call(m, "CreateTask", ctx, task, event, newWebhookEvent)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskRepositoryMockRecorder) CreateTask(task any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task, event, newWebhookEvent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task, event, newWebhookEvent)
+}
+
+// CreateTaskOccurrence mocks base method.
+func (m *MockTaskRepository) CreateTaskOccurrence(ctx context.Context, id uint, next *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskOccurrence", ctx, id, next, event, newWebhookEvent)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskOccurrence indicates an expected call of CreateTaskOccurrence.
+func (mr *MockTaskRepositoryMockRecorder) CreateTaskOccurrence(ctx, id, next, event, newWebhookEvent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskOccurrence", reflect.TypeOf((*MockTaskRepository)(nil).CreateTaskOccurrence), ctx, id, next, event, newWebhookEvent)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTaskRepository) DeleteTask(companyId, id uint) error {
+func (m *MockTaskRepository) DeleteTask(ctx context.Context, companyId, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", companyId, id)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, companyId, id, event, webhookEvents)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskRepositoryMockRecorder) DeleteTask(companyId, id any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) DeleteTask(ctx, companyId, id, event, webhookEvents any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), companyId, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), ctx, companyId, id, event, webhookEvents)
 }
 
 // DeleteTaskById mocks base method.
-func (m *MockTaskRepository) DeleteTaskById(id uint) error {
+func (m *MockTaskRepository) DeleteTaskById(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTaskById", id)
+	ret := m.ctrl.Call(m, "DeleteTaskById", ctx, id, event, webhookEvents)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTaskById indicates an expected call of DeleteTaskById.
-func (mr *MockTaskRepositoryMockRecorder) DeleteTaskById(id any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) DeleteTaskById(ctx, id, event, webhookEvents any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskById", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTaskById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskById", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTaskById), ctx, id, event, webhookEvents)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTaskRepository) GetSubtasks(ctx context.Context, companyId, parentId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", ctx, companyId, parentId, createUserId, limit, offset)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTaskRepositoryMockRecorder) GetSubtasks(ctx, companyId, parentId, createUserId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTaskRepository)(nil).GetSubtasks), ctx, companyId, parentId, createUserId, limit, offset)
 }
 
 // GetTask mocks base method.
-func (m *MockTaskRepository) GetTask(companyId, id, createUserId uint) (*model.Task, error) {
+func (m *MockTaskRepository) GetTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", companyId, id, createUserId)
+	ret := m.ctrl.Call(m, "GetTask", ctx, companyId, id, createUserId)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockTaskRepositoryMockRecorder) GetTask(companyId, id, createUserId any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTask(ctx, companyId, id, createUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTask), ctx, companyId, id, createUserId)
+}
+
+// GetTaskAncestorIds mocks base method.
+func (m *MockTaskRepository) GetTaskAncestorIds(ctx context.Context, id uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAncestorIds", ctx, id)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAncestorIds indicates an expected call of GetTaskAncestorIds.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskAncestorIds(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTask), companyId, id, createUserId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAncestorIds", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskAncestorIds), ctx, id)
 }
 
 // GetTaskById mocks base method.
-func (m *MockTaskRepository) GetTaskById(id uint) (*model.Task, error) {
+func (m *MockTaskRepository) GetTaskById(ctx context.Context, id uint) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskById", id)
+	ret := m.ctrl.Call(m, "GetTaskById", ctx, id)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskById indicates an expected call of GetTaskById.
-func (mr *MockTaskRepositoryMockRecorder) GetTaskById(id any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTaskById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskById), ctx, id)
+}
+
+// GetTaskDescendants mocks base method.
+func (m *MockTaskRepository) GetTaskDescendants(ctx context.Context, id uint) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskDescendants", ctx, id)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskDescendants indicates an expected call of GetTaskDescendants.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskDescendants(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskDescendants", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskDescendants), ctx, id)
+}
+
+// GetTaskSubtreeHeight mocks base method.
+func (m *MockTaskRepository) GetTaskSubtreeHeight(ctx context.Context, id uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskSubtreeHeight", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskSubtreeHeight indicates an expected call of GetTaskSubtreeHeight.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskSubtreeHeight(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskSubtreeHeight", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskSubtreeHeight), ctx, id)
 }
 
 // GetTasks mocks base method.
-func (m *MockTaskRepository) GetTasks(limit, offset int) ([]*model.Task, error) {
+func (m *MockTaskRepository) GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", limit, offset)
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, pagination)
 	ret0, _ := ret[0].([]*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTaskRepositoryMockRecorder) GetTasks(limit, offset any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTasks(ctx, filter, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetTasks), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetTasks), ctx, filter, pagination)
 }
 
 // GetTasksByCompanyId mocks base method.
-func (m *MockTaskRepository) GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+func (m *MockTaskRepository) GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByCompanyId", companyId, createUserId, limit, offset)
+	ret := m.ctrl.Call(m, "GetTasksByCompanyId", ctx, companyId, createUserId, filter, pagination)
 	ret0, _ := ret[0].([]*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByCompanyId indicates an expected call of GetTasksByCompanyId.
-func (mr *MockTaskRepositoryMockRecorder) GetTasksByCompanyId(companyId, createUserId, limit, offset any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetTasksByCompanyId(ctx, companyId, createUserId, filter, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByCompanyId", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksByCompanyId), ctx, companyId, createUserId, filter, pagination)
+}
+
+// GetTasksWithDueRecurrence mocks base method.
+func (m *MockTaskRepository) GetTasksWithDueRecurrence(ctx context.Context, until time.Time, limit int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksWithDueRecurrence", ctx, until, limit)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksWithDueRecurrence indicates an expected call of GetTasksWithDueRecurrence.
+func (mr *MockTaskRepositoryMockRecorder) GetTasksWithDueRecurrence(ctx, until, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksWithDueRecurrence", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksWithDueRecurrence), ctx, until, limit)
+}
+
+// GetTrashedSubtasks mocks base method.
+func (m *MockTaskRepository) GetTrashedSubtasks(ctx context.Context, id uint) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedSubtasks", ctx, id)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedSubtasks indicates an expected call of GetTrashedSubtasks.
+func (mr *MockTaskRepositoryMockRecorder) GetTrashedSubtasks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedSubtasks", reflect.TypeOf((*MockTaskRepository)(nil).GetTrashedSubtasks), ctx, id)
+}
+
+// GetTrashedTask mocks base method.
+func (m *MockTaskRepository) GetTrashedTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTask", ctx, companyId, id, createUserId)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedTask indicates an expected call of GetTrashedTask.
+func (mr *MockTaskRepositoryMockRecorder) GetTrashedTask(ctx, companyId, id, createUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTrashedTask), ctx, companyId, id, createUserId)
+}
+
+// GetTrashedTasksByCompanyId mocks base method.
+func (m *MockTaskRepository) GetTrashedTasksByCompanyId(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTasksByCompanyId", ctx, companyId, createUserId, limit, offset)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedTasksByCompanyId indicates an expected call of GetTrashedTasksByCompanyId.
+func (mr *MockTaskRepositoryMockRecorder) GetTrashedTasksByCompanyId(ctx, companyId, createUserId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTasksByCompanyId", reflect.TypeOf((*MockTaskRepository)(nil).GetTrashedTasksByCompanyId), ctx, companyId, createUserId, limit, offset)
+}
+
+// PatchTask mocks base method.
+func (m *MockTaskRepository) PatchTask(ctx context.Context, id, version uint, patch *model.TaskPatch, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, id, version, patch, event, webhookEvents)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTaskRepositoryMockRecorder) PatchTask(ctx, id, version, patch, event, webhookEvents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTaskRepository)(nil).PatchTask), ctx, id, version, patch, event, webhookEvents)
+}
+
+// PurgeExpiredTasks mocks base method.
+func (m *MockTaskRepository) PurgeExpiredTasks(ctx context.Context) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredTasks", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeExpiredTasks indicates an expected call of PurgeExpiredTasks.
+func (mr *MockTaskRepositoryMockRecorder) PurgeExpiredTasks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredTasks", reflect.TypeOf((*MockTaskRepository)(nil).PurgeExpiredTasks), ctx)
+}
+
+// PurgeTask mocks base method.
+func (m *MockTaskRepository) PurgeTask(ctx context.Context, id uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTask", ctx, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTask indicates an expected call of PurgeTask.
+func (mr *MockTaskRepositoryMockRecorder) PurgeTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTask", reflect.TypeOf((*MockTaskRepository)(nil).PurgeTask), ctx, id)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTaskRepository) RestoreTask(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id, event, webhookEvents)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskRepositoryMockRecorder) RestoreTask(ctx, id, event, webhookEvents any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByCompanyId", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksByCompanyId), companyId, createUserId, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTaskRepository)(nil).RestoreTask), ctx, id, event, webhookEvents)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTaskRepository) UpdateTask(id uint, task *model.Task) (*model.Task, error) {
+func (m *MockTaskRepository) UpdateTask(ctx context.Context, id uint, task *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", id, task)
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, task, event, webhookEvents)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskRepositoryMockRecorder) UpdateTask(id, task any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, id, task, event, webhookEvents any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), id, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, id, task, event, webhookEvents)
 }
@@ -10,7 +10,9 @@
 package mock_usecase
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 	model "todo-api/model"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,134 +42,268 @@ func (m *MockTaskUseCase) EXPECT() *MockTaskUseCaseMockRecorder {
 }
 
 // CreateTask mocks base method.
-func (m *MockTaskUseCase) CreateTask(task *model.Task) (*model.Task, error) {
+func (m *MockTaskUseCase) CreateTask(ctx context.Context, task *model.Task) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", task)
+	ret := m.ctrl.Call(m, "CreateTask", ctx, task)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskUseCaseMockRecorder) CreateTask(task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) CreateTask(ctx, task any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTask), ctx, task)
 }
 
 // CreateTaskByAdmin mocks base method.
-func (m *MockTaskUseCase) CreateTaskByAdmin(task *model.Task) (*model.Task, error) {
+func (m *MockTaskUseCase) CreateTaskByAdmin(ctx context.Context, task *model.Task) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTaskByAdmin", task)
+	ret := m.ctrl.Call(m, "CreateTaskByAdmin", ctx, task)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTaskByAdmin indicates an expected call of CreateTaskByAdmin.
-func (mr *MockTaskUseCaseMockRecorder) CreateTaskByAdmin(task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) CreateTaskByAdmin(ctx, task any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTaskByAdmin), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).CreateTaskByAdmin), ctx, task)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTaskUseCase) DeleteTask(companyId, taskId, createUserId uint) error {
+func (m *MockTaskUseCase) DeleteTask(ctx context.Context, companyId, taskId, createUserId uint, subtaskPolicy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", companyId, taskId, createUserId)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, companyId, taskId, createUserId, subtaskPolicy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskUseCaseMockRecorder) DeleteTask(companyId, taskId, createUserId any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) DeleteTask(ctx, companyId, taskId, createUserId, subtaskPolicy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTask), companyId, taskId, createUserId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTask), ctx, companyId, taskId, createUserId, subtaskPolicy)
 }
 
 // DeleteTaskByAdmin mocks base method.
-func (m *MockTaskUseCase) DeleteTaskByAdmin(taskId uint) error {
+func (m *MockTaskUseCase) DeleteTaskByAdmin(ctx context.Context, taskId, actorId uint, subtaskPolicy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTaskByAdmin", taskId)
+	ret := m.ctrl.Call(m, "DeleteTaskByAdmin", ctx, taskId, actorId, subtaskPolicy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTaskByAdmin indicates an expected call of DeleteTaskByAdmin.
-func (mr *MockTaskUseCaseMockRecorder) DeleteTaskByAdmin(taskId any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) DeleteTaskByAdmin(ctx, taskId, actorId, subtaskPolicy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTaskByAdmin), taskId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).DeleteTaskByAdmin), ctx, taskId, actorId, subtaskPolicy)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTaskUseCase) GetSubtasks(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", ctx, companyId, taskId, createUserId, limit, offset)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTaskUseCaseMockRecorder) GetSubtasks(ctx, companyId, taskId, createUserId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetSubtasks), ctx, companyId, taskId, createUserId, limit, offset)
 }
 
 // GetTask mocks base method.
-func (m *MockTaskUseCase) GetTask(companyId, taskId, createUserId uint) (*model.Task, error) {
+func (m *MockTaskUseCase) GetTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", companyId, taskId, createUserId)
+	ret := m.ctrl.Call(m, "GetTask", ctx, companyId, taskId, createUserId)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockTaskUseCaseMockRecorder) GetTask(companyId, taskId, createUserId any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTask(ctx, companyId, taskId, createUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), ctx, companyId, taskId, createUserId)
+}
+
+// GetTaskHistory mocks base method.
+func (m *MockTaskUseCase) GetTaskHistory(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", ctx, companyId, taskId, createUserId, limit, offset)
+	ret0, _ := ret[0].([]*model.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockTaskUseCaseMockRecorder) GetTaskHistory(ctx, companyId, taskId, createUserId, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), companyId, taskId, createUserId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockTaskUseCase)(nil).GetTaskHistory), ctx, companyId, taskId, createUserId, limit, offset)
 }
 
 // GetTasks mocks base method.
-func (m *MockTaskUseCase) GetTasks(limit, offset int) ([]*model.Task, error) {
+func (m *MockTaskUseCase) GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", limit, offset)
-	ret0, _ := ret[0].([]*model.Task)
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, pagination)
+	ret0, _ := ret[0].(*model.TaskPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTaskUseCaseMockRecorder) GetTasks(limit, offset any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTasks(ctx, filter, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasks), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasks), ctx, filter, pagination)
 }
 
 // GetTasksByCompanyId mocks base method.
-func (m *MockTaskUseCase) GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+func (m *MockTaskUseCase) GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByCompanyId", companyId, createUserId, limit, offset)
-	ret0, _ := ret[0].([]*model.Task)
+	ret := m.ctrl.Call(m, "GetTasksByCompanyId", ctx, companyId, createUserId, filter, pagination)
+	ret0, _ := ret[0].(*model.TaskPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByCompanyId indicates an expected call of GetTasksByCompanyId.
-func (mr *MockTaskUseCaseMockRecorder) GetTasksByCompanyId(companyId, createUserId, limit, offset any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTasksByCompanyId(ctx, companyId, createUserId, filter, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByCompanyId", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasksByCompanyId), ctx, companyId, createUserId, filter, pagination)
+}
+
+// GetTrashedTasks mocks base method.
+func (m *MockTaskUseCase) GetTrashedTasks(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTasks", ctx, companyId, createUserId, limit, offset)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedTasks indicates an expected call of GetTrashedTasks.
+func (mr *MockTaskUseCaseMockRecorder) GetTrashedTasks(ctx, companyId, createUserId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTasks", reflect.TypeOf((*MockTaskUseCase)(nil).GetTrashedTasks), ctx, companyId, createUserId, limit, offset)
+}
+
+// MaterializeTaskOccurrences mocks base method.
+func (m *MockTaskUseCase) MaterializeTaskOccurrences(ctx context.Context, now, until time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeTaskOccurrences", ctx, now, until)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaterializeTaskOccurrences indicates an expected call of MaterializeTaskOccurrences.
+func (mr *MockTaskUseCaseMockRecorder) MaterializeTaskOccurrences(ctx, now, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeTaskOccurrences", reflect.TypeOf((*MockTaskUseCase)(nil).MaterializeTaskOccurrences), ctx, now, until)
+}
+
+// PatchTask mocks base method.
+func (m *MockTaskUseCase) PatchTask(ctx context.Context, companyId, taskId, createUserId uint, patch *model.TaskPatch, version *uint, force bool) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, companyId, taskId, createUserId, patch, version, force)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTaskUseCaseMockRecorder) PatchTask(ctx, companyId, taskId, createUserId, patch, version, force any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTaskUseCase)(nil).PatchTask), ctx, companyId, taskId, createUserId, patch, version, force)
+}
+
+// PurgeExpiredTasks mocks base method.
+func (m *MockTaskUseCase) PurgeExpiredTasks(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredTasks", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredTasks indicates an expected call of PurgeExpiredTasks.
+func (mr *MockTaskUseCaseMockRecorder) PurgeExpiredTasks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredTasks", reflect.TypeOf((*MockTaskUseCase)(nil).PurgeExpiredTasks), ctx)
+}
+
+// PurgeTaskByAdmin mocks base method.
+func (m *MockTaskUseCase) PurgeTaskByAdmin(ctx context.Context, taskId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTaskByAdmin", ctx, taskId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTaskByAdmin indicates an expected call of PurgeTaskByAdmin.
+func (mr *MockTaskUseCaseMockRecorder) PurgeTaskByAdmin(ctx, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).PurgeTaskByAdmin), ctx, taskId)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTaskUseCase) RestoreTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, companyId, taskId, createUserId)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskUseCaseMockRecorder) RestoreTask(ctx, companyId, taskId, createUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTaskUseCase)(nil).RestoreTask), ctx, companyId, taskId, createUserId)
+}
+
+// SearchTasks mocks base method.
+func (m *MockTaskUseCase) SearchTasks(ctx context.Context, companyId, createUserId uint, query string, limit, offset int) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", ctx, companyId, createUserId, query, limit, offset)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockTaskUseCaseMockRecorder) SearchTasks(ctx, companyId, createUserId, query, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByCompanyId", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasksByCompanyId), companyId, createUserId, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockTaskUseCase)(nil).SearchTasks), ctx, companyId, createUserId, query, limit, offset)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTaskUseCase) UpdateTask(companyId, taskId, createUserId uint, task *model.Task) (*model.Task, error) {
+func (m *MockTaskUseCase) UpdateTask(ctx context.Context, companyId, taskId, createUserId uint, task *model.Task, version *uint, force bool) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", companyId, taskId, createUserId, task)
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, companyId, taskId, createUserId, task, version, force)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskUseCaseMockRecorder) UpdateTask(companyId, taskId, createUserId, task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) UpdateTask(ctx, companyId, taskId, createUserId, task, version, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTask), companyId, taskId, createUserId, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTask), ctx, companyId, taskId, createUserId, task, version, force)
 }
 
 // UpdateTaskByAdmin mocks base method.
-func (m *MockTaskUseCase) UpdateTaskByAdmin(taskId uint, task *model.Task) (*model.Task, error) {
+func (m *MockTaskUseCase) UpdateTaskByAdmin(ctx context.Context, taskId, actorId uint, task *model.Task, version *uint, force bool) (*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskByAdmin", taskId, task)
+	ret := m.ctrl.Call(m, "UpdateTaskByAdmin", ctx, taskId, actorId, task, version, force)
 	ret0, _ := ret[0].(*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTaskByAdmin indicates an expected call of UpdateTaskByAdmin.
-func (mr *MockTaskUseCaseMockRecorder) UpdateTaskByAdmin(taskId, task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) UpdateTaskByAdmin(ctx, taskId, actorId, task, version, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTaskByAdmin), taskId, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskByAdmin", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTaskByAdmin), ctx, taskId, actorId, task, version, force)
 }
@@ -10,6 +10,7 @@
 package mock_usecase
 
 import (
+	context "context"
 	reflect "reflect"
 	model "todo-api/model"
 
@@ -40,16 +41,16 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 }
 
 // CreateUser mocks base method.
-func (m *MockUserUseCase) CreateUser(username, email, password, role string, companyIds []uint) (*model.User, error) {
+func (m *MockUserUseCase) CreateUser(ctx context.Context, username, email, password, role string, companyIds []uint) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", username, email, password, role, companyIds)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, username, email, password, role, companyIds)
 	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserUseCaseMockRecorder) CreateUser(username, email, password, role, companyIds any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) CreateUser(ctx, username, email, password, role, companyIds any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserUseCase)(nil).CreateUser), username, email, password, role, companyIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserUseCase)(nil).CreateUser), ctx, username, email, password, role, companyIds)
 }
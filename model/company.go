@@ -2,9 +2,33 @@ package model
 
 import "time"
 
+const (
+	// ゴミ箱のタスクを保持するデフォルトの日数
+	DEFAULT_TRASH_RETENTION_DAYS = 30
+
+	// 会社ごとの添付ファイルの合計サイズのデフォルトの上限(1GiB)
+	DEFAULT_ATTACHMENT_QUOTA_BYTES = 1 << 30
+
+	// 会社のデフォルトのタイムゾーン
+	DEFAULT_COMPANY_TIME_ZONE = "UTC"
+)
+
 type Company struct {
-	ID        uint
-	Name      string
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
+	ID                    uint
+	Name                  string
+	TimeZone              string
+	DisableTaskTotalCount bool
+	TrashRetentionDays    uint
+	AttachmentQuotaBytes  int64
+	CreatedAt             *time.Time
+	UpdatedAt             *time.Time
+}
+
+// Location は、会社のタイムゾーンを返す。読み込めない場合は UTC を返す。
+func (c *Company) Location() *time.Location {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
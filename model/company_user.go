@@ -2,10 +2,31 @@ package model
 
 import "time"
 
+const (
+	// 会社の設定とメンバーを管理できるオーナー
+	COMPANY_ROLE_OWNER = "owner"
+
+	// 他のメンバーのタスクも管理できるマネージャー
+	COMPANY_ROLE_MANAGER = "manager"
+
+	// タスクを作成・更新できる一般メンバー
+	COMPANY_ROLE_MEMBER = "member"
+
+	// 閲覧のみ可能なメンバー
+	COMPANY_ROLE_VIEWER = "viewer"
+)
+
 type CompanyUser struct {
 	ID        uint
 	CompanyID uint
 	UserID    uint
+	Role      string
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
+	User      *User
+}
+
+// Can は、メンバーのロールで action が許可されているかを返す。
+func (c *CompanyUser) Can(action Action) bool {
+	return Can(c.Role, action)
 }
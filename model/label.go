@@ -0,0 +1,34 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// ラベルの絞り込みで、いずれかのラベルが付いたタスクに絞り込む
+	LABEL_MATCH_ANY = "any"
+
+	// ラベルの絞り込みで、全てのラベルが付いたタスクに絞り込む
+	LABEL_MATCH_ALL = "all"
+)
+
+// Label は、会社ごとに定義するタスクの分類
+type Label struct {
+	ID        uint
+	CompanyID uint
+	Name      string
+	Color     string
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// labelNames は、ラベル名の一覧を名前順で返す。履歴に記録するために使用する。
+func labelNames(labels []*Label) []string {
+	names := []string{}
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	slices.Sort(names)
+	return names
+}
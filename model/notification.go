@@ -0,0 +1,118 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// タスクの担当者に指定された
+	NOTIFICATION_TYPE_TASK_ASSIGNED = "task_assigned"
+	// タスクのステータスが変更された
+	NOTIFICATION_TYPE_TASK_STATUS_CHANGED = "task_status_changed"
+	// タスクにコメントが投稿された
+	NOTIFICATION_TYPE_TASK_COMMENTED = "task_commented"
+	// タスクの期限が近づいた
+	NOTIFICATION_TYPE_TASK_DUE_SOON = "task_due_soon"
+	// タスクの期限を過ぎた
+	NOTIFICATION_TYPE_TASK_OVERDUE = "task_overdue"
+)
+
+// NOTIFICATION_TYPES は、通知の種別の一覧。設定の取得時にこの順に並べる。
+var NOTIFICATION_TYPES = []string{
+	NOTIFICATION_TYPE_TASK_ASSIGNED,
+	NOTIFICATION_TYPE_TASK_STATUS_CHANGED,
+	NOTIFICATION_TYPE_TASK_COMMENTED,
+	NOTIFICATION_TYPE_TASK_DUE_SOON,
+	NOTIFICATION_TYPE_TASK_OVERDUE,
+}
+
+// Notification は、ユーザへのアプリ内の通知。Title は通知した時点のタスクのタイトル。
+// ActorID は通知のきっかけとなった操作を行ったユーザで、期限の通知のようにシステムが作成した場合は nil。
+type Notification struct {
+	ID        uint
+	UserID    uint
+	CompanyID uint
+	TaskID    *uint
+	ActorID   *uint
+	Type      string
+	Title     string
+	ReadAt    *time.Time
+	CreatedAt *time.Time
+}
+
+// NotificationPage は、通知の一覧と未読の件数
+type NotificationPage struct {
+	Notifications []*Notification
+	UnreadCount   int64
+}
+
+// NotificationPreference は、ユーザが種別ごとに通知を受け取るかどうかの設定。設定がない種別は受け取る。
+type NotificationPreference struct {
+	UserID    uint
+	Type      string
+	Enabled   bool
+	UpdatedAt *time.Time
+}
+
+// NewTaskNotifications は、タスクに関係するユーザのうち、操作したユーザ以外への通知を作成する。
+// 非公開のタスクは作成者以外が閲覧できないため、作成者のみに通知する。actorId が nil の場合はシステムによる通知とする。
+func NewTaskNotifications(notificationType string, task *Task, actorId *uint, userIds []uint) []*Notification {
+	notifications := []*Notification{}
+	notified := []uint{}
+	for _, userId := range userIds {
+		if actorId != nil && userId == *actorId {
+			continue
+		}
+		if task.Visibility == "private" && userId != task.CreateUserId {
+			continue
+		}
+		if slices.Contains(notified, userId) {
+			continue
+		}
+		notified = append(notified, userId)
+
+		taskId := task.ID
+		notifications = append(notifications, &Notification{
+			UserID:    userId,
+			CompanyID: task.CompanyID,
+			TaskID:    &taskId,
+			ActorID:   actorId,
+			Type:      notificationType,
+			Title:     task.Title,
+		})
+	}
+	return notifications
+}
+
+// TaskWatcherIds は、タスクの変更を通知する作成者と担当者のIDを返す。
+func TaskWatcherIds(task *Task) []uint {
+	ids := []uint{task.CreateUserId}
+	if task.AssigneeID != nil {
+		ids = append(ids, *task.AssigneeID)
+	}
+	return ids
+}
+
+// IsNotificationEnabled は、設定の一覧から userId のユーザが notificationType の通知を受け取るかどうかを返す。
+func IsNotificationEnabled(preferences []*NotificationPreference, userId uint, notificationType string) bool {
+	for _, preference := range preferences {
+		if preference.UserID == userId && preference.Type == notificationType {
+			return preference.Enabled
+		}
+	}
+	return true
+}
+
+// NewNotificationPreferences は、保存されている設定に、設定がない種別のデフォルトを補って全ての種別の設定を返す。
+func NewNotificationPreferences(userId uint, stored []*NotificationPreference) []*NotificationPreference {
+	preferences := []*NotificationPreference{}
+	for _, notificationType := range NOTIFICATION_TYPES {
+		preferences = append(preferences, &NotificationPreference{
+			UserID:  userId,
+			Type:    notificationType,
+			Enabled: IsNotificationEnabled(stored, userId, notificationType),
+		})
+	}
+	return preferences
+}
@@ -0,0 +1,89 @@
+package model
+
+// Action は会社内で権限チェックの対象となる操作
+type Action string
+
+const (
+	// タスクの閲覧
+	ACTION_READ_TASK Action = "task:read"
+
+	// タスクの作成
+	ACTION_CREATE_TASK Action = "task:create"
+
+	// タスクの更新
+	ACTION_UPDATE_TASK Action = "task:update"
+
+	// 自分が作成したタスクの削除
+	ACTION_DELETE_TASK Action = "task:delete"
+
+	// 他のユーザが作成したタスクの削除
+	ACTION_DELETE_OTHERS_TASK Action = "task:delete_others"
+
+	// タスクへのコメントの投稿、自分のコメントの編集・削除
+	ACTION_COMMENT_TASK Action = "task:comment"
+
+	// 他のユーザのコメントの編集・削除
+	ACTION_MODERATE_COMMENT Action = "comment:moderate"
+
+	// ラベルの作成・更新・削除
+	ACTION_MANAGE_LABEL Action = "label:manage"
+
+	// メンバーの閲覧
+	ACTION_READ_MEMBER Action = "member:read"
+
+	// メンバーの追加・削除・ロール変更
+	ACTION_MANAGE_MEMBER Action = "member:manage"
+
+	// Webhook の作成・更新・削除と配信の確認・再送
+	ACTION_MANAGE_WEBHOOK Action = "webhook:manage"
+)
+
+// rolePermissions は会社のロールごとに許可される操作
+var rolePermissions = map[string][]Action{
+	COMPANY_ROLE_OWNER: {
+		ACTION_READ_TASK,
+		ACTION_CREATE_TASK,
+		ACTION_UPDATE_TASK,
+		ACTION_DELETE_TASK,
+		ACTION_DELETE_OTHERS_TASK,
+		ACTION_COMMENT_TASK,
+		ACTION_MODERATE_COMMENT,
+		ACTION_MANAGE_LABEL,
+		ACTION_READ_MEMBER,
+		ACTION_MANAGE_MEMBER,
+		ACTION_MANAGE_WEBHOOK,
+	},
+	COMPANY_ROLE_MANAGER: {
+		ACTION_READ_TASK,
+		ACTION_CREATE_TASK,
+		ACTION_UPDATE_TASK,
+		ACTION_DELETE_TASK,
+		ACTION_DELETE_OTHERS_TASK,
+		ACTION_COMMENT_TASK,
+		ACTION_MODERATE_COMMENT,
+		ACTION_MANAGE_LABEL,
+		ACTION_READ_MEMBER,
+	},
+	COMPANY_ROLE_MEMBER: {
+		ACTION_READ_TASK,
+		ACTION_CREATE_TASK,
+		ACTION_UPDATE_TASK,
+		ACTION_DELETE_TASK,
+		ACTION_COMMENT_TASK,
+		ACTION_READ_MEMBER,
+	},
+	COMPANY_ROLE_VIEWER: {
+		ACTION_READ_TASK,
+		ACTION_READ_MEMBER,
+	},
+}
+
+// Can は、会社のロール role で action が許可されているかを返す。
+func Can(role string, action Action) bool {
+	for _, a := range rolePermissions[role] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
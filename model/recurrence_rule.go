@@ -0,0 +1,292 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// 繰り返しの頻度
+	RECURRENCE_FREQ_DAILY   = "DAILY"
+	RECURRENCE_FREQ_WEEKLY  = "WEEKLY"
+	RECURRENCE_FREQ_MONTHLY = "MONTHLY"
+
+	// 次の繰り返しを探す期間の上限。条件に一致する日が見つからないルールで無限に探さないようにする。
+	MAX_RECURRENCE_PERIODS = 1000
+)
+
+// BYDAY の曜日の表記。time.Weekday の順に並べる。
+var recurrenceWeekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// RecurrenceWeekday は、BYDAY に指定された曜日。Ordinal は MONTHLY の場合のみ指定でき、
+// 1 は月の最初、-1 は月の最後のその曜日を表す。0 の場合は月の全てのその曜日を表す。
+type RecurrenceWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule は、RFC 5545 の RRULE のうち FREQ (DAILY/WEEKLY/MONTHLY)、INTERVAL、BYDAY、UNTIL、COUNT に対応する繰り返しのルール。
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	ByDay    []RecurrenceWeekday
+	Count    int
+
+	// UNTIL。日付のみで指定された場合は UntilIsDate を true とし、会社のタイムゾーンでのその日の終わりまでを含める。
+	Until       *time.Time
+	UntilIsDate bool
+}
+
+// ParseRecurrenceRule は、"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE" のような RRULE の文字列を解析する。
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &RecurrenceRule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		val = strings.ToUpper(val)
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rule part %s", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			if val != RECURRENCE_FREQ_DAILY && val != RECURRENCE_FREQ_WEEKLY && val != RECURRENCE_FREQ_MONTHLY {
+				return nil, fmt.Errorf("unsupported FREQ %s", val)
+			}
+			rule.Freq = val
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %s", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %s", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			if until, err := time.Parse("20060102T150405Z", val); err == nil {
+				rule.Until = &until
+			} else if until, err := time.Parse("20060102", val); err == nil {
+				rule.Until = &until
+				rule.UntilIsDate = true
+			} else {
+				return nil, fmt.Errorf("invalid UNTIL %s", val)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, err := parseRecurrenceWeekday(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be used together")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != RECURRENCE_FREQ_MONTHLY {
+			return nil, errors.New("BYDAY with ordinal is only supported with FREQ=MONTHLY")
+		}
+	}
+	return rule, nil
+}
+
+func parseRecurrenceWeekday(value string) (RecurrenceWeekday, error) {
+	if len(value) < 2 {
+		return RecurrenceWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+	}
+	weekday := slices.Index(recurrenceWeekdayNames, value[len(value)-2:])
+	if weekday < 0 {
+		return RecurrenceWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+	}
+	ordinal := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return RecurrenceWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+		}
+		ordinal = n
+	}
+	return RecurrenceWeekday{Ordinal: ordinal, Weekday: time.Weekday(weekday)}, nil
+}
+
+// String は、ルールを正規化した RRULE の文字列を返す。
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := []string{}
+		for _, day := range r.ByDay {
+			name := recurrenceWeekdayNames[day.Weekday]
+			if day.Ordinal != 0 {
+				name = strconv.Itoa(day.Ordinal) + name
+			}
+			days = append(days, name)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		if r.UntilIsDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next は、start を起点とする繰り返しのうち、after より後の最初の日時を返す。
+// 日付と時刻は loc のタイムゾーンで計算する。UNTIL を過ぎる場合や見つからない場合は nil を返す。COUNT は考慮しない。
+func (r *RecurrenceRule) Next(start, after time.Time, loc *time.Location) *time.Time {
+	start = start.In(loc)
+	first := r.periodsBetween(start, after.In(loc))
+	first = max(first/r.Interval*r.Interval-r.Interval, 0)
+
+	for period := first; period <= first+MAX_RECURRENCE_PERIODS*r.Interval; period += r.Interval {
+		for _, candidate := range r.candidates(start, period) {
+			if candidate.Before(start) || !candidate.After(after) {
+				continue
+			}
+			if r.isAfterUntil(candidate) {
+				return nil
+			}
+			next := candidate.UTC()
+			return &next
+		}
+	}
+	return nil
+}
+
+// nextOccurrence は、index 回目の繰り返しが after の場合の、次の繰り返しの日時を返す。COUNT に達した場合は nil を返す。
+func (r *RecurrenceRule) nextOccurrence(start time.Time, index int, after time.Time, loc *time.Location) *time.Time {
+	if r.Count > 0 && index >= r.Count {
+		return nil
+	}
+	return r.Next(start, after, loc)
+}
+
+// periodsBetween は、start から t までの期間 (日、週、月) の数を返す。
+func (r *RecurrenceRule) periodsBetween(start, t time.Time) int {
+	switch r.Freq {
+	case RECURRENCE_FREQ_DAILY:
+		return daysBetween(start, t)
+	case RECURRENCE_FREQ_WEEKLY:
+		return daysBetween(startOfWeek(start), startOfWeek(t)) / 7
+	default:
+		return (t.Year()-start.Year())*12 + int(t.Month()) - int(start.Month())
+	}
+}
+
+// candidates は、start から period 番目の期間に含まれる、ルールに一致する日時を昇順に返す。
+func (r *RecurrenceRule) candidates(start time.Time, period int) []time.Time {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	candidates := []time.Time{}
+	switch r.Freq {
+	case RECURRENCE_FREQ_DAILY:
+		day := at(start.Year(), start.Month(), start.Day()+period)
+		if len(r.ByDay) == 0 || r.hasWeekday(day.Weekday()) {
+			candidates = append(candidates, day)
+		}
+	case RECURRENCE_FREQ_WEEKLY:
+		weekStart := startOfWeek(start).AddDate(0, 0, period*7)
+		for offset := 0; offset < 7; offset++ {
+			day := at(weekStart.Year(), weekStart.Month(), weekStart.Day()+offset)
+			if (len(r.ByDay) == 0 && day.Weekday() == start.Weekday()) || r.hasWeekday(day.Weekday()) {
+				candidates = append(candidates, day)
+			}
+		}
+	case RECURRENCE_FREQ_MONTHLY:
+		month := time.Date(start.Year(), start.Month()+time.Month(period), 1, 0, 0, 0, 0, start.Location())
+		daysInMonth := month.AddDate(0, 1, -1).Day()
+		if len(r.ByDay) == 0 {
+			// 月に存在しない日 (31日など) はその月を飛ばす
+			if start.Day() <= daysInMonth {
+				candidates = append(candidates, at(month.Year(), month.Month(), start.Day()))
+			}
+			break
+		}
+		for day := 1; day <= daysInMonth; day++ {
+			candidate := at(month.Year(), month.Month(), day)
+			if r.matchesMonthlyWeekday(candidate, daysInMonth) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+func (r *RecurrenceRule) hasWeekday(weekday time.Weekday) bool {
+	return slices.ContainsFunc(r.ByDay, func(day RecurrenceWeekday) bool {
+		return day.Weekday == weekday
+	})
+}
+
+// matchesMonthlyWeekday は、t が BYDAY に指定された月の何番目かの曜日に一致するかを返す。
+func (r *RecurrenceRule) matchesMonthlyWeekday(t time.Time, daysInMonth int) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday != t.Weekday() {
+			continue
+		}
+		switch {
+		case day.Ordinal == 0:
+			return true
+		case day.Ordinal > 0 && (t.Day()-1)/7+1 == day.Ordinal:
+			return true
+		case day.Ordinal < 0 && (daysInMonth-t.Day())/7+1 == -day.Ordinal:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) isAfterUntil(t time.Time) bool {
+	if r.Until == nil {
+		return false
+	}
+	if r.UntilIsDate {
+		return t.Format("20060102") > r.Until.Format("20060102")
+	}
+	return t.After(*r.Until)
+}
+
+// startOfWeek は、t を含む週の月曜日 (RFC 5545 の WKST のデフォルト) の0時を返す。
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween は、a の日付から b の日付までの日数を返す。
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -1,10 +1,33 @@
 package model
 
-import "time"
+import (
+	"time"
+	myErrors "todo-api/errors"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// 未着手のタスクのステータス
+	TASK_STATUS_PENDING = "pending"
+
+	// 完了したタスクのステータス
+	TASK_STATUS_DONE = "done"
+
+	// タスクの階層の最大の深さ。親を持たないタスクの深さを1とする。
+	MAX_TASK_DEPTH = 5
+
+	// サブタスクを持つタスクの削除時に、サブタスクがある場合は削除しない
+	SUBTASK_DELETE_POLICY_BLOCK = "block"
+
+	// サブタスクを持つタスクの削除時に、サブタスクもまとめて削除する
+	SUBTASK_DELETE_POLICY_CASCADE = "cascade"
+)
 
 type Task struct {
 	ID           uint
 	CompanyID    uint
+	ParentTaskID *uint
 	CreateUserId uint
 	Title        string
 	Description  string
@@ -12,7 +35,98 @@ type Task struct {
 	AssigneeID   *uint
 	Visibility   string
 	Status       string
-	CreatedAt    *time.Time
-	UpdatedAt    *time.Time
-	Assignee     *User
+	Version      uint
+
+	// 繰り返しのルール (RFC 5545 の RRULE)。nil の場合は繰り返さない。
+	RecurrenceRule *string
+	// 繰り返しの起点となる最初の期限と、このタスクが何回目の繰り返しか
+	RecurrenceStartAt *time.Time
+	RecurrenceIndex   int
+	// 次の繰り返しの期限。次のタスクを作成済みの場合や、繰り返しが終了した場合は nil
+	RecurrenceNextDueAt *time.Time
+
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	DeletedAt gorm.DeletedAt
+	Assignee  *User
+
+	// nil の場合、更新時にラベルを変更しない
+	Labels []*Label `gorm:"many2many:task_labels"`
+
+	// 取得時に集計する進捗
+	Progress TaskProgress `gorm:"-"`
+
+	// このタスクの完了を妨げているタスクと、このタスクの完了を待っているタスク。タスクの詳細の取得時のみ設定する。
+	Blockers   []*Task `gorm:"-"`
+	Dependents []*Task `gorm:"-"`
+}
+
+// StartRecurrence は、タスクの期限を起点とする新しい繰り返しを設定する。rule が nil の場合は繰り返しを解除する。
+// 期限のないタスクは繰り返せないため、ErrInvalidRecurrence を返す。
+func (t *Task) StartRecurrence(rule *RecurrenceRule, loc *time.Location) error {
+	if rule == nil {
+		t.RecurrenceRule = nil
+		t.RecurrenceStartAt = nil
+		t.RecurrenceIndex = 0
+		t.RecurrenceNextDueAt = nil
+		return nil
+	}
+	if t.DueDate == nil {
+		return myErrors.ErrInvalidRecurrence
+	}
+
+	value := rule.String()
+	t.RecurrenceRule = &value
+	t.RecurrenceStartAt = t.DueDate
+	t.RecurrenceIndex = 1
+	t.RecurrenceNextDueAt = rule.nextOccurrence(*t.DueDate, 1, *t.DueDate, loc)
+	return nil
+}
+
+// NextOccurrence は、繰り返しのタスクの次のタスクを返す。now より前の繰り返しは作成せずに飛ばす。
+// 次の繰り返しがない場合は nil を返す。
+func (t *Task) NextOccurrence(loc *time.Location, now time.Time) (*Task, error) {
+	if t.RecurrenceRule == nil || t.RecurrenceStartAt == nil || t.RecurrenceNextDueAt == nil {
+		return nil, nil
+	}
+	rule, err := ParseRecurrenceRule(*t.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate := *t.RecurrenceNextDueAt
+	index := t.RecurrenceIndex + 1
+	for dueDate.Before(now) {
+		next := rule.nextOccurrence(*t.RecurrenceStartAt, index, dueDate, loc)
+		if next == nil {
+			return nil, nil
+		}
+		dueDate = *next
+		index++
+	}
+
+	return &Task{
+		CompanyID:           t.CompanyID,
+		ParentTaskID:        t.ParentTaskID,
+		CreateUserId:        t.CreateUserId,
+		Title:               t.Title,
+		Description:         t.Description,
+		DueDate:             &dueDate,
+		AssigneeID:          t.AssigneeID,
+		Visibility:          t.Visibility,
+		Status:              TASK_STATUS_PENDING,
+		RecurrenceRule:      t.RecurrenceRule,
+		RecurrenceStartAt:   t.RecurrenceStartAt,
+		RecurrenceIndex:     index,
+		RecurrenceNextDueAt: rule.nextOccurrence(*t.RecurrenceStartAt, index, dueDate, loc),
+		Labels:              t.Labels,
+	}, nil
+}
+
+// TaskProgress は、直下のサブタスクとチェックリストの完了数と総数。ゴミ箱のサブタスクは含めない。
+type TaskProgress struct {
+	SubtasksDone   int64
+	SubtasksTotal  int64
+	ChecklistDone  int64
+	ChecklistTotal int64
 }
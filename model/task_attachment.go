@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// TaskAttachment は、タスクの添付ファイルのメタデータ。ファイルの実体は StorageKey でストレージに保存する。
+type TaskAttachment struct {
+	ID          uint
+	TaskID      uint
+	CompanyID   uint
+	UserID      uint
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   *time.Time
+}
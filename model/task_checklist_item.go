@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// タスクごとのチェックリストの項目の上限数
+const MAX_TASK_CHECKLIST_ITEMS = 100
+
+// TaskChecklistItem は、タスクのチェックリストの項目。サブタスクと異なり、担当者や期限を持たない。
+type TaskChecklistItem struct {
+	ID        uint
+	TaskID    uint
+	Title     string
+	Done      bool
+	Position  int
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
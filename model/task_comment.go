@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// TaskComment は、タスクに対するコメント
+type TaskComment struct {
+	ID        uint
+	TaskID    uint
+	UserID    uint
+	Body      string
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	User      *User
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	myErrors "todo-api/errors"
+)
+
+const (
+	// 次のページを取得するカーソル
+	CURSOR_DIRECTION_NEXT = "next"
+
+	// 前のページを取得するカーソル
+	CURSOR_DIRECTION_PREV = "prev"
+)
+
+// errInvalidCursor はカーソルの形式が不正、またはソート条件と一致しない場合のエラー
+var errInvalidCursor = myErrors.ErrInvalidArgument.WithMessage("cursor is invalid")
+
+// TaskCursor はタスク一覧のキーセットページングに使用するカーソル。
+// Values には OrderKeys の各フィールドに対応する境界の行の値を保持する(NULL の場合は nil)。
+type TaskCursor struct {
+	Direction string    `json:"d"`
+	Sort      string    `json:"s"`
+	Values    []*string `json:"v"`
+}
+
+// TaskPagination はタスク一覧のページング条件。
+// Cursor が指定された場合はキーセットページングとなり、Offset は使用しない。
+type TaskPagination struct {
+	Limit          int
+	Offset         int
+	Cursor         *TaskCursor
+	WithTotalCount bool
+}
+
+// TaskPage はタスク一覧のページング結果
+type TaskPage struct {
+	Tasks      []*Task
+	NextCursor *TaskCursor
+	PrevCursor *TaskCursor
+	TotalCount *int64
+}
+
+// OrderKeys は、ソート条件の末尾に id を加えた、行を一意に並べるためのキーを返す。
+func (f *TaskFilter) OrderKeys() []TaskSort {
+	keys := []TaskSort{}
+	if f != nil {
+		for _, sort := range f.Sort {
+			if sort.Field == "id" {
+				return append(keys, sort)
+			}
+			keys = append(keys, sort)
+		}
+	}
+	return append(keys, TaskSort{Field: "id"})
+}
+
+// SortString はソート条件をクエリパラメータと同じ形式の文字列で返す。
+func (f *TaskFilter) SortString() string {
+	fields := []string{}
+	for _, sort := range f.OrderKeys() {
+		if sort.Desc {
+			fields = append(fields, "-"+sort.Field)
+		} else {
+			fields = append(fields, sort.Field)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// NewTaskCursor は、指定したタスクを境界とするカーソルを生成する。
+func NewTaskCursor(direction string, filter *TaskFilter, task *Task) *TaskCursor {
+	values := []*string{}
+	for _, sort := range filter.OrderKeys() {
+		values = append(values, sort.valueOf(task))
+	}
+	return &TaskCursor{
+		Direction: direction,
+		Sort:      filter.SortString(),
+		Values:    values,
+	}
+}
+
+// Encode はカーソルをクライアントに返す不透明な文字列に変換する。
+func (c *TaskCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeTaskCursor は、クライアントから受け取ったカーソルを復元する。
+// カーソル生成時とソート条件が異なる場合は ErrInvalidArgument を返す。
+func DecodeTaskCursor(token string, filter *TaskFilter) (*TaskCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	cursor := &TaskCursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, errInvalidCursor
+	}
+
+	if cursor.Direction != CURSOR_DIRECTION_NEXT && cursor.Direction != CURSOR_DIRECTION_PREV {
+		return nil, errInvalidCursor
+	}
+	if cursor.Sort != filter.SortString() {
+		return nil, errInvalidCursor
+	}
+	keys := filter.OrderKeys()
+	if len(cursor.Values) != len(keys) {
+		return nil, errInvalidCursor
+	}
+	for i, key := range keys {
+		if _, err := key.ParseValue(cursor.Values[i]); err != nil {
+			return nil, errInvalidCursor
+		}
+	}
+	return cursor, nil
+}
+
+// valueOf は、ソート対象のフィールドの値をカーソルに保存する文字列で返す。
+func (s TaskSort) valueOf(task *Task) *string {
+	var value string
+	switch s.Field {
+	case "id":
+		value = strconv.FormatUint(uint64(task.ID), 10)
+	case "title":
+		value = task.Title
+	case "status":
+		value = task.Status
+	case "due_date":
+		if task.DueDate == nil {
+			return nil
+		}
+		value = task.DueDate.Format(time.RFC3339Nano)
+	case "created_at":
+		if task.CreatedAt == nil {
+			return nil
+		}
+		value = task.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		if task.UpdatedAt == nil {
+			return nil
+		}
+		value = task.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return &value
+}
+
+// Nullable はソート対象のカラムが NULL を取りうるかどうかを返す。
+func (s TaskSort) Nullable() bool {
+	return s.Field == "due_date" || s.Field == "created_at" || s.Field == "updated_at"
+}
+
+// ParseValue は、カーソルに保存した値をクエリのパラメータに使用できる型に変換する。
+func (s TaskSort) ParseValue(value *string) (any, error) {
+	if value == nil {
+		if !s.Nullable() {
+			return nil, errInvalidCursor
+		}
+		return nil, nil
+	}
+
+	switch s.Field {
+	case "id":
+		return strconv.ParseUint(*value, 10, 64)
+	case "due_date", "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, *value)
+	default:
+		return *value, nil
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// TaskDependency は、タスク TaskID が BlockedByTaskID のタスクの完了を待っていることを表す。
+// 依存関係は同じ会社のタスク間に限る。
+type TaskDependency struct {
+	TaskID          uint
+	BlockedByTaskID uint
+	CompanyID       uint
+	CreatedAt       *time.Time
+}
+
+// TaskDependencyGraph は、会社のタスクの依存関係のグラフ。
+type TaskDependencyGraph struct {
+	Tasks        []*Task
+	Dependencies []*TaskDependency
+}
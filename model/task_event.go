@@ -0,0 +1,170 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+const (
+	// タスクの変更履歴の操作種別
+	TASK_EVENT_ACTION_CREATE  = "create"
+	TASK_EVENT_ACTION_UPDATE  = "update"
+	TASK_EVENT_ACTION_DELETE  = "delete"
+	TASK_EVENT_ACTION_RESTORE = "restore"
+)
+
+// TaskFieldChange は、1つのフィールドの変更前と変更後の値
+type TaskFieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// TaskEvent は、タスクの変更履歴。誰がいつどのフィールドを変更したかを保持する。
+// ByAdmin はシステムオペレーターの管理APIによる操作であることを示す。
+type TaskEvent struct {
+	ID        uint
+	TaskID    uint
+	CompanyID uint
+	ActorID   uint
+	Action    string
+	ByAdmin   bool
+	Changes   map[string]TaskFieldChange `gorm:"serializer:json"`
+	CreatedAt *time.Time
+	Actor     *User
+}
+
+// NewTaskEvent は、変更前と変更後のタスクを比較して変更履歴を作成する。
+// 作成時は before に nil を指定する。削除と復元は after に nil を指定し、変更内容は記録しない。
+func NewTaskEvent(action string, actorId uint, byAdmin bool, before, after *Task) *TaskEvent {
+	task := after
+	if task == nil {
+		task = before
+	}
+	return &TaskEvent{
+		TaskID:    task.ID,
+		CompanyID: task.CompanyID,
+		ActorID:   actorId,
+		Action:    action,
+		ByAdmin:   byAdmin,
+		Changes:   diffTask(before, after),
+	}
+}
+
+// taskEventPayload は、タスクの変更を外部に送信する際のデータ
+type taskEventPayload struct {
+	Task    *taskSnapshot              `json:"task"`
+	ActorID uint                       `json:"actor_id"`
+	ByAdmin bool                       `json:"by_admin"`
+	Changes map[string]TaskFieldChange `json:"changes"`
+}
+
+type taskSnapshot struct {
+	ID             uint       `json:"id"`
+	CompanyID      uint       `json:"company_id"`
+	ParentTaskID   *uint      `json:"parent_task_id"`
+	CreateUserID   uint       `json:"create_user_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	DueDate        *time.Time `json:"due_date"`
+	AssigneeID     *uint      `json:"assignee_id"`
+	Visibility     string     `json:"visibility"`
+	Status         string     `json:"status"`
+	RecurrenceRule *string    `json:"recurrence_rule"`
+	Version        uint       `json:"version"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+}
+
+// MarshalTaskEventPayload は、変更履歴と変更後のタスクを、Webhook やリアルタイム配信で送信する JSON に変換する。
+func MarshalTaskEventPayload(event *TaskEvent, task *Task) ([]byte, error) {
+	var deletedAt *time.Time
+	if task.DeletedAt.Valid {
+		deletedAt = &task.DeletedAt.Time
+	}
+	return json.Marshal(&taskEventPayload{
+		Task: &taskSnapshot{
+			ID:             task.ID,
+			CompanyID:      task.CompanyID,
+			ParentTaskID:   task.ParentTaskID,
+			CreateUserID:   task.CreateUserId,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate,
+			AssigneeID:     task.AssigneeID,
+			Visibility:     task.Visibility,
+			Status:         task.Status,
+			RecurrenceRule: task.RecurrenceRule,
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+			DeletedAt:      deletedAt,
+		},
+		ActorID: event.ActorID,
+		ByAdmin: event.ByAdmin,
+		Changes: event.Changes,
+	})
+}
+
+// diffTask は、ユーザが変更できるフィールドのうち値が変わったものを返す。
+func diffTask(before, after *Task) map[string]TaskFieldChange {
+	changes := map[string]TaskFieldChange{}
+	if after == nil {
+		return changes
+	}
+	if before == nil {
+		before = &Task{}
+	}
+
+	if before.Title != after.Title {
+		changes["title"] = TaskFieldChange{From: before.Title, To: after.Title}
+	}
+	if before.Description != after.Description {
+		changes["description"] = TaskFieldChange{From: before.Description, To: after.Description}
+	}
+	if !equalTime(before.DueDate, after.DueDate) {
+		changes["due_date"] = TaskFieldChange{From: before.DueDate, To: after.DueDate}
+	}
+	if !equalUint(before.AssigneeID, after.AssigneeID) {
+		changes["assignee_id"] = TaskFieldChange{From: before.AssigneeID, To: after.AssigneeID}
+	}
+	if !equalUint(before.ParentTaskID, after.ParentTaskID) {
+		changes["parent_task_id"] = TaskFieldChange{From: before.ParentTaskID, To: after.ParentTaskID}
+	}
+	if before.Visibility != after.Visibility {
+		changes["visibility"] = TaskFieldChange{From: before.Visibility, To: after.Visibility}
+	}
+	if before.Status != after.Status {
+		changes["status"] = TaskFieldChange{From: before.Status, To: after.Status}
+	}
+	if !equalString(before.RecurrenceRule, after.RecurrenceRule) {
+		changes["recurrence_rule"] = TaskFieldChange{From: before.RecurrenceRule, To: after.RecurrenceRule}
+	}
+	// ラベルを変更しない更新では after.Labels が nil となる
+	if after.Labels != nil && !slices.Equal(labelNames(before.Labels), labelNames(after.Labels)) {
+		changes["labels"] = TaskFieldChange{From: labelNames(before.Labels), To: labelNames(after.Labels)}
+	}
+	return changes
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func equalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalUint(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// taskSortColumns はソートに指定できるフィールドと、対応するカラム名の許可リスト
+var taskSortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"status":     "status",
+	"due_date":   "due_date",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// TaskSort はタスク一覧のソート条件
+type TaskSort struct {
+	Field string
+	Desc  bool
+}
+
+// Column はソート対象のカラム名を返す。許可リストにないフィールドの場合は false を返す。
+func (s TaskSort) Column() (string, bool) {
+	column, ok := taskSortColumns[s.Field]
+	return column, ok
+}
+
+// IsTaskSortField はソートに指定できるフィールドかどうかを返す
+func IsTaskSortField(field string) bool {
+	_, ok := taskSortColumns[field]
+	return ok
+}
+
+// TaskFilter はタスク一覧の絞り込み条件。nil のフィールドは絞り込みに使用しない。
+type TaskFilter struct {
+	Status       *string
+	AssigneeID   *uint
+	CreateUserID *uint
+	Visibility   *string
+	DueBefore    *time.Time
+	DueAfter     *time.Time
+
+	// 期限切れ(期限を過ぎていて未完了)のタスクのみに絞り込む
+	Overdue bool
+
+	// ラベル名で絞り込む。LabelMatch が all の場合は全てのラベル、それ以外はいずれかのラベルが付いたタスクに絞り込む
+	Labels     []string
+	LabelMatch string
+
+	Sort []TaskSort
+}
@@ -0,0 +1,115 @@
+package model
+
+import "time"
+
+// Optional は部分更新で値が指定されたかどうかを保持する
+type Optional[T any] struct {
+	Set   bool
+	Value T
+}
+
+// TaskPatch はタスクの部分更新の内容。Set が false のフィールドは更新しない。
+// DueDate、AssigneeID、ParentTaskID、RecurrenceRule は nil を指定すると値をクリアする。
+// RecurrenceStartAt、RecurrenceIndex、RecurrenceNextDueAt は、繰り返しのルールを変更する場合に合わせて設定する。
+// Labels はカラムではないため Columns には含まれず、指定された場合はラベルを置き換える。
+type TaskPatch struct {
+	Title        Optional[string]
+	Description  Optional[string]
+	DueDate      Optional[*time.Time]
+	AssigneeID   Optional[*uint]
+	ParentTaskID Optional[*uint]
+	Visibility   Optional[string]
+	Status       Optional[string]
+	Labels       Optional[[]*Label]
+
+	RecurrenceRule      Optional[*string]
+	RecurrenceStartAt   Optional[*time.Time]
+	RecurrenceIndex     Optional[int]
+	RecurrenceNextDueAt Optional[*time.Time]
+}
+
+// IsEmpty は、更新する内容がないかどうかを返す
+func (p *TaskPatch) IsEmpty() bool {
+	return len(p.Columns()) == 0 && !p.Labels.Set
+}
+
+// Columns は更新するカラムと値を返す
+func (p *TaskPatch) Columns() map[string]any {
+	columns := map[string]any{}
+	if p.Title.Set {
+		columns["title"] = p.Title.Value
+	}
+	if p.Description.Set {
+		columns["description"] = p.Description.Value
+	}
+	if p.DueDate.Set {
+		columns["due_date"] = p.DueDate.Value
+	}
+	if p.AssigneeID.Set {
+		columns["assignee_id"] = p.AssigneeID.Value
+	}
+	if p.ParentTaskID.Set {
+		columns["parent_task_id"] = p.ParentTaskID.Value
+	}
+	if p.Visibility.Set {
+		columns["visibility"] = p.Visibility.Value
+	}
+	if p.Status.Set {
+		columns["status"] = p.Status.Value
+	}
+	if p.RecurrenceRule.Set {
+		columns["recurrence_rule"] = p.RecurrenceRule.Value
+	}
+	if p.RecurrenceStartAt.Set {
+		columns["recurrence_start_at"] = p.RecurrenceStartAt.Value
+	}
+	if p.RecurrenceIndex.Set {
+		columns["recurrence_index"] = p.RecurrenceIndex.Value
+	}
+	if p.RecurrenceNextDueAt.Set {
+		columns["recurrence_next_due_at"] = p.RecurrenceNextDueAt.Value
+	}
+	return columns
+}
+
+// Apply は、部分更新を適用したタスクのコピーを返す。task 自体は変更しない。
+func (p *TaskPatch) Apply(task *Task) *Task {
+	patched := *task
+	if p.Title.Set {
+		patched.Title = p.Title.Value
+	}
+	if p.Description.Set {
+		patched.Description = p.Description.Value
+	}
+	if p.DueDate.Set {
+		patched.DueDate = p.DueDate.Value
+	}
+	if p.AssigneeID.Set {
+		patched.AssigneeID = p.AssigneeID.Value
+	}
+	if p.ParentTaskID.Set {
+		patched.ParentTaskID = p.ParentTaskID.Value
+	}
+	if p.Visibility.Set {
+		patched.Visibility = p.Visibility.Value
+	}
+	if p.Status.Set {
+		patched.Status = p.Status.Value
+	}
+	if p.Labels.Set {
+		patched.Labels = p.Labels.Value
+	}
+	if p.RecurrenceRule.Set {
+		patched.RecurrenceRule = p.RecurrenceRule.Value
+	}
+	if p.RecurrenceStartAt.Set {
+		patched.RecurrenceStartAt = p.RecurrenceStartAt.Value
+	}
+	if p.RecurrenceIndex.Set {
+		patched.RecurrenceIndex = p.RecurrenceIndex.Value
+	}
+	if p.RecurrenceNextDueAt.Set {
+		patched.RecurrenceNextDueAt = p.RecurrenceNextDueAt.Value
+	}
+	return &patched
+}
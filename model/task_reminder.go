@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	// 期限を過ぎたタスクの通知を表す、期限までの時間
+	TASK_REMINDER_OVERDUE = time.Duration(0)
+)
+
+// TaskReminder は、送信したタスクの期限のリマインダー。
+// 同じ期限に対して同じリマインダーを再送しないよう、送信前に記録する。期限が変更された場合は新しい期限に対して送る。
+type TaskReminder struct {
+	TaskID        uint
+	DueDate       time.Time
+	WindowSeconds int64
+	CreatedAt     *time.Time
+}
+
+// NewTaskReminder は、期限の window 前に送るリマインダーを作成する。window が0の場合は期限切れの通知を表す。
+func NewTaskReminder(task *Task, window time.Duration) *TaskReminder {
+	return &TaskReminder{
+		TaskID:        task.ID,
+		DueDate:       *task.DueDate,
+		WindowSeconds: int64(window / time.Second),
+	}
+}
+
+// IsOverdue は、期限切れの通知かどうかを返す。
+func (r *TaskReminder) IsOverdue() bool {
+	return r.WindowSeconds == 0
+}
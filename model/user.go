@@ -2,6 +2,14 @@ package model
 
 import "time"
 
+const (
+	// 全ての会社を横断して管理するシステムオペレーター。会社のロールとは独立している
+	USER_ROLE_SYSTEM_OPERATOR = "system_operator"
+
+	// 一般ユーザ
+	USER_ROLE_USER = "user"
+)
+
 type User struct {
 	ID           uint
 	Username     string
@@ -11,3 +19,8 @@ type User struct {
 	CreatedAt    *time.Time
 	UpdatedAt    *time.Time
 }
+
+// IsSystemOperator は、ユーザがシステムオペレーターかを返す。
+func (u *User) IsSystemOperator() bool {
+	return u.Role == USER_ROLE_SYSTEM_OPERATOR
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+const (
+	// タスクが作成された
+	WEBHOOK_EVENT_TYPE_TASK_CREATED = "task.created"
+	// タスクが更新された
+	WEBHOOK_EVENT_TYPE_TASK_UPDATED = "task.updated"
+	// タスクがゴミ箱に移動された
+	WEBHOOK_EVENT_TYPE_TASK_DELETED = "task.deleted"
+	// タスクがゴミ箱から元に戻された
+	WEBHOOK_EVENT_TYPE_TASK_RESTORED = "task.restored"
+
+	// 送信待ち、または再試行待ちの配信
+	WEBHOOK_DELIVERY_STATUS_PENDING = "pending"
+	// 送信に成功した配信
+	WEBHOOK_DELIVERY_STATUS_SUCCEEDED = "succeeded"
+	// 再試行の上限に達した配信
+	WEBHOOK_DELIVERY_STATUS_FAILED = "failed"
+
+	// 1つの配信を送信する最大の回数
+	WEBHOOK_MAX_ATTEMPTS = 10
+	// 最初の再試行までの間隔。以降は失敗するたびに2倍にする。
+	WEBHOOK_RETRY_BASE_INTERVAL = time.Minute
+	// 再試行の間隔の上限
+	WEBHOOK_RETRY_MAX_INTERVAL = 6 * time.Hour
+
+	// 配信の記録に残すエラーの最大の文字数
+	webhookDeliveryErrorMaxLength = 1024
+)
+
+// WEBHOOK_EVENT_TYPES は、購読できるイベントの種別の一覧
+var WEBHOOK_EVENT_TYPES = []string{
+	WEBHOOK_EVENT_TYPE_TASK_CREATED,
+	WEBHOOK_EVENT_TYPE_TASK_UPDATED,
+	WEBHOOK_EVENT_TYPE_TASK_DELETED,
+	WEBHOOK_EVENT_TYPE_TASK_RESTORED,
+}
+
+// taskEventWebhookEventTypes は、タスクの変更履歴の操作種別に対応するイベントの種別
+var taskEventWebhookEventTypes = map[string]string{
+	TASK_EVENT_ACTION_CREATE:  WEBHOOK_EVENT_TYPE_TASK_CREATED,
+	TASK_EVENT_ACTION_UPDATE:  WEBHOOK_EVENT_TYPE_TASK_UPDATED,
+	TASK_EVENT_ACTION_DELETE:  WEBHOOK_EVENT_TYPE_TASK_DELETED,
+	TASK_EVENT_ACTION_RESTORE: WEBHOOK_EVENT_TYPE_TASK_RESTORED,
+}
+
+// Webhook は、会社のイベントを外部の URL に送信する購読。Secret は署名に使用し、APIのレスポンスには含めない。
+type Webhook struct {
+	ID         uint
+	CompanyID  uint
+	URL        string
+	Secret     string
+	EventTypes []string `gorm:"serializer:json"`
+	Active     bool
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+}
+
+// Subscribes は、Webhook が有効で、eventType のイベントを購読しているかどうかを返す。
+func (w *Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookEvent は、Webhook で送信するイベント。
+// 変更と同じトランザクション内で記録し (トランザクショナルアウトボックス)、送信処理が購読ごとの配信に振り分ける。
+// DispatchedAt は配信に振り分けた日時で、nil の場合は振り分けを待っている。
+type WebhookEvent struct {
+	ID           uint
+	CompanyID    uint
+	Type         string
+	Payload      string
+	DispatchedAt *time.Time
+	CreatedAt    *time.Time
+}
+
+// NewTaskWebhookEvent は、タスクの変更履歴と変更後のタスクから、Webhook で送信するイベントを作成する。
+// 非公開のタスクは作成者以外が閲覧できないため、イベントを作成せずに nil を返す。
+func NewTaskWebhookEvent(event *TaskEvent, task *Task) (*WebhookEvent, error) {
+	eventType, ok := taskEventWebhookEventTypes[event.Action]
+	if !ok || task.Visibility == "private" {
+		return nil, nil
+	}
+
+	payload, err := MarshalTaskEventPayload(event, task)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookEvent{
+		CompanyID: task.CompanyID,
+		Type:      eventType,
+		Payload:   string(payload),
+	}, nil
+}
+
+// Body は、Webhook で送信するリクエストボディを返す。再送した場合も同じ内容になる。
+func (e *WebhookEvent) Body() ([]byte, error) {
+	return json.Marshal(&struct {
+		ID        uint            `json:"id"`
+		Type      string          `json:"type"`
+		CompanyID uint            `json:"company_id"`
+		CreatedAt *time.Time      `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}{
+		ID:        e.ID,
+		Type:      e.Type,
+		CompanyID: e.CompanyID,
+		CreatedAt: e.CreatedAt,
+		Data:      json.RawMessage(e.Payload),
+	})
+}
+
+// WebhookDelivery は、1つの Webhook への1つのイベントの配信と、その送信結果の記録。
+// 送信に失敗した場合は、NextAttemptAt まで待ってから再試行する。Redelivery は利用者の操作による再送であることを示す。
+type WebhookDelivery struct {
+	ID             uint
+	WebhookID      uint
+	WebhookEventID uint
+	Redelivery     bool
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	Error          *string
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	Webhook        *Webhook
+	WebhookEvent   *WebhookEvent
+}
+
+// NewWebhookDelivery は、now にすぐ送信する配信を作成する。
+func NewWebhookDelivery(webhookId, webhookEventId uint, redelivery bool, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		WebhookID:      webhookId,
+		WebhookEventID: webhookEventId,
+		Redelivery:     redelivery,
+		Status:         WEBHOOK_DELIVERY_STATUS_PENDING,
+		NextAttemptAt:  &now,
+	}
+}
+
+// RecordAttempt は、now に送信した結果を記録する。
+// 失敗した場合は指数バックオフで次の再試行の日時を設定し、最大の回数に達した場合は失敗とする。
+// responseStatus は、レスポンスを受け取れなかった場合は nil。
+func (d *WebhookDelivery) RecordAttempt(now time.Time, responseStatus *int, attemptErr error) {
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = responseStatus
+	if attemptErr == nil {
+		d.Status = WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+		d.NextAttemptAt = nil
+		d.Error = nil
+		return
+	}
+
+	message := attemptErr.Error()
+	if runes := []rune(message); len(runes) > webhookDeliveryErrorMaxLength {
+		message = string(runes[:webhookDeliveryErrorMaxLength])
+	}
+	d.Error = &message
+	if d.Attempts >= WEBHOOK_MAX_ATTEMPTS {
+		d.Status = WEBHOOK_DELIVERY_STATUS_FAILED
+		d.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(WebhookRetryInterval(d.Attempts))
+	d.NextAttemptAt = &next
+}
+
+// WebhookRetryInterval は、attempts 回失敗した後に次の再試行までに待つ間隔を返す。
+func WebhookRetryInterval(attempts int) time.Duration {
+	interval := WEBHOOK_RETRY_BASE_INTERVAL
+	for i := 1; i < attempts; i++ {
+		interval *= 2
+		if interval >= WEBHOOK_RETRY_MAX_INTERVAL {
+			return WEBHOOK_RETRY_MAX_INTERVAL
+		}
+	}
+	return interval
+}
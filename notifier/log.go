@@ -0,0 +1,20 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"todo-api/model"
+)
+
+// logNotifier は通知を送らずにログに出力する実装。送信先が設定されていない開発環境で使用する。
+type logNotifier struct{}
+
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, recipient *model.User, message *Message) error {
+	slog.Info(fmt.Sprintf("notification to user %d: %s", recipient.ID, message.Subject))
+	return nil
+}
@@ -0,0 +1,19 @@
+package notifier
+
+import (
+	"context"
+	"todo-api/model"
+)
+
+// Message は利用者に送る通知の内容
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Notifier は利用者に通知を送る。
+// 送信方法を差し替える場合は、このインターフェースを実装する。
+type Notifier interface {
+	// Notify は、recipient に message を送る。
+	Notify(ctx context.Context, recipient *model.User, message *Message) error
+}
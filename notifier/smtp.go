@@ -0,0 +1,132 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+	"todo-api/model"
+)
+
+const (
+	// SMTP サーバへの接続と読み書きの時間の上限の既定値
+	DEFAULT_SMTP_TIMEOUT = 30 * time.Second
+)
+
+// SMTPConfig は SMTP サーバの接続設定
+type SMTPConfig struct {
+	Host string
+	Port string
+	// 認証が不要な場合は空にする
+	Username string
+	Password string
+	// 送信元のメールアドレス
+	From string
+	// 1通の送信 (接続から切断まで) の時間の上限。0 の場合は DEFAULT_SMTP_TIMEOUT とする
+	Timeout time.Duration
+}
+
+// smtpNotifier は SMTP でメールを送る実装。
+// サーバが STARTTLS に対応している場合は暗号化してから送る。
+type smtpNotifier struct {
+	config SMTPConfig
+	now    func() time.Time
+}
+
+func NewSMTPNotifier(config SMTPConfig) Notifier {
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_SMTP_TIMEOUT
+	}
+	return &smtpNotifier{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, recipient *model.User, message *Message) error {
+	if recipient.Email == "" {
+		return fmt.Errorf("user %d has no email address", recipient.ID)
+	}
+
+	// サーバが応答しない場合に送信する処理が止まらないよう、ctx に期限がなくても時間の上限を設ける
+	deadline := time.Now().Add(n.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.config.Host, n.config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	conn.SetDeadline(deadline)
+	// 接続後の読み書きは ctx で中断できないため、ctx が終了したら接続を閉じて中断する
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if err := client.Mail(n.config.From); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	if err := client.Rcpt(recipient.Email); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	if _, err := w.Write(n.buildMessage(recipient, message)); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	// 送信は完了しているため、切断時のエラーは無視する
+	client.Quit()
+	return nil
+}
+
+// buildMessage は、UTF-8 のテキストのメールを組み立てる。件名は MIME エンコードする。
+func (n *smtpNotifier) buildMessage(recipient *model.User, message *Message) []byte {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", n.config.From},
+		{"To", (&mail.Address{Name: recipient.Username, Address: recipient.Email}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", n.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+	body := strings.ReplaceAll(message.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buf.Bytes()
+}
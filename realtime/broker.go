@@ -0,0 +1,41 @@
+package realtime
+
+import "errors"
+
+// ErrClosed は、サーバの停止によりブローカーが閉じられた後に購読しようとした場合のエラー
+var ErrClosed = errors.New("realtime broker is closed")
+
+// Subscription は、1つの接続による会社のイベントの購読
+type Subscription struct {
+	// Replay は、購読を開始する前に発行されたイベントのうち、指定された ID より後のもの。
+	// 指定された ID より後のイベントが既にバッファから消えている場合は、代わりに stream.reset のイベントのみを含む。
+	Replay []*Event
+	// Events は、購読を開始した後に発行されたイベント。
+	// 購読の終了、ブローカーの停止、または受信が追いつかない場合に閉じられる。
+	Events <-chan *Event
+
+	close func()
+}
+
+// NewSubscription は、購読を作成する。close は購読の終了時に呼び出され、複数回呼び出されても問題ないように実装する。
+func NewSubscription(replay []*Event, events <-chan *Event, close func()) *Subscription {
+	return &Subscription{Replay: replay, Events: events, close: close}
+}
+
+// Close は、購読を終了する。複数回呼び出してもよい。
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// Broker は、タスクの変更などのイベントを購読している接続に配信する。
+// 複数のサーバインスタンスに配信する場合は、NewHub に共有の Transport を指定する。
+type Broker interface {
+	// Publish は、event に ID を割り当て、event を閲覧できる購読に配信する。
+	Publish(event *Event)
+	// Subscribe は、userId のユーザとして companyId の会社のイベントを購読する。
+	// lastEventId が nil でない場合は、その ID より後のイベントをバッファから再送する。
+	// ブローカーが閉じられている場合は ErrClosed を返す。
+	Subscribe(companyId, userId uint, lastEventId *uint64) (*Subscription, error)
+	// Close は、全ての購読を終了し、以降の購読を拒否する。
+	Close()
+}
@@ -0,0 +1,166 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+	"todo-api/model"
+)
+
+const (
+	// タスクが作成された
+	EVENT_TYPE_TASK_CREATED = "task.created"
+	// タスクが更新された
+	EVENT_TYPE_TASK_UPDATED = "task.updated"
+	// タスクがゴミ箱に移動された
+	EVENT_TYPE_TASK_DELETED = "task.deleted"
+	// タスクがゴミ箱から元に戻された
+	EVENT_TYPE_TASK_RESTORED = "task.restored"
+	// タスクが非公開になり、作成者以外が閲覧できなくなった
+	EVENT_TYPE_TASK_HIDDEN = "task.hidden"
+	// コメントが投稿された
+	EVENT_TYPE_COMMENT_CREATED = "comment.created"
+	// コメントが編集された
+	EVENT_TYPE_COMMENT_UPDATED = "comment.updated"
+	// コメントが削除された
+	EVENT_TYPE_COMMENT_DELETED = "comment.deleted"
+	// 再送できないイベントがあり、クライアントが一覧を取得し直す必要がある
+	EVENT_TYPE_STREAM_RESET = "stream.reset"
+	// ユーザがタスクを閲覧している、コメントを入力しているなどの状態が変わった
+	EVENT_TYPE_PRESENCE_UPDATED = "presence.updated"
+)
+
+const (
+	// タスクを閲覧している
+	PRESENCE_STATE_VIEWING = "viewing"
+	// タスクのコメントを入力している
+	PRESENCE_STATE_TYPING = "typing"
+	// タスクの閲覧をやめた
+	PRESENCE_STATE_LEFT = "left"
+)
+
+// taskEventTypes は、タスクの変更履歴の操作種別に対応するイベントの種別
+var taskEventTypes = map[string]string{
+	model.TASK_EVENT_ACTION_CREATE:  EVENT_TYPE_TASK_CREATED,
+	model.TASK_EVENT_ACTION_UPDATE:  EVENT_TYPE_TASK_UPDATED,
+	model.TASK_EVENT_ACTION_DELETE:  EVENT_TYPE_TASK_DELETED,
+	model.TASK_EVENT_ACTION_RESTORE: EVENT_TYPE_TASK_RESTORED,
+}
+
+// Event は、購読している接続に配信するイベント。ID はトランスポートが発行時に割り当てる。
+// Data は JSON で、閲覧できるユーザは対象のタスクの公開範囲で決まる。
+// 他のサーバインスタンスに送れるよう、JSON に変換できる。
+type Event struct {
+	ID        uint64 `json:"id"`
+	CompanyID uint   `json:"company_id"`
+	TaskID    uint   `json:"task_id"`
+	Type      string `json:"type"`
+	Data      []byte `json:"data"`
+	// ActorID は、イベントを起こしたユーザの ID。不明な場合は 0
+	ActorID uint `json:"actor_id"`
+	// Private と CreateUserID は、対象のタスクの公開範囲
+	Private      bool `json:"private"`
+	CreateUserID uint `json:"create_user_id"`
+	// Transient は、再接続したクライアントに再送しない一時的なイベントかどうか
+	Transient bool `json:"transient"`
+}
+
+// VisibleTo は、userId のユーザがイベントを閲覧できるかどうかを返す。
+// 非公開のタスクのイベントは作成者のみが閲覧でき、非公開になったことは作成者以外にのみ通知する。
+func (e *Event) VisibleTo(userId uint) bool {
+	if e.Type == EVENT_TYPE_TASK_HIDDEN {
+		return userId != e.CreateUserID
+	}
+	return !e.Private || userId == e.CreateUserID
+}
+
+// NewTaskEvent は、タスクの変更履歴と変更後のタスクからイベントを作成する。対応しない操作種別の場合は nil を返す。
+func NewTaskEvent(event *model.TaskEvent, task *model.Task) (*Event, error) {
+	eventType, ok := taskEventTypes[event.Action]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := model.MarshalTaskEventPayload(event, task)
+	if err != nil {
+		return nil, err
+	}
+	e := newEvent(eventType, task, data)
+	e.ActorID = event.ActorID
+	return e, nil
+}
+
+// NewTaskHiddenEvent は、task が非公開になったことを、作成者以外に通知するイベントを作成する。内容はタスクの ID のみとする。
+func NewTaskHiddenEvent(task *model.Task) (*Event, error) {
+	data, err := json.Marshal(map[string]any{
+		"task": map[string]any{"id": task.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEvent(EVENT_TYPE_TASK_HIDDEN, task, data), nil
+}
+
+// commentEventPayload は、コメントのイベントで配信するデータ
+type commentEventPayload struct {
+	TaskID  uint          `json:"task_id"`
+	Comment *commentEvent `json:"comment"`
+}
+
+type commentEvent struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	Body      string     `json:"body,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// NewCommentEvent は、task のコメントの投稿、編集、削除のイベントを作成する。削除の場合は本文を含めない。
+func NewCommentEvent(eventType string, task *model.Task, comment *model.TaskComment) (*Event, error) {
+	payload := &commentEventPayload{
+		TaskID:  task.ID,
+		Comment: &commentEvent{ID: comment.ID, UserID: comment.UserID},
+	}
+	if eventType != EVENT_TYPE_COMMENT_DELETED {
+		payload.Comment.Body = comment.Body
+		payload.Comment.CreatedAt = comment.CreatedAt
+		payload.Comment.UpdatedAt = comment.UpdatedAt
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	e := newEvent(eventType, task, data)
+	e.ActorID = comment.UserID
+	return e, nil
+}
+
+// presenceEventPayload は、プレゼンスのイベントで配信するデータ
+type presenceEventPayload struct {
+	TaskID uint   `json:"task_id"`
+	UserID uint   `json:"user_id"`
+	State  string `json:"state"`
+}
+
+// NewPresenceEvent は、userId のユーザの task に対する状態が state になったことを通知するイベントを作成する。
+// 状態は接続している間だけ意味を持つため、再送しない。
+func NewPresenceEvent(task *model.Task, userId uint, state string) (*Event, error) {
+	data, err := json.Marshal(&presenceEventPayload{TaskID: task.ID, UserID: userId, State: state})
+	if err != nil {
+		return nil, err
+	}
+	e := newEvent(EVENT_TYPE_PRESENCE_UPDATED, task, data)
+	e.ActorID = userId
+	e.Transient = true
+	return e, nil
+}
+
+func newEvent(eventType string, task *model.Task, data []byte) *Event {
+	return &Event{
+		CompanyID:    task.CompanyID,
+		TaskID:       task.ID,
+		Type:         eventType,
+		Data:         data,
+		Private:      task.Visibility == "private",
+		CreateUserID: task.CreateUserId,
+	}
+}
@@ -0,0 +1,158 @@
+package realtime
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+const (
+	// 再送のために保持するイベントの件数のデフォルト
+	DEFAULT_REPLAY_BUFFER_SIZE = 1000
+	// 1つの購読で受信を待っているイベントの件数の上限。超えた場合は購読を終了し、クライアントに再接続させる。
+	subscriberBufferSize = 64
+)
+
+// hub は、このサーバインスタンスに接続している購読にイベントを配信する実装。
+// イベントはトランスポートを経由して発行するため、他のインスタンスで発行されたイベントも配信される。
+// 直近のイベントを固定長のバッファに保持し、再接続したクライアントに再送する。
+type hub struct {
+	transport   Transport
+	mu          sync.Mutex
+	lastId      uint64
+	buffer      []*Event
+	bufferSize  int
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type subscriber struct {
+	companyId uint
+	userId    uint
+	events    chan *Event
+}
+
+// NewHub は、transport で受け取ったイベントを配信し、直近の bufferSize 件のイベントを再送できるブローカーを作成する。
+func NewHub(transport Transport, bufferSize int) Broker {
+	h := &hub{
+		transport:   transport,
+		bufferSize:  bufferSize,
+		subscribers: map[*subscriber]struct{}{},
+	}
+	transport.Receive(h.dispatch)
+	return h
+}
+
+func (h *hub) Publish(event *Event) {
+	h.mu.Lock()
+	closed := h.closed
+	h.mu.Unlock()
+	if closed {
+		return
+	}
+
+	if err := h.transport.Publish(event); err != nil {
+		slog.Info(fmt.Sprintf("error Publish: %v", err))
+	}
+}
+
+// dispatch は、トランスポートから受け取ったイベントをバッファに追加し、閲覧できる購読に配信する。
+func (h *hub) dispatch(event *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.lastId = event.ID
+	if !event.Transient {
+		h.buffer = append(h.buffer, event)
+		if len(h.buffer) > h.bufferSize {
+			h.buffer = h.buffer[len(h.buffer)-h.bufferSize:]
+		}
+	}
+
+	for s := range h.subscribers {
+		if s.companyId != event.CompanyID || !event.VisibleTo(s.userId) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			// 受信が追いつかない購読は終了し、再接続時にバッファから再送する
+			h.unsubscribe(s)
+		}
+	}
+}
+
+func (h *hub) Subscribe(companyId, userId uint, lastEventId *uint64) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	s := &subscriber{
+		companyId: companyId,
+		userId:    userId,
+		events:    make(chan *Event, subscriberBufferSize),
+	}
+	h.subscribers[s] = struct{}{}
+	subscription := NewSubscription([]*Event{}, s.events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.unsubscribe(s)
+	})
+	if lastEventId == nil {
+		return subscription, nil
+	}
+
+	// 最後に受け取った ID より後、またはバッファの最も古いイベントより前の ID の場合は、間のイベントを再送できない
+	oldestId := h.lastId + 1
+	if len(h.buffer) > 0 {
+		oldestId = h.buffer[0].ID
+	}
+	if *lastEventId > h.lastId || *lastEventId+1 < oldestId {
+		// クライアントは一覧を取得し直し、以降はこの ID から再開する
+		subscription.Replay = append(subscription.Replay, &Event{
+			ID:        h.lastId,
+			CompanyID: companyId,
+			Type:      EVENT_TYPE_STREAM_RESET,
+			Data:      []byte("{}"),
+		})
+		return subscription, nil
+	}
+	for _, event := range h.buffer {
+		if event.ID > *lastEventId && event.CompanyID == companyId && event.VisibleTo(userId) {
+			subscription.Replay = append(subscription.Replay, event)
+		}
+	}
+	return subscription, nil
+}
+
+func (h *hub) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	for s := range h.subscribers {
+		h.unsubscribe(s)
+	}
+	h.mu.Unlock()
+
+	// トランスポートは受信中に h.mu を取得するため、解放してから閉じる
+	if err := h.transport.Close(); err != nil {
+		slog.Info(fmt.Sprintf("error Close: %v", err))
+	}
+}
+
+// unsubscribe は、購読を終了してチャネルを閉じる。h.mu を取得した状態で呼び出す。
+func (h *hub) unsubscribe(s *subscriber) {
+	if _, ok := h.subscribers[s]; !ok {
+		return
+	}
+	delete(h.subscribers, s)
+	close(s.events)
+}
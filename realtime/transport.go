@@ -0,0 +1,60 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Transport は、発行されたイベントを全てのサーバインスタンスのハブに届ける。
+// 複数のインスタンスで動かす場合は、Redis の Pub/Sub などの共有のメッセージブローカーを使ってこのインターフェースを実装する。
+type Transport interface {
+	// Publish は、event に ID を割り当てて全てのインスタンスに送る。
+	// ID は全てのインスタンスで共通で、送った順に増加しなければならない。
+	Publish(event *Event) error
+	// Receive は、送られたイベントを受け取る関数を登録する。receive は送られた順に1つずつ呼び出す。
+	Receive(receive func(event *Event))
+	// Close は、イベントの受信を停止する。
+	Close() error
+}
+
+// localTransport は、同じプロセスのハブにのみイベントを届ける実装。
+type localTransport struct {
+	mu      sync.Mutex
+	nextId  uint64
+	receive func(event *Event)
+}
+
+// NewLocalTransport は、サーバインスタンスが1つの場合のトランスポートを作成する。
+func NewLocalTransport() Transport {
+	return &localTransport{
+		// 再起動の前に発行された ID で再接続された場合に、新しいイベントと取り違えないよう、
+		// 起動した時刻 (マイクロ秒) から ID を割り当てる
+		nextId: uint64(time.Now().UnixMicro()),
+	}
+}
+
+func (t *localTransport) Publish(event *Event) error {
+	// 受け取る順序が ID の順になるよう、割り当てから受信までを排他する
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextId++
+	event.ID = t.nextId
+	if t.receive != nil {
+		t.receive(event)
+	}
+	return nil
+}
+
+func (t *localTransport) Receive(receive func(event *Event)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.receive = receive
+}
+
+func (t *localTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.receive = nil
+	return nil
+}
@@ -1,16 +1,23 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	myErrors "todo-api/errors"
 	"todo-api/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CompanyRepository interface {
-	GetCompany(id uint) (*model.Company, error)
+	GetCompanies(ctx context.Context, limit, offset int) ([]*model.Company, error)
+	GetCompaniesByUserId(ctx context.Context, userId uint) ([]*model.Company, error)
+	GetCompany(ctx context.Context, id uint) (*model.Company, error)
+	CreateCompany(ctx context.Context, company *model.Company) (*model.Company, error)
+	UpdateCompany(ctx context.Context, company *model.Company) (*model.Company, error)
+	DeleteCompany(ctx context.Context, id uint) ([]string, error)
 }
 
 type companyRepository struct {
@@ -21,9 +28,34 @@ func NewCompanyRepository(db *gorm.DB) CompanyRepository {
 	return &companyRepository{db: db}
 }
 
-func (r *companyRepository) GetCompany(id uint) (*model.Company, error) {
+func (r *companyRepository) GetCompanies(ctx context.Context, limit, offset int) ([]*model.Company, error) {
+	companies := []*model.Company{}
+	result := r.db.WithContext(ctx).Order("id").Limit(limit).Offset(offset).Find(&companies)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetCompanies: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return companies, nil
+}
+
+// GetCompaniesByUserId は、company_users を介してユーザが所属する会社の一覧を返す。
+func (r *companyRepository) GetCompaniesByUserId(ctx context.Context, userId uint) ([]*model.Company, error) {
+	companies := []*model.Company{}
+	result := r.db.WithContext(ctx).
+		Joins("INNER JOIN company_users ON company_users.company_id = companies.id").
+		Where("company_users.user_id = ?", userId).
+		Order("companies.id").
+		Find(&companies)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetCompaniesByUserId: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return companies, nil
+}
+
+func (r *companyRepository) GetCompany(ctx context.Context, id uint) (*model.Company, error) {
 	company := &model.Company{}
-	result := r.db.Find(company, "id = ?", id)
+	result := r.db.WithContext(ctx).Find(company, "id = ?", id)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetCompany: %v", result.Error))
 		return nil, myErrors.ErrDb
@@ -33,3 +65,50 @@ func (r *companyRepository) GetCompany(id uint) (*model.Company, error) {
 	}
 	return company, nil
 }
+
+func (r *companyRepository) CreateCompany(ctx context.Context, company *model.Company) (*model.Company, error) {
+	if err := r.db.WithContext(ctx).Create(company).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateCompany: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return company, nil
+}
+
+func (r *companyRepository) UpdateCompany(ctx context.Context, company *model.Company) (*model.Company, error) {
+	if err := r.db.WithContext(ctx).Save(company).Error; err != nil {
+		slog.Info(fmt.Sprintf("error UpdateCompany: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return company, nil
+}
+
+// DeleteCompany は、会社を削除し、削除した添付ファイルのストレージのキーを返す。
+// タスクと添付ファイルのメタデータは外部キーで削除されるため、ファイルの実体は呼び出し側でストレージから削除する。
+// 削除までの間に添付ファイルが追加されないよう、会社の行をロックした上でキーを取得する。
+func (r *companyRepository) DeleteCompany(ctx context.Context, id uint) ([]string, error) {
+	storageKeys := []string{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&model.Company{}, id)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error DeleteCompany: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+
+		if err := tx.Model(&model.TaskAttachment{}).Where("company_id = ?", id).Pluck("storage_key", &storageKeys).Error; err != nil {
+			slog.Info(fmt.Sprintf("error DeleteCompany: %v", err))
+			return myErrors.ErrDb
+		}
+		if err := tx.Where("id = ?", id).Delete(&model.Company{}).Error; err != nil {
+			slog.Info(fmt.Sprintf("error DeleteCompany: %v", err))
+			return myErrors.ErrDb
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storageKeys, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	myErrors "todo-api/errors"
@@ -10,8 +11,12 @@ import (
 )
 
 type CompanyUserRepository interface {
-	GetCompanyUser(companyId, userId uint) (*model.CompanyUser, error)
-	CreateCompanyUsers(companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error)
+	GetCompanyUsers(ctx context.Context, companyId uint) ([]*model.CompanyUser, error)
+	GetCompanyUser(ctx context.Context, companyId, userId uint) (*model.CompanyUser, error)
+	CountCompanyUsersByRole(ctx context.Context, companyId uint, role string) (int64, error)
+	CreateCompanyUsers(ctx context.Context, companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error)
+	UpdateCompanyUser(ctx context.Context, companyUser *model.CompanyUser) (*model.CompanyUser, error)
+	DeleteCompanyUser(ctx context.Context, companyId, userId uint, reassigneeId *uint) error
 }
 
 type companyUserRepository struct {
@@ -22,9 +27,19 @@ func NewCompanyUserRepository(db *gorm.DB) CompanyUserRepository {
 	return &companyUserRepository{db: db}
 }
 
-func (r *companyUserRepository) GetCompanyUser(companyId, userId uint) (*model.CompanyUser, error) {
+func (r *companyUserRepository) GetCompanyUsers(ctx context.Context, companyId uint) ([]*model.CompanyUser, error) {
+	companyUsers := []*model.CompanyUser{}
+	result := r.db.WithContext(ctx).Preload("User").Where("company_id = ?", companyId).Order("id").Find(&companyUsers)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetCompanyUsers: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return companyUsers, nil
+}
+
+func (r *companyUserRepository) GetCompanyUser(ctx context.Context, companyId, userId uint) (*model.CompanyUser, error) {
 	company := &model.CompanyUser{}
-	result := r.db.Find(company, "company_id = ? AND user_id = ?", companyId, userId)
+	result := r.db.WithContext(ctx).Find(company, "company_id = ? AND user_id = ?", companyId, userId)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetCompanyUser: %v", result.Error))
 		return nil, myErrors.ErrDb
@@ -35,9 +50,53 @@ func (r *companyUserRepository) GetCompanyUser(companyId, userId uint) (*model.C
 	return company, nil
 }
 
-func (r *companyUserRepository) CreateCompanyUsers(companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error) {
-	if err := r.db.Create(companyUsers).Error; err != nil {
+func (r *companyUserRepository) CountCompanyUsersByRole(ctx context.Context, companyId uint, role string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&model.CompanyUser{}).Where("company_id = ? AND role = ?", companyId, role).Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CountCompanyUsersByRole: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return count, nil
+}
+
+func (r *companyUserRepository) CreateCompanyUsers(ctx context.Context, companyUsers []*model.CompanyUser) ([]*model.CompanyUser, error) {
+	if err := r.db.WithContext(ctx).Create(companyUsers).Error; err != nil {
 		return nil, myErrors.ErrDb
 	}
 	return companyUsers, nil
 }
+
+func (r *companyUserRepository) UpdateCompanyUser(ctx context.Context, companyUser *model.CompanyUser) (*model.CompanyUser, error) {
+	if err := r.db.WithContext(ctx).Save(companyUser).Error; err != nil {
+		slog.Info(fmt.Sprintf("error UpdateCompanyUser: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return companyUser, nil
+}
+
+// DeleteCompanyUser は、ユーザを会社から外す。
+// tasks.assignee_id が所属外のユーザを指さないよう、同じトランザクション内で
+// 担当タスクを reassigneeId に付け替える(nil の場合は担当者なしにする)。
+// 復元された場合に備え、ゴミ箱のタスクも付け替える。
+func (r *companyUserRepository) DeleteCompanyUser(ctx context.Context, companyId, userId uint, reassigneeId *uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.Task{}).
+			Where("company_id = ? AND assignee_id = ?", companyId, userId).
+			Update("assignee_id", reassigneeId)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error DeleteCompanyUser: %v", result.Error))
+			return myErrors.ErrDb
+		}
+
+		result = tx.Where("company_id = ? AND user_id = ?", companyId, userId).Delete(&model.CompanyUser{})
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error DeleteCompanyUser: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+		return nil
+	})
+}
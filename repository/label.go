@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+)
+
+type LabelRepository interface {
+	GetLabels(ctx context.Context, companyId uint) ([]*model.Label, error)
+	GetLabel(ctx context.Context, companyId, id uint) (*model.Label, error)
+	GetLabelByName(ctx context.Context, companyId uint, name string) (*model.Label, error)
+	GetLabelsByIds(ctx context.Context, companyId uint, ids []uint) ([]*model.Label, error)
+	CreateLabel(ctx context.Context, label *model.Label) (*model.Label, error)
+	UpdateLabel(ctx context.Context, label *model.Label) (*model.Label, error)
+	DeleteLabel(ctx context.Context, companyId, id uint) error
+}
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+func (r *labelRepository) GetLabels(ctx context.Context, companyId uint) ([]*model.Label, error) {
+	labels := []*model.Label{}
+	result := r.db.WithContext(ctx).Where("company_id = ?", companyId).Order("name").Find(&labels)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetLabels: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) GetLabel(ctx context.Context, companyId, id uint) (*model.Label, error) {
+	label := &model.Label{}
+	result := r.db.WithContext(ctx).Where("id = ? AND company_id = ?", id, companyId).Find(label)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetLabel: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return label, nil
+}
+
+func (r *labelRepository) GetLabelByName(ctx context.Context, companyId uint, name string) (*model.Label, error) {
+	label := &model.Label{}
+	result := r.db.WithContext(ctx).Where("company_id = ? AND name = ?", companyId, name).Find(label)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetLabelByName: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return label, nil
+}
+
+// GetLabelsByIds は、会社のラベルのうち ids に含まれるものを取得する。他の会社のラベルは含まれない。
+func (r *labelRepository) GetLabelsByIds(ctx context.Context, companyId uint, ids []uint) ([]*model.Label, error) {
+	labels := []*model.Label{}
+	if len(ids) == 0 {
+		return labels, nil
+	}
+	result := r.db.WithContext(ctx).Where("company_id = ? AND id IN ?", companyId, ids).Order("name").Find(&labels)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetLabelsByIds: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) CreateLabel(ctx context.Context, label *model.Label) (*model.Label, error) {
+	if err := r.db.WithContext(ctx).Create(label).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateLabel: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return label, nil
+}
+
+func (r *labelRepository) UpdateLabel(ctx context.Context, label *model.Label) (*model.Label, error) {
+	result := r.db.WithContext(ctx).Model(label).
+		Where("company_id = ?", label.CompanyID).
+		Updates(map[string]any{"name": label.Name, "color": label.Color})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error UpdateLabel: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return label, nil
+}
+
+// DeleteLabel は、ラベルを削除する。タスクとの関連は外部キーにより削除される。
+func (r *labelRepository) DeleteLabel(ctx context.Context, companyId, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND company_id = ?", id, companyId).Delete(&model.Label{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteLabel: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	GetNotifications(ctx context.Context, userId uint, unreadOnly bool, limit, offset int) ([]*model.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userId uint) (int64, error)
+	CreateNotifications(ctx context.Context, notifications []*model.Notification) error
+	MarkNotificationRead(ctx context.Context, userId, id uint, readAt time.Time) error
+	MarkAllNotificationsRead(ctx context.Context, userId uint, readAt time.Time) error
+	GetNotificationPreferences(ctx context.Context, userIds []uint) ([]*model.NotificationPreference, error)
+	SaveNotificationPreferences(ctx context.Context, preferences []*model.NotificationPreference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// GetNotifications は、ユーザの通知を新しい順に取得する。unreadOnly が true の場合は未読の通知のみを取得する。
+func (r *notificationRepository) GetNotifications(ctx context.Context, userId uint, unreadOnly bool, limit, offset int) ([]*model.Notification, error) {
+	notifications := []*model.Notification{}
+	query := r.db.WithContext(ctx).Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	result := query.Order("id DESC").Limit(limit).Offset(offset).Find(&notifications)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetNotifications: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnreadNotifications(ctx context.Context, userId uint) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CountUnreadNotifications: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) CreateNotifications(ctx context.Context, notifications []*model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(notifications).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateNotifications: %v", err))
+		return myErrors.ErrDb
+	}
+	return nil
+}
+
+// MarkNotificationRead は、ユーザの通知を既読にする。既に既読の場合は既読にした日時を変えない。
+// ユーザの通知でない場合は ErrNotFound を返す。
+func (r *notificationRepository) MarkNotificationRead(ctx context.Context, userId, id uint, readAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error MarkNotificationRead: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		// 既読の通知は値が変わらず RowsAffected が0になるため、存在するかを確認する
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&model.Notification{}).Where("id = ? AND user_id = ?", id, userId).Count(&count).Error; err != nil {
+			slog.Info(fmt.Sprintf("error MarkNotificationRead: %v", err))
+			return myErrors.ErrDb
+		}
+		if count == 0 {
+			return myErrors.ErrNotFound
+		}
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead は、ユーザの未読の通知を全て既読にする。
+func (r *notificationRepository) MarkAllNotificationsRead(ctx context.Context, userId uint, readAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error MarkAllNotificationsRead: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	return nil
+}
+
+// GetNotificationPreferences は、ユーザが保存した通知の設定を取得する。設定がない種別は含まない。
+func (r *notificationRepository) GetNotificationPreferences(ctx context.Context, userIds []uint) ([]*model.NotificationPreference, error) {
+	preferences := []*model.NotificationPreference{}
+	result := r.db.WithContext(ctx).Where("user_id IN ?", userIds).Find(&preferences)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetNotificationPreferences: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return preferences, nil
+}
+
+// SaveNotificationPreferences は、通知の設定を保存する。既に設定がある種別は上書きする。
+func (r *notificationRepository) SaveNotificationPreferences(ctx context.Context, preferences []*model.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"})}).
+		Create(preferences)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error SaveNotificationPreferences: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	return nil
+}
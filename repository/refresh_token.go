@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	GetRefreshTokenByTokenHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	ExistsActiveRefreshToken(ctx context.Context, familyId string) (bool, error)
+	CreateRefreshToken(ctx context.Context, refreshToken *model.RefreshToken) (*model.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, id uint, newRefreshToken *model.RefreshToken) (*model.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) GetRefreshTokenByTokenHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	refreshToken := &model.RefreshToken{}
+	result := r.db.WithContext(ctx).Find(refreshToken, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetRefreshTokenByTokenHash: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return refreshToken, nil
+}
+
+// ExistsActiveRefreshToken は、指定したファミリー(ログインセッション)に
+// 失効していないリフレッシュトークンが存在するかを返す。
+func (r *refreshTokenRepository) ExistsActiveRefreshToken(ctx context.Context, familyId string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyId, time.Now()).
+		Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error ExistsActiveRefreshToken: %v", result.Error))
+		return false, myErrors.ErrDb
+	}
+	return count > 0, nil
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, refreshToken *model.RefreshToken) (*model.RefreshToken, error) {
+	if err := r.db.WithContext(ctx).Create(refreshToken).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateRefreshToken: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return refreshToken, nil
+}
+
+// RotateRefreshToken は、未失効のリフレッシュトークンを失効させ、同じトランザクション内で新しいリフレッシュトークンを作成する。
+// 既に失効済みの場合は ErrNotFound を返し、新しいリフレッシュトークンは作成しない。
+func (r *refreshTokenRepository) RotateRefreshToken(ctx context.Context, id uint, newRefreshToken *model.RefreshToken) (*model.RefreshToken, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error RotateRefreshToken: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+		if err := tx.Create(newRefreshToken).Error; err != nil {
+			slog.Info(fmt.Sprintf("error RotateRefreshToken: %v", err))
+			return myErrors.ErrDb
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRefreshToken, nil
+}
+
+// RevokeRefreshTokenFamily は、ファミリーに属する全てのリフレッシュトークンを失効させる。
+func (r *refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	result := r.db.WithContext(ctx).Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error RevokeRefreshTokenFamily: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	return nil
+}
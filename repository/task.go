@@ -1,23 +1,43 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 	myErrors "todo-api/errors"
 	"todo-api/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
-	GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error)
-	GetTasks(limit, offset int) ([]*model.Task, error)
-	GetTaskById(id uint) (*model.Task, error)
-	GetTask(companyId, id, createUserId uint) (*model.Task, error)
-	CreateTask(task *model.Task) (*model.Task, error)
-	UpdateTask(id uint, task *model.Task) (*model.Task, error)
-	DeleteTaskById(id uint) error
-	DeleteTask(companyId, id uint) error
+	GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error)
+	CountTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter) (int64, error)
+	GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error)
+	CountTasks(ctx context.Context, filter *model.TaskFilter) (int64, error)
+	GetTaskById(ctx context.Context, id uint) (*model.Task, error)
+	GetTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error)
+	GetSubtasks(ctx context.Context, companyId, parentId, createUserId uint, limit, offset int) ([]*model.Task, error)
+	GetTaskAncestorIds(ctx context.Context, id uint) ([]uint, error)
+	GetTaskSubtreeHeight(ctx context.Context, id uint) (int, error)
+	GetTaskDescendants(ctx context.Context, id uint) ([]*model.Task, error)
+	CreateTask(ctx context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(task *model.Task) (*model.WebhookEvent, error)) (*model.Task, error)
+	UpdateTask(ctx context.Context, id uint, task *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error)
+	PatchTask(ctx context.Context, id, version uint, patch *model.TaskPatch, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error
+	DeleteTaskById(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error
+	DeleteTask(ctx context.Context, companyId, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error
+	GetTrashedTasksByCompanyId(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error)
+	GetTrashedTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error)
+	GetTrashedSubtasks(ctx context.Context, id uint) ([]*model.Task, error)
+	RestoreTask(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error
+	PurgeTask(ctx context.Context, id uint) ([]string, error)
+	PurgeExpiredTasks(ctx context.Context) (int64, []string, error)
+	GetTasksWithDueRecurrence(ctx context.Context, until time.Time, limit int) ([]*model.Task, error)
+	CreateTaskOccurrence(ctx context.Context, id uint, next *model.Task, event *model.TaskEvent, newWebhookEvent func(task *model.Task) (*model.WebhookEvent, error)) (*model.Task, error)
 }
 
 type taskRepository struct {
@@ -28,32 +48,73 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
-func (r *taskRepository) GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+// GetTasksByCompanyId は、会社のタスク一覧を取得する。
+// 次のページの有無を判定できるよう、Limit より1件多く取得する。
+func (r *taskRepository) GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error) {
 	tasks := []*model.Task{}
-	result := r.db.Preload("Assignee").
+	query := r.db.WithContext(ctx).Preload("Assignee").Preload("Labels").
 		Where("company_id = ?", companyId).
-		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
-		Limit(limit).Offset(offset).Find(&tasks)
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId)
+	query, err := applyTaskPagination(applyTaskFilter(query, filter), filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&tasks)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetTasks: %v", result.Error))
 		return nil, myErrors.ErrDb
 	}
+	if err := loadTaskProgress(r.db.WithContext(ctx), tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
-func (r *taskRepository) GetTasks(limit, offset int) ([]*model.Task, error) {
+func (r *taskRepository) CountTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&model.Task{}).
+		Where("company_id = ?", companyId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId)
+	result := applyTaskFilter(query, filter).Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CountTasksByCompanyId: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return count, nil
+}
+
+// GetTasks は、全ての会社のタスク一覧を取得する。
+// 次のページの有無を判定できるよう、Limit より1件多く取得する。
+func (r *taskRepository) GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) ([]*model.Task, error) {
 	tasks := []*model.Task{}
-	result := r.db.Preload("Assignee").Limit(limit).Offset(offset).Find(&tasks)
+	query, err := applyTaskPagination(applyTaskFilter(r.db.WithContext(ctx).Preload("Assignee").Preload("Labels"), filter), filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&tasks)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetTasks: %v", result.Error))
 		return nil, myErrors.ErrDb
 	}
+	if err := loadTaskProgress(r.db.WithContext(ctx), tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
-func (r *taskRepository) GetTaskById(id uint) (*model.Task, error) {
+func (r *taskRepository) CountTasks(ctx context.Context, filter *model.TaskFilter) (int64, error) {
+	var count int64
+	result := applyTaskFilter(r.db.WithContext(ctx).Model(&model.Task{}), filter).Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CountTasks: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return count, nil
+}
+
+func (r *taskRepository) GetTaskById(ctx context.Context, id uint) (*model.Task, error) {
 	task := &model.Task{}
-	result := r.db.Preload("Assignee").Where("id = ?", id).Find(task)
+	result := r.db.WithContext(ctx).Preload("Assignee").Preload("Labels").Where("id = ?", id).Find(task)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetTaskById: %v", result.Error))
 		return nil, myErrors.ErrDb
@@ -61,12 +122,15 @@ func (r *taskRepository) GetTaskById(id uint) (*model.Task, error) {
 	if result.RowsAffected == 0 {
 		return nil, myErrors.ErrNotFound
 	}
+	if err := loadTaskProgress(r.db.WithContext(ctx), []*model.Task{task}); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-func (r *taskRepository) GetTask(companyId, id, createUserId uint) (*model.Task, error) {
+func (r *taskRepository) GetTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error) {
 	task := &model.Task{}
-	result := r.db.Preload("Assignee").
+	result := r.db.WithContext(ctx).Preload("Assignee").Preload("Labels").
 		Where("id = ? AND company_id = ?", id, companyId).
 		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
 		Find(task)
@@ -77,42 +141,704 @@ func (r *taskRepository) GetTask(companyId, id, createUserId uint) (*model.Task,
 	if result.RowsAffected == 0 {
 		return nil, myErrors.ErrNotFound
 	}
+	if err := loadTaskProgress(r.db.WithContext(ctx), []*model.Task{task}); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-func (r *taskRepository) CreateTask(task *model.Task) (*model.Task, error) {
-	if err := r.db.Create(task).Error; err != nil {
+// GetSubtasks は、閲覧できる直下のサブタスクを作成日時の古い順に取得する。
+func (r *taskRepository) GetSubtasks(ctx context.Context, companyId, parentId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	tasks := []*model.Task{}
+	result := r.db.WithContext(ctx).Preload("Assignee").Preload("Labels").
+		Where("company_id = ? AND parent_task_id = ?", companyId, parentId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Order("created_at").Order("id").
+		Limit(limit).Offset(offset).Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetSubtasks: %v", result.Error))
 		return nil, myErrors.ErrDb
 	}
-	return task, nil
+	if err := loadTaskProgress(r.db.WithContext(ctx), tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskAncestorIds は、タスク自身と祖先のタスクのIDを、タスク自身から親をたどる順に取得する。
+// ゴミ箱のタスクも含める。
+func (r *taskRepository) GetTaskAncestorIds(ctx context.Context, id uint) ([]uint, error) {
+	ids := []uint{}
+	result := r.db.WithContext(ctx).Raw(`WITH RECURSIVE ancestors (id, parent_task_id, depth) AS (
+			SELECT id, parent_task_id, 1 FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT tasks.id, tasks.parent_task_id, ancestors.depth + 1 FROM tasks
+			INNER JOIN ancestors ON tasks.id = ancestors.parent_task_id
+		) SELECT id FROM ancestors ORDER BY depth`, id).Scan(&ids)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskAncestorIds: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if len(ids) == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return ids, nil
 }
 
-func (r *taskRepository) UpdateTask(id uint, task *model.Task) (*model.Task, error) {
-	result := r.db.Save(task)
-	if err := result.Error; err != nil {
+// GetTaskSubtreeHeight は、タスク自身を1として、最も深い子孫のタスクまでの階層の数を返す。
+// ゴミ箱から元に戻せるよう、ゴミ箱のタスクも含める。
+func (r *taskRepository) GetTaskSubtreeHeight(ctx context.Context, id uint) (int, error) {
+	var height sql.NullInt64
+	result := r.db.WithContext(ctx).Raw(`WITH RECURSIVE subtree (id, depth) AS (
+			SELECT id, 1 FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT tasks.id, subtree.depth + 1 FROM tasks
+			INNER JOIN subtree ON tasks.parent_task_id = subtree.id
+		) SELECT MAX(depth) FROM subtree`, id).Scan(&height)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskSubtreeHeight: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	if !height.Valid {
+		return 0, myErrors.ErrNotFound
+	}
+	return int(height.Int64), nil
+}
+
+// GetTaskDescendants は、ゴミ箱にない子孫のタスクを取得する。
+func (r *taskRepository) GetTaskDescendants(ctx context.Context, id uint) ([]*model.Task, error) {
+	db := r.db.WithContext(ctx)
+	subtaskIds, err := selectSubtaskIds(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*model.Task{}
+	if len(subtaskIds) == 0 {
+		return tasks, nil
+	}
+	if err := db.Where("id IN ?", subtaskIds).Order("id").Find(&tasks).Error; err != nil {
+		slog.Info(fmt.Sprintf("error GetTaskDescendants: %v", err))
 		return nil, myErrors.ErrDb
 	}
+	return tasks, nil
+}
+
+// CreateTask は、タスクを作成し、同じトランザクション内で変更履歴と Webhook で送信するイベントを記録する。
+// 作成するタスクの ID は書き込むまで決まらないため、Webhook で送信するイベントは作成したタスクを newWebhookEvent に渡して作成する。
+func (r *taskRepository) CreateTask(ctx context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(task *model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+	// バージョンは1から始める
+	task.Version = 1
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+			return myErrors.ErrDb
+		}
+		if len(task.Labels) > 0 {
+			if err := replaceTaskLabels(tx, task.ID, task.Labels); err != nil {
+				return err
+			}
+		}
+		if err := createTaskEvent(tx, task.ID, event); err != nil {
+			return err
+		}
+		return createNewTaskWebhookEvent(tx, task, newWebhookEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// UpdateTask は、task.Version が現在のバージョンと一致する場合のみタスクを更新し、バージョンを1つ進める。
+// 他の更新によりバージョンが変わっていた場合は ErrConflict を返す。
+// 変更履歴と Webhook で送信するイベントは同じトランザクション内で記録する。
+func (r *taskRepository) UpdateTask(ctx context.Context, id uint, task *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error) {
+	version := task.Version
+	task.Version = version + 1
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(task).
+			Where("version = ?", version).
+			Select("*").Omit(clause.Associations).
+			Updates(task)
+		if err := result.Error; err != nil {
+			slog.Info(fmt.Sprintf("error UpdateTask: %v", err))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrConflict
+		}
+		if task.Labels != nil {
+			if err := replaceTaskLabels(tx, id, task.Labels); err != nil {
+				return err
+			}
+		}
+		if err := createTaskEvent(tx, id, event); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, webhookEvents)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-func (r *taskRepository) DeleteTaskById(id uint) error {
-	result := r.db.Where("id = ?", id).Delete(&model.Task{})
+// PatchTask は、バージョンが一致する場合のみ、部分更新で指定されたカラムを更新し、バージョンを1つ進める。
+// 他の更新によりバージョンが変わっていた場合は ErrConflict を返す。
+// 変更履歴と Webhook で送信するイベントは同じトランザクション内で記録する。
+func (r *taskRepository) PatchTask(ctx context.Context, id, version uint, patch *model.TaskPatch, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	if patch.IsEmpty() {
+		return nil
+	}
+	columns := patch.Columns()
+	columns["version"] = gorm.Expr("version + 1")
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).Where("id = ? AND version = ?", id, version).Updates(columns)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error PatchTask: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrConflict
+		}
+		if patch.Labels.Set {
+			if err := replaceTaskLabels(tx, id, patch.Labels.Value); err != nil {
+				return err
+			}
+		}
+		if err := createTaskEvent(tx, id, event); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, webhookEvents)
+	})
+}
+
+// DeleteTaskById は、タスクをゴミ箱に移動する。ゴミ箱にないサブタスクも同じ削除日時でゴミ箱に移動する。
+// 変更履歴と Webhook で送信するイベントは同じトランザクション内で記録する。
+func (r *taskRepository) DeleteTaskById(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&model.Task{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+		if err := createTaskEvent(tx, id, event); err != nil {
+			return err
+		}
+		if err := deleteSubtasks(tx, id, event); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, webhookEvents)
+	})
+}
+
+// DeleteTask は、会社のタスクをゴミ箱に移動する。ゴミ箱にないサブタスクも同じ削除日時でゴミ箱に移動する。
+// 変更履歴と Webhook で送信するイベントは同じトランザクション内で記録する。
+func (r *taskRepository) DeleteTask(ctx context.Context, companyId, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND company_id = ?", id, companyId).Delete(&model.Task{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+		if err := createTaskEvent(tx, id, event); err != nil {
+			return err
+		}
+		if err := deleteSubtasks(tx, id, event); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, webhookEvents)
+	})
+}
+
+// GetTrashedTasksByCompanyId は、会社のゴミ箱にあるタスクを削除日時の新しい順に取得する。
+func (r *taskRepository) GetTrashedTasksByCompanyId(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	tasks := []*model.Task{}
+	result := r.db.WithContext(ctx).Unscoped().Preload("Assignee").Preload("Labels").
+		Where("company_id = ? AND deleted_at IS NOT NULL", companyId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Order("deleted_at DESC").Order("id").
+		Limit(limit).Offset(offset).Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTrashedTasksByCompanyId: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) GetTrashedTask(ctx context.Context, companyId, id, createUserId uint) (*model.Task, error) {
+	task := &model.Task{}
+	result := r.db.WithContext(ctx).Unscoped().Preload("Assignee").Preload("Labels").
+		Where("id = ? AND company_id = ? AND deleted_at IS NOT NULL", id, companyId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Find(task)
 	if result.Error != nil {
-		return result.Error
+		slog.Info(fmt.Sprintf("error GetTrashedTask: %v", result.Error))
+		return nil, myErrors.ErrDb
 	}
 	if result.RowsAffected == 0 {
-		return myErrors.ErrNotFound
+		return nil, myErrors.ErrNotFound
 	}
-	return nil
+	return task, nil
 }
 
-func (r *taskRepository) DeleteTask(companyId, id uint) error {
-	result := r.db.Where("id = ? AND company_id = ?", id, companyId).Delete(&model.Task{})
+// GetTrashedSubtasks は、ゴミ箱のタスクと同時にゴミ箱に移動したサブタスク(削除日時が同じもの)を取得する。
+// タスクを元に戻すと、これらのサブタスクも元に戻る。
+func (r *taskRepository) GetTrashedSubtasks(ctx context.Context, id uint) ([]*model.Task, error) {
+	db := r.db.WithContext(ctx)
+	subtaskIds, err := selectSubtaskIds(db, id)
+	if err != nil {
+		return nil, err
+	}
+	tasks := []*model.Task{}
+	if len(subtaskIds) == 0 {
+		return tasks, nil
+	}
+	deletedAt := db.Unscoped().Model(&model.Task{}).Select("deleted_at").Where("id = ?", id)
+	result := db.Unscoped().Where("id IN ? AND deleted_at = (?)", subtaskIds, deletedAt).Order("id").Find(&tasks)
 	if result.Error != nil {
-		return result.Error
+		slog.Info(fmt.Sprintf("error GetTrashedSubtasks: %v", result.Error))
+		return nil, myErrors.ErrDb
 	}
-	if result.RowsAffected == 0 {
-		return myErrors.ErrNotFound
+	return tasks, nil
+}
+
+// RestoreTask は、ゴミ箱のタスクを元に戻し、バージョンを1つ進める。
+// 同時にゴミ箱に移動したサブタスク(削除日時が同じもの)も元に戻す。
+// 変更履歴と Webhook で送信するイベントは同じトランザクション内で記録する。
+func (r *taskRepository) RestoreTask(ctx context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		task := &model.Task{}
+		result := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Find(task)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error RestoreTask: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+
+		subtaskIds, err := selectSubtaskIds(tx, id)
+		if err != nil {
+			return err
+		}
+		restoreIds := []uint{}
+		if len(subtaskIds) > 0 {
+			err := tx.Unscoped().Model(&model.Task{}).
+				Where("id IN ? AND deleted_at = ?", subtaskIds, task.DeletedAt).
+				Pluck("id", &restoreIds).Error
+			if err != nil {
+				slog.Info(fmt.Sprintf("error RestoreTask: %v", err))
+				return myErrors.ErrDb
+			}
+		}
+
+		result = tx.Unscoped().Model(&model.Task{}).
+			Where("id IN ?", append([]uint{id}, restoreIds...)).
+			Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error RestoreTask: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if err := createTaskEvent(tx, id, event); err != nil {
+			return err
+		}
+		if err := createSubtaskEvents(tx, restoreIds, event); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, webhookEvents)
+	})
+}
+
+// PurgeTask は、ゴミ箱のタスクを完全に削除し、削除した添付ファイルのストレージのキーを返す。
+// 子孫のタスクと添付ファイルのメタデータは外部キーで削除されるため、ファイルの実体は呼び出し側でストレージから削除する。
+func (r *taskRepository) PurgeTask(ctx context.Context, id uint) ([]string, error) {
+	storageKeys := []string{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		subtaskIds, err := selectSubtaskIds(tx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.TaskAttachment{}).
+			Where("task_id IN ?", append([]uint{id}, subtaskIds...)).
+			Pluck("storage_key", &storageKeys).Error
+		if err != nil {
+			slog.Info(fmt.Sprintf("error PurgeTask: %v", err))
+			return myErrors.ErrDb
+		}
+
+		result := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&model.Task{})
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error PurgeTask: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storageKeys, nil
+}
+
+// PurgeExpiredTasks は、会社ごとの保持期間を過ぎたゴミ箱のタスクを完全に削除し、削除した件数と、
+// 削除した添付ファイルのストレージのキーを返す。ファイルの実体は呼び出し側でストレージから削除する。
+func (r *taskRepository) PurgeExpiredTasks(ctx context.Context) (int64, []string, error) {
+	now := time.Now()
+	var count int64
+	storageKeys := []string{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 子孫のタスクも外部キーで削除されるため、子孫の添付ファイルも含める
+		err := tx.Raw(`WITH RECURSIVE purged (id) AS (
+				SELECT tasks.id FROM tasks
+				INNER JOIN companies ON companies.id = tasks.company_id
+				WHERE tasks.deleted_at IS NOT NULL
+				AND tasks.deleted_at < DATE_SUB(?, INTERVAL companies.trash_retention_days DAY)
+				UNION ALL
+				SELECT tasks.id FROM tasks INNER JOIN purged ON tasks.parent_task_id = purged.id
+			) SELECT DISTINCT task_attachments.storage_key FROM task_attachments
+			INNER JOIN purged ON purged.id = task_attachments.task_id`, now).Scan(&storageKeys).Error
+		if err != nil {
+			slog.Info(fmt.Sprintf("error PurgeExpiredTasks: %v", err))
+			return myErrors.ErrDb
+		}
+
+		result := tx.Exec(`DELETE tasks FROM tasks
+			INNER JOIN companies ON companies.id = tasks.company_id
+			WHERE tasks.deleted_at IS NOT NULL
+			AND tasks.deleted_at < DATE_SUB(?, INTERVAL companies.trash_retention_days DAY)`, now)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error PurgeExpiredTasks: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		count = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return count, storageKeys, nil
+}
+
+// GetTasksWithDueRecurrence は、次の繰り返しの期限が until 以前で、次のタスクがまだ作成されていないタスクを期限の早い順に取得する。
+func (r *taskRepository) GetTasksWithDueRecurrence(ctx context.Context, until time.Time, limit int) ([]*model.Task, error) {
+	tasks := []*model.Task{}
+	result := r.db.WithContext(ctx).Preload("Labels").
+		Where("recurrence_next_due_at <= ?", until).
+		Order("recurrence_next_due_at").Order("id").
+		Limit(limit).Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTasksWithDueRecurrence: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return tasks, nil
+}
+
+// CreateTaskOccurrence は、繰り返しのタスクの次のタスクを作成し、元のタスクの次の繰り返しの期限をクリアする。
+// next が nil の場合は繰り返しが終了したものとして、期限のクリアのみを行う。
+// 次のタスクが既に作成されている場合は ErrConflict を返す。
+// Webhook で送信するイベントは、作成したタスクを newWebhookEvent に渡して作成し、同じトランザクション内で記録する。
+func (r *taskRepository) CreateTaskOccurrence(ctx context.Context, id uint, next *model.Task, event *model.TaskEvent, newWebhookEvent func(task *model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同時に作成されないよう、期限が残っている場合のみクリアする。
+		// 古い期限を読み込んだ更新で戻されないよう、バージョンも上げる
+		result := tx.Model(&model.Task{}).
+			Where("id = ? AND recurrence_next_due_at IS NOT NULL", id).
+			Updates(map[string]any{"recurrence_next_due_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskOccurrence: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrConflict
+		}
+		if next == nil {
+			return nil
+		}
+
+		next.Version = 1
+		if err := tx.Omit(clause.Associations).Create(next).Error; err != nil {
+			return myErrors.ErrDb
+		}
+		if len(next.Labels) > 0 {
+			if err := replaceTaskLabels(tx, next.ID, next.Labels); err != nil {
+				return err
+			}
+		}
+		if err := createTaskEvent(tx, next.ID, event); err != nil {
+			return err
+		}
+		return createNewTaskWebhookEvent(tx, next, newWebhookEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// applyTaskFilter は、タスク一覧の絞り込み条件をクエリに適用する。
+func applyTaskFilter(query *gorm.DB, filter *model.TaskFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.AssigneeID != nil {
+		query = query.Where("assignee_id = ?", *filter.AssigneeID)
+	}
+	if filter.CreateUserID != nil {
+		query = query.Where("create_user_id = ?", *filter.CreateUserID)
+	}
+	if filter.Visibility != nil {
+		query = query.Where("visibility = ?", *filter.Visibility)
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("due_date < ?", *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		query = query.Where("due_date > ?", *filter.DueAfter)
+	}
+	if filter.Overdue {
+		query = query.Where("due_date < ? AND status != 'done'", time.Now())
+	}
+	if len(filter.Labels) > 0 {
+		labels := filter.Labels
+		subQuery := query.Session(&gorm.Session{NewDB: true}).
+			Table("task_labels").
+			Select("task_labels.task_id").
+			Joins("INNER JOIN labels ON labels.id = task_labels.label_id").
+			Where("labels.name IN ?", labels)
+		if filter.LabelMatch == model.LABEL_MATCH_ALL {
+			subQuery = subQuery.Group("task_labels.task_id").Having("COUNT(DISTINCT labels.id) = ?", len(labels))
+		}
+		query = query.Where("tasks.id IN (?)", subQuery)
+	}
+	return query
+}
+
+// replaceTaskLabels は、タスクに付けるラベルを labels に置き換える。
+func replaceTaskLabels(tx *gorm.DB, taskId uint, labels []*model.Label) error {
+	if err := tx.Exec("DELETE FROM task_labels WHERE task_id = ?", taskId).Error; err != nil {
+		slog.Info(fmt.Sprintf("error replaceTaskLabels: %v", err))
+		return myErrors.ErrDb
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	rows := []map[string]any{}
+	for _, label := range labels {
+		rows = append(rows, map[string]any{"task_id": taskId, "label_id": label.ID})
+	}
+	if err := tx.Table("task_labels").Create(rows).Error; err != nil {
+		slog.Info(fmt.Sprintf("error replaceTaskLabels: %v", err))
+		return myErrors.ErrDb
 	}
 	return nil
 }
+
+// selectSubtaskIds は、ゴミ箱のタスクを含め、タスクの全ての子孫のIDを取得する。
+func selectSubtaskIds(tx *gorm.DB, id uint) ([]uint, error) {
+	ids := []uint{}
+	err := tx.Raw(`WITH RECURSIVE subtree (id) AS (
+			SELECT id FROM tasks WHERE parent_task_id = ?
+			UNION ALL
+			SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.parent_task_id = subtree.id
+		) SELECT id FROM subtree`, id).Scan(&ids).Error
+	if err != nil {
+		slog.Info(fmt.Sprintf("error selectSubtaskIds: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return ids, nil
+}
+
+// deleteSubtasks は、ゴミ箱にない子孫のタスクを、親と同じ削除日時でゴミ箱に移動する。
+// 削除日時を揃えることで、親を元に戻すときに一緒に元に戻せるようにする。
+func deleteSubtasks(tx *gorm.DB, id uint, event *model.TaskEvent) error {
+	subtaskIds, err := selectSubtaskIds(tx, id)
+	if err != nil || len(subtaskIds) == 0 {
+		return err
+	}
+
+	deleteIds := []uint{}
+	if err := tx.Model(&model.Task{}).Where("id IN ?", subtaskIds).Pluck("id", &deleteIds).Error; err != nil {
+		slog.Info(fmt.Sprintf("error deleteSubtasks: %v", err))
+		return myErrors.ErrDb
+	}
+	if len(deleteIds) == 0 {
+		return nil
+	}
+	var deletedAt gorm.DeletedAt
+	if err := tx.Unscoped().Model(&model.Task{}).Where("id = ?", id).Pluck("deleted_at", &deletedAt).Error; err != nil {
+		slog.Info(fmt.Sprintf("error deleteSubtasks: %v", err))
+		return myErrors.ErrDb
+	}
+	if err := tx.Model(&model.Task{}).Where("id IN ?", deleteIds).Update("deleted_at", deletedAt).Error; err != nil {
+		slog.Info(fmt.Sprintf("error deleteSubtasks: %v", err))
+		return myErrors.ErrDb
+	}
+	return createSubtaskEvents(tx, deleteIds, event)
+}
+
+// createSubtaskEvents は、親のタスクの操作に伴う子孫のタスクの変更履歴を、親と同じ操作者と操作種別で記録する。
+func createSubtaskEvents(tx *gorm.DB, subtaskIds []uint, event *model.TaskEvent) error {
+	for _, subtaskId := range subtaskIds {
+		subtaskEvent := *event
+		subtaskEvent.ID = 0
+		subtaskEvent.Changes = map[string]model.TaskFieldChange{}
+		if err := createTaskEvent(tx, subtaskId, &subtaskEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTaskProgress は、タスクごとに直下のサブタスクとチェックリストの進捗を集計する。
+func loadTaskProgress(db *gorm.DB, tasks []*model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := []uint{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	type count struct {
+		TaskID uint
+		Done   int64
+		Total  int64
+	}
+	subtaskCounts := []*count{}
+	err := db.Model(&model.Task{}).
+		Select("parent_task_id AS task_id, SUM(status = 'done') AS done, COUNT(*) AS total").
+		Where("parent_task_id IN ?", ids).
+		Group("parent_task_id").
+		Scan(&subtaskCounts).Error
+	if err != nil {
+		slog.Info(fmt.Sprintf("error loadTaskProgress: %v", err))
+		return myErrors.ErrDb
+	}
+	checklistCounts := []*count{}
+	err = db.Model(&model.TaskChecklistItem{}).
+		Select("task_id, SUM(done) AS done, COUNT(*) AS total").
+		Where("task_id IN ?", ids).
+		Group("task_id").
+		Scan(&checklistCounts).Error
+	if err != nil {
+		slog.Info(fmt.Sprintf("error loadTaskProgress: %v", err))
+		return myErrors.ErrDb
+	}
+
+	progress := map[uint]*model.TaskProgress{}
+	for _, task := range tasks {
+		progress[task.ID] = &task.Progress
+	}
+	for _, c := range subtaskCounts {
+		progress[c.TaskID].SubtasksDone = c.Done
+		progress[c.TaskID].SubtasksTotal = c.Total
+	}
+	for _, c := range checklistCounts {
+		progress[c.TaskID].ChecklistDone = c.Done
+		progress[c.TaskID].ChecklistTotal = c.Total
+	}
+	return nil
+}
+
+// applyTaskPagination は、ソート条件とページング条件をクエリに適用する。
+// カーソルが指定された場合は、カーソルの行より後(prev の場合は前)の行に絞り込む。
+// prev の場合は並び順を反転して取得するため、呼び出し側で結果を反転させる必要がある。
+// ソートのカラム名は許可リストから取得したもののみを使用する。
+func applyTaskPagination(query *gorm.DB, filter *model.TaskFilter, pagination *model.TaskPagination) (*gorm.DB, error) {
+	keys := filter.OrderKeys()
+	reverse := pagination.Cursor != nil && pagination.Cursor.Direction == model.CURSOR_DIRECTION_PREV
+	if reverse {
+		for i := range keys {
+			keys[i].Desc = !keys[i].Desc
+		}
+	}
+
+	if pagination.Cursor != nil {
+		sql, args, err := taskKeysetCondition(keys, pagination.Cursor.Values)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(sql, args...)
+	} else {
+		query = query.Offset(pagination.Offset)
+	}
+
+	for _, key := range keys {
+		column, ok := key.Column()
+		if !ok {
+			continue
+		}
+		if key.Desc {
+			column += " DESC"
+		}
+		query = query.Order(column)
+	}
+	return query.Limit(pagination.Limit + 1), nil
+}
+
+// taskKeysetCondition は、並び順で境界の行より後にある行を取得する条件を組み立てる。
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... の形の条件となる。
+// MySQL では NULL は最小の値として並ぶため、NULL を含むカラムはそれに合わせて比較する。
+func taskKeysetCondition(keys []model.TaskSort, values []*string) (string, []any, error) {
+	conditions := []string{}
+	args := []any{}
+	equals := []string{}
+	equalArgs := []any{}
+
+	for i, key := range keys {
+		column, ok := key.Column()
+		if !ok {
+			return "", nil, myErrors.ErrInvalidArgument
+		}
+		value, err := key.ParseValue(values[i])
+		if err != nil {
+			return "", nil, err
+		}
+
+		var after string
+		afterArgs := []any{}
+		switch {
+		case value == nil && key.Desc:
+			after = ""
+		case value == nil:
+			after = column + " IS NOT NULL"
+		case key.Desc && key.Nullable():
+			after = "(" + column + " < ? OR " + column + " IS NULL)"
+			afterArgs = append(afterArgs, value)
+		case key.Desc:
+			after = column + " < ?"
+			afterArgs = append(afterArgs, value)
+		default:
+			after = column + " > ?"
+			afterArgs = append(afterArgs, value)
+		}
+		if after != "" {
+			conditions = append(conditions, "("+strings.Join(append(append([]string{}, equals...), after), " AND ")+")")
+			args = append(append(args, equalArgs...), afterArgs...)
+		}
+
+		if value == nil {
+			equals = append(equals, column+" IS NULL")
+		} else {
+			equals = append(equals, column+" = ?")
+			equalArgs = append(equalArgs, value)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskAttachmentRepository interface {
+	GetTaskAttachments(ctx context.Context, taskId uint) ([]*model.TaskAttachment, error)
+	GetTaskAttachment(ctx context.Context, taskId, id uint) (*model.TaskAttachment, error)
+	GetAttachmentUsage(ctx context.Context, companyId uint) (int64, error)
+	CreateTaskAttachment(ctx context.Context, attachment *model.TaskAttachment, quotaBytes int64) (*model.TaskAttachment, error)
+	DeleteTaskAttachment(ctx context.Context, taskId, id uint) error
+}
+
+type taskAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskAttachmentRepository(db *gorm.DB) TaskAttachmentRepository {
+	return &taskAttachmentRepository{db: db}
+}
+
+func (r *taskAttachmentRepository) GetTaskAttachments(ctx context.Context, taskId uint) ([]*model.TaskAttachment, error) {
+	attachments := []*model.TaskAttachment{}
+	result := r.db.WithContext(ctx).Where("task_id = ?", taskId).Order("id").Find(&attachments)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskAttachments: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return attachments, nil
+}
+
+func (r *taskAttachmentRepository) GetTaskAttachment(ctx context.Context, taskId, id uint) (*model.TaskAttachment, error) {
+	attachment := &model.TaskAttachment{}
+	result := r.db.WithContext(ctx).Where("id = ? AND task_id = ?", id, taskId).Find(attachment)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskAttachment: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return attachment, nil
+}
+
+// GetAttachmentUsage は、会社の添付ファイルの合計サイズを返す。
+func (r *taskAttachmentRepository) GetAttachmentUsage(ctx context.Context, companyId uint) (int64, error) {
+	return sumAttachmentSize(r.db.WithContext(ctx), companyId)
+}
+
+// CreateTaskAttachment は、添付ファイルのメタデータを作成する。
+// 同時にアップロードされた場合も上限を超えないよう、会社の行をロックした上で合計サイズを確認する。
+// 合計サイズが quotaBytes を超える場合は ErrQuotaExceeded を返す。
+func (r *taskAttachmentRepository) CreateTaskAttachment(ctx context.Context, attachment *model.TaskAttachment, quotaBytes int64) (*model.TaskAttachment, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&model.Company{}, attachment.CompanyID)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskAttachment: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+
+		usage, err := sumAttachmentSize(tx, attachment.CompanyID)
+		if err != nil {
+			return err
+		}
+		if usage+attachment.Size > quotaBytes {
+			return myErrors.ErrQuotaExceeded
+		}
+
+		if err := tx.Create(attachment).Error; err != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskAttachment: %v", err))
+			return myErrors.ErrDb
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *taskAttachmentRepository) DeleteTaskAttachment(ctx context.Context, taskId, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND task_id = ?", id, taskId).Delete(&model.TaskAttachment{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteTaskAttachment: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
+
+func sumAttachmentSize(db *gorm.DB, companyId uint) (int64, error) {
+	var usage int64
+	result := db.Model(&model.TaskAttachment{}).
+		Where("company_id = ?", companyId).
+		Select("COALESCE(SUM(size), 0)").Scan(&usage)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error sumAttachmentSize: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return usage, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskChecklistItemRepository interface {
+	GetTaskChecklistItems(ctx context.Context, taskId uint) ([]*model.TaskChecklistItem, error)
+	GetTaskChecklistItem(ctx context.Context, taskId, id uint) (*model.TaskChecklistItem, error)
+	CreateTaskChecklistItem(ctx context.Context, item *model.TaskChecklistItem, maxItems int) (*model.TaskChecklistItem, error)
+	UpdateTaskChecklistItem(ctx context.Context, item *model.TaskChecklistItem) (*model.TaskChecklistItem, error)
+	DeleteTaskChecklistItem(ctx context.Context, taskId, id uint) error
+}
+
+type taskChecklistItemRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskChecklistItemRepository(db *gorm.DB) TaskChecklistItemRepository {
+	return &taskChecklistItemRepository{db: db}
+}
+
+// GetTaskChecklistItems は、タスクのチェックリストを表示順に取得する。
+func (r *taskChecklistItemRepository) GetTaskChecklistItems(ctx context.Context, taskId uint) ([]*model.TaskChecklistItem, error) {
+	items := []*model.TaskChecklistItem{}
+	result := r.db.WithContext(ctx).Where("task_id = ?", taskId).Order("position").Order("id").Find(&items)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskChecklistItems: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return items, nil
+}
+
+func (r *taskChecklistItemRepository) GetTaskChecklistItem(ctx context.Context, taskId, id uint) (*model.TaskChecklistItem, error) {
+	item := &model.TaskChecklistItem{}
+	result := r.db.WithContext(ctx).Where("id = ? AND task_id = ?", id, taskId).Find(item)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskChecklistItem: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return item, nil
+}
+
+// CreateTaskChecklistItem は、チェックリストの末尾に項目を追加する。
+// 同時に追加された場合も上限数を超えないよう、タスクの行をロックした上で件数を確認する。
+// 上限数に達している場合は ErrQuotaExceeded を返す。
+func (r *taskChecklistItemRepository) CreateTaskChecklistItem(ctx context.Context, item *model.TaskChecklistItem, maxItems int) (*model.TaskChecklistItem, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&model.Task{}, item.TaskID)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskChecklistItem: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+
+		var stats struct {
+			Count       int
+			MaxPosition int
+		}
+		err := tx.Model(&model.TaskChecklistItem{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS max_position").
+			Where("task_id = ?", item.TaskID).
+			Scan(&stats).Error
+		if err != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskChecklistItem: %v", err))
+			return myErrors.ErrDb
+		}
+		if stats.Count >= maxItems {
+			return myErrors.ErrQuotaExceeded
+		}
+
+		item.Position = stats.MaxPosition + 1
+		if err := tx.Create(item).Error; err != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskChecklistItem: %v", err))
+			return myErrors.ErrDb
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *taskChecklistItemRepository) UpdateTaskChecklistItem(ctx context.Context, item *model.TaskChecklistItem) (*model.TaskChecklistItem, error) {
+	if err := r.db.WithContext(ctx).Model(item).Select("title", "done").Updates(item).Error; err != nil {
+		slog.Info(fmt.Sprintf("error UpdateTaskChecklistItem: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return item, nil
+}
+
+func (r *taskChecklistItemRepository) DeleteTaskChecklistItem(ctx context.Context, taskId, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND task_id = ?", id, taskId).Delete(&model.TaskChecklistItem{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteTaskChecklistItem: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+)
+
+type TaskCommentRepository interface {
+	GetTaskComments(ctx context.Context, taskId uint, limit, offset int) ([]*model.TaskComment, error)
+	GetTaskComment(ctx context.Context, taskId, id uint) (*model.TaskComment, error)
+	CreateTaskComment(ctx context.Context, comment *model.TaskComment) (*model.TaskComment, error)
+	UpdateTaskComment(ctx context.Context, comment *model.TaskComment) (*model.TaskComment, error)
+	DeleteTaskComment(ctx context.Context, taskId, id uint) error
+}
+
+type taskCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskCommentRepository(db *gorm.DB) TaskCommentRepository {
+	return &taskCommentRepository{db: db}
+}
+
+// GetTaskComments は、タスクのコメントを投稿の古い順に取得する。
+func (r *taskCommentRepository) GetTaskComments(ctx context.Context, taskId uint, limit, offset int) ([]*model.TaskComment, error) {
+	comments := []*model.TaskComment{}
+	result := r.db.WithContext(ctx).Preload("User").
+		Where("task_id = ?", taskId).
+		Order("created_at").Order("id").
+		Limit(limit).Offset(offset).Find(&comments)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskComments: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return comments, nil
+}
+
+func (r *taskCommentRepository) GetTaskComment(ctx context.Context, taskId, id uint) (*model.TaskComment, error) {
+	comment := &model.TaskComment{}
+	result := r.db.WithContext(ctx).Preload("User").Where("id = ? AND task_id = ?", id, taskId).Find(comment)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskComment: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return comment, nil
+}
+
+func (r *taskCommentRepository) CreateTaskComment(ctx context.Context, comment *model.TaskComment) (*model.TaskComment, error) {
+	if err := r.db.WithContext(ctx).Omit("User").Create(comment).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateTaskComment: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return comment, nil
+}
+
+func (r *taskCommentRepository) UpdateTaskComment(ctx context.Context, comment *model.TaskComment) (*model.TaskComment, error) {
+	if err := r.db.WithContext(ctx).Model(comment).Update("body", comment.Body).Error; err != nil {
+		slog.Info(fmt.Sprintf("error UpdateTaskComment: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return comment, nil
+}
+
+func (r *taskCommentRepository) DeleteTaskComment(ctx context.Context, taskId, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND task_id = ?", id, taskId).Delete(&model.TaskComment{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteTaskComment: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskDependencyRepository interface {
+	GetBlockers(ctx context.Context, companyId, taskId, createUserId uint) ([]*model.Task, error)
+	GetDependents(ctx context.Context, companyId, taskId, createUserId uint) ([]*model.Task, error)
+	CountUnfinishedBlockers(ctx context.Context, taskId uint) (int64, error)
+	GetTaskDependencyGraph(ctx context.Context, companyId, createUserId uint) (*model.TaskDependencyGraph, error)
+	CreateTaskDependency(ctx context.Context, dependency *model.TaskDependency, validate func(reachableTaskIds []uint) error) (*model.TaskDependency, error)
+	DeleteTaskDependency(ctx context.Context, taskId, blockedByTaskId uint) error
+}
+
+type taskDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskDependencyRepository(db *gorm.DB) TaskDependencyRepository {
+	return &taskDependencyRepository{db: db}
+}
+
+// GetBlockers は、タスクの完了を妨げているタスクのうち、閲覧できるものを取得する。
+func (r *taskDependencyRepository) GetBlockers(ctx context.Context, companyId, taskId, createUserId uint) ([]*model.Task, error) {
+	blockerIds := r.db.WithContext(ctx).Model(&model.TaskDependency{}).Select("blocked_by_task_id").Where("task_id = ?", taskId)
+	tasks := []*model.Task{}
+	result := r.db.WithContext(ctx).Where("company_id = ? AND id IN (?)", companyId, blockerIds).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Order("id").Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetBlockers: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return tasks, nil
+}
+
+// GetDependents は、タスクの完了を待っているタスクのうち、閲覧できるものを取得する。
+func (r *taskDependencyRepository) GetDependents(ctx context.Context, companyId, taskId, createUserId uint) ([]*model.Task, error) {
+	dependentIds := r.db.WithContext(ctx).Model(&model.TaskDependency{}).Select("task_id").Where("blocked_by_task_id = ?", taskId)
+	tasks := []*model.Task{}
+	result := r.db.WithContext(ctx).Where("company_id = ? AND id IN (?)", companyId, dependentIds).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Order("id").Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetDependents: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return tasks, nil
+}
+
+// CountUnfinishedBlockers は、タスクの完了を妨げている未完了のタスクの数を返す。
+// 閲覧できるかどうかに関わらず数え、ゴミ箱のタスクは数えない。
+func (r *taskDependencyRepository) CountUnfinishedBlockers(ctx context.Context, taskId uint) (int64, error) {
+	blockerIds := r.db.WithContext(ctx).Model(&model.TaskDependency{}).Select("blocked_by_task_id").Where("task_id = ?", taskId)
+	var count int64
+	result := r.db.WithContext(ctx).Model(&model.Task{}).
+		Where("id IN (?) AND status != ?", blockerIds, model.TASK_STATUS_DONE).
+		Count(&count)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CountUnfinishedBlockers: %v", result.Error))
+		return 0, myErrors.ErrDb
+	}
+	return count, nil
+}
+
+// GetTaskDependencyGraph は、会社の依存関係のうち、両端のタスクを閲覧できるものと、それらのタスクを取得する。
+// ゴミ箱のタスクの依存関係は含めない。
+func (r *taskDependencyRepository) GetTaskDependencyGraph(ctx context.Context, companyId, createUserId uint) (*model.TaskDependencyGraph, error) {
+	visibleTaskIds := r.db.WithContext(ctx).Model(&model.Task{}).Select("id").
+		Where("company_id = ?", companyId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId)
+
+	dependencies := []*model.TaskDependency{}
+	result := r.db.WithContext(ctx).Where("company_id = ?", companyId).
+		Where("task_id IN (?) AND blocked_by_task_id IN (?)", visibleTaskIds, visibleTaskIds).
+		Order("task_id").Order("blocked_by_task_id").
+		Find(&dependencies)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskDependencyGraph: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+
+	graph := &model.TaskDependencyGraph{Tasks: []*model.Task{}, Dependencies: dependencies}
+	if len(dependencies) == 0 {
+		return graph, nil
+	}
+	taskIds := []uint{}
+	for _, dependency := range dependencies {
+		taskIds = append(taskIds, dependency.TaskID, dependency.BlockedByTaskID)
+	}
+	result = r.db.WithContext(ctx).Where("id IN ?", taskIds).Order("id").Find(&graph.Tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskDependencyGraph: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return graph, nil
+}
+
+// CreateTaskDependency は、依存関係を作成する。
+// 同時に追加された場合も循環しないよう、会社の行をロックした上で、ブロッカーから依存関係を辿って到達できるタスクの ID を
+// validate に渡す。validate がエラーを返した場合は作成せずにそのエラーを返す。既に同じ依存関係がある場合は ErrConflict を返す。
+func (r *taskDependencyRepository) CreateTaskDependency(ctx context.Context, dependency *model.TaskDependency, validate func(reachableTaskIds []uint) error) (*model.TaskDependency, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&model.Company{}, dependency.CompanyID)
+		if result.Error != nil {
+			slog.Info(fmt.Sprintf("error CreateTaskDependency: %v", result.Error))
+			return myErrors.ErrDb
+		}
+		if result.RowsAffected == 0 {
+			return myErrors.ErrNotFound
+		}
+
+		reachableTaskIds, err := selectReachableTaskIds(tx, dependency.BlockedByTaskID)
+		if err != nil {
+			return err
+		}
+		if err := validate(reachableTaskIds); err != nil {
+			return err
+		}
+
+		if err := tx.Create(dependency).Error; err != nil {
+			if isDuplicatedKeyError(tx, err) {
+				return myErrors.ErrConflict
+			}
+			slog.Info(fmt.Sprintf("error CreateTaskDependency: %v", err))
+			return myErrors.ErrDb
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dependency, nil
+}
+
+// selectReachableTaskIds は、id のタスクから依存関係を辿って到達できるタスクの ID を返す。id 自身は含めない。
+func selectReachableTaskIds(tx *gorm.DB, id uint) ([]uint, error) {
+	ids := []uint{}
+	err := tx.Raw(`WITH RECURSIVE reachable (id) AS (
+			SELECT blocked_by_task_id FROM task_dependencies WHERE task_id = ?
+			UNION
+			SELECT task_dependencies.blocked_by_task_id FROM task_dependencies INNER JOIN reachable ON task_dependencies.task_id = reachable.id
+		) SELECT id FROM reachable`, id).Scan(&ids).Error
+	if err != nil {
+		slog.Info(fmt.Sprintf("error selectReachableTaskIds: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return ids, nil
+}
+
+// isDuplicatedKeyError は、err が一意制約違反によるものかを返す。
+func isDuplicatedKeyError(db *gorm.DB, err error) bool {
+	translator, ok := db.Dialector.(gorm.ErrorTranslator)
+	if !ok {
+		return false
+	}
+	return errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+}
+
+func (r *taskDependencyRepository) DeleteTaskDependency(ctx context.Context, taskId, blockedByTaskId uint) error {
+	result := r.db.WithContext(ctx).Where("task_id = ? AND blocked_by_task_id = ?", taskId, blockedByTaskId).Delete(&model.TaskDependency{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteTaskDependency: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+)
+
+type TaskEventRepository interface {
+	GetTaskEvents(ctx context.Context, taskId uint, limit, offset int) ([]*model.TaskEvent, error)
+}
+
+type taskEventRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskEventRepository(db *gorm.DB) TaskEventRepository {
+	return &taskEventRepository{db: db}
+}
+
+// GetTaskEvents は、タスクの変更履歴を新しい順に取得する。
+func (r *taskEventRepository) GetTaskEvents(ctx context.Context, taskId uint, limit, offset int) ([]*model.TaskEvent, error) {
+	events := []*model.TaskEvent{}
+	result := r.db.WithContext(ctx).Preload("Actor").
+		Where("task_id = ?", taskId).
+		Order("created_at DESC").Order("id DESC").
+		Limit(limit).Offset(offset).Find(&events)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTaskEvents: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return events, nil
+}
+
+// createTaskEvent は、タスクの変更と同じトランザクション内で変更履歴を記録する。
+func createTaskEvent(tx *gorm.DB, taskId uint, event *model.TaskEvent) error {
+	event.TaskID = taskId
+	if err := tx.Omit("Actor").Create(event).Error; err != nil {
+		slog.Info(fmt.Sprintf("error createTaskEvent: %v", err))
+		return myErrors.ErrDb
+	}
+	return nil
+}
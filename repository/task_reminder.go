@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskReminderRepository interface {
+	GetTasksToRemind(ctx context.Context, now time.Time, window time.Duration, limit int) ([]*model.Task, error)
+	CreateTaskReminder(ctx context.Context, reminder *model.TaskReminder) error
+	DeleteTaskReminder(ctx context.Context, reminder *model.TaskReminder) error
+}
+
+type taskReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskReminderRepository(db *gorm.DB) TaskReminderRepository {
+	return &taskReminderRepository{db: db}
+}
+
+// GetTasksToRemind は、未完了のタスクのうち、期限の window 前を過ぎ、window 以下のリマインダーをまだ送っていないタスクを期限の早い順に取得する。
+// window が0の場合は期限を過ぎたタスク、それ以外の場合はまだ期限を過ぎていないタスクを対象とする。
+func (r *taskReminderRepository) GetTasksToRemind(ctx context.Context, now time.Time, window time.Duration, limit int) ([]*model.Task, error) {
+	tasks := []*model.Task{}
+	query := r.db.WithContext(ctx).Preload("Assignee").
+		Where("status != ? AND due_date <= ?", model.TASK_STATUS_DONE, now.Add(window))
+	if window != model.TASK_REMINDER_OVERDUE {
+		query = query.Where("due_date > ?", now)
+	}
+	// より直前のリマインダーを送っている場合は、それより前のリマインダーは送らない
+	result := query.
+		Where(`NOT EXISTS (SELECT 1 FROM task_reminders
+			WHERE task_reminders.task_id = tasks.id
+			AND task_reminders.due_date = tasks.due_date
+			AND task_reminders.window_seconds <= ?)`, int64(window/time.Second)).
+		Order("due_date").Order("id").
+		Limit(limit).Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetTasksToRemind: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return tasks, nil
+}
+
+// CreateTaskReminder は、リマインダーを記録する。既に記録されている場合は ErrConflict を返す。
+func (r *taskReminderRepository) CreateTaskReminder(ctx context.Context, reminder *model.TaskReminder) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error CreateTaskReminder: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrConflict
+	}
+	return nil
+}
+
+// DeleteTaskReminder は、送信できなかったリマインダーの記録を削除する。
+func (r *taskReminderRepository) DeleteTaskReminder(ctx context.Context, reminder *model.TaskReminder) error {
+	result := r.db.WithContext(ctx).
+		Where("task_id = ? AND due_date = ? AND window_seconds = ?", reminder.TaskID, reminder.DueDate, reminder.WindowSeconds).
+		Delete(&model.TaskReminder{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteTaskReminder: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	return nil
+}
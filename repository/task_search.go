@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskSearchRepository はタスクの全文検索を行う。
+// 検索エンジンを差し替える場合は、このインターフェースを実装する。
+type TaskSearchRepository interface {
+	SearchTasks(ctx context.Context, companyId, createUserId uint, query string, limit, offset int) ([]*model.Task, error)
+}
+
+// taskSearchRepository は MySQL の FULLTEXT インデックス(ngram パーサー)を使用した全文検索の実装
+type taskSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskSearchRepository(db *gorm.DB) TaskSearchRepository {
+	return &taskSearchRepository{db: db}
+}
+
+// SearchTasks は、タイトルと説明から検索し、関連度の高い順にタスクを返す。
+func (r *taskSearchRepository) SearchTasks(ctx context.Context, companyId, createUserId uint, query string, limit, offset int) ([]*model.Task, error) {
+	tasks := []*model.Task{}
+	match := "MATCH(title, description) AGAINST(? IN NATURAL LANGUAGE MODE)"
+	result := r.db.WithContext(ctx).Preload("Assignee").Preload("Labels").
+		Where("company_id = ?", companyId).
+		Where("visibility = 'private' AND create_user_id = ? OR visibility != 'private'", createUserId).
+		Where(match, query).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: match + " DESC", Vars: []any{query}}}).
+		Order("id").
+		Limit(limit).Offset(offset).Find(&tasks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error SearchTasks: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if err := loadTaskProgress(r.db.WithContext(ctx), tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	myErrors "todo-api/errors"
@@ -10,9 +11,9 @@ import (
 )
 
 type UserRepository interface {
-	GetUser(id uint) (*model.User, error)
-	GetUserByUsername(username string) (*model.User, error)
-	CreateUser(user *model.User) (*model.User, error)
+	GetUser(ctx context.Context, id uint) (*model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
 }
 
 type userRepository struct {
@@ -23,9 +24,9 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) GetUser(id uint) (*model.User, error) {
+func (r *userRepository) GetUser(ctx context.Context, id uint) (*model.User, error) {
 	user := &model.User{}
-	result := r.db.Find(user, "id = ?", id)
+	result := r.db.WithContext(ctx).Find(user, "id = ?", id)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetUser: %v", result.Error))
 		return nil, myErrors.ErrDb
@@ -36,9 +37,9 @@ func (r *userRepository) GetUser(id uint) (*model.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) GetUserByUsername(username string) (*model.User, error) {
+func (r *userRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	user := &model.User{}
-	result := r.db.Find(user, "username = ?", username)
+	result := r.db.WithContext(ctx).Find(user, "username = ?", username)
 	if result.Error != nil {
 		slog.Info(fmt.Sprintf("error GetUserByUsername: %v", result.Error))
 		return nil, myErrors.ErrDb
@@ -49,8 +50,8 @@ func (r *userRepository) GetUserByUsername(username string) (*model.User, error)
 	return user, nil
 }
 
-func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
-	if err := r.db.Create(user).Error; err != nil {
+func (r *userRepository) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
 		return nil, myErrors.ErrDb
 	}
 	return user, nil
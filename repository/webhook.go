@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context, companyId uint) ([]*model.Webhook, error)
+	GetWebhook(ctx context.Context, companyId, id uint) (*model.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, companyId, id uint) error
+	GetWebhookDeliveries(ctx context.Context, webhookId uint, limit, offset int) ([]*model.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, webhookId, id uint) (*model.WebhookDelivery, error)
+	CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error)
+	DispatchWebhookEvents(ctx context.Context, now time.Time, limit int) (int, error)
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+	ClaimWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery, now, leaseUntil time.Time) error
+	SaveWebhookDeliveryAttempt(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) GetWebhooks(ctx context.Context, companyId uint) ([]*model.Webhook, error) {
+	webhooks := []*model.Webhook{}
+	result := r.db.WithContext(ctx).Where("company_id = ?", companyId).Order("id").Find(&webhooks)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetWebhooks: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) GetWebhook(ctx context.Context, companyId, id uint) (*model.Webhook, error) {
+	webhook := &model.Webhook{}
+	result := r.db.WithContext(ctx).Where("id = ? AND company_id = ?", id, companyId).Find(webhook)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetWebhook: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return webhook, nil
+}
+
+func (r *webhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	if err := r.db.WithContext(ctx).Create(webhook).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateWebhook: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return webhook, nil
+}
+
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	result := r.db.WithContext(ctx).Model(webhook).
+		Where("company_id = ?", webhook.CompanyID).
+		Select("url", "secret", "event_types", "active").
+		Updates(webhook)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error UpdateWebhook: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook は、Webhook を削除する。配信の記録は外部キーにより削除される。
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, companyId, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND company_id = ?", id, companyId).Delete(&model.Webhook{})
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error DeleteWebhook: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrNotFound
+	}
+	return nil
+}
+
+// GetWebhookDeliveries は、Webhook の配信を新しい順に、イベントと合わせて取得する。
+func (r *webhookRepository) GetWebhookDeliveries(ctx context.Context, webhookId uint, limit, offset int) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+	result := r.db.WithContext(ctx).Preload("WebhookEvent").
+		Where("webhook_id = ?", webhookId).
+		Order("id DESC").
+		Limit(limit).Offset(offset).Find(&deliveries)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetWebhookDeliveries: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) GetWebhookDelivery(ctx context.Context, webhookId, id uint) (*model.WebhookDelivery, error) {
+	delivery := &model.WebhookDelivery{}
+	result := r.db.WithContext(ctx).Preload("WebhookEvent").Where("id = ? AND webhook_id = ?", id, webhookId).Find(delivery)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetWebhookDelivery: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return nil, myErrors.ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (r *webhookRepository) CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(delivery).Error; err != nil {
+		slog.Info(fmt.Sprintf("error CreateWebhookDelivery: %v", err))
+		return nil, myErrors.ErrDb
+	}
+	return delivery, nil
+}
+
+// DispatchWebhookEvents は、振り分けを待っているイベントを古い順に limit 件まで取得し、購読している Webhook ごとの配信を作成する。
+// 配信の作成と振り分け済みの記録は同じトランザクションで行い、他のプロセスが処理中のイベントは SKIP LOCKED で飛ばすため、
+// 同じイベントの配信を重複して作成しない。振り分けたイベントの件数を返す。
+func (r *webhookRepository) DispatchWebhookEvents(ctx context.Context, now time.Time, limit int) (int, error) {
+	count := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		events := []*model.WebhookEvent{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL").
+			Order("id").Limit(limit).Find(&events).Error
+		if err != nil {
+			slog.Info(fmt.Sprintf("error DispatchWebhookEvents: %v", err))
+			return myErrors.ErrDb
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		companyIds := []uint{}
+		eventIds := []uint{}
+		for _, event := range events {
+			companyIds = append(companyIds, event.CompanyID)
+			eventIds = append(eventIds, event.ID)
+		}
+		webhooks := []*model.Webhook{}
+		if err := tx.Where("company_id IN ? AND active = ?", companyIds, true).Find(&webhooks).Error; err != nil {
+			slog.Info(fmt.Sprintf("error DispatchWebhookEvents: %v", err))
+			return myErrors.ErrDb
+		}
+
+		deliveries := []*model.WebhookDelivery{}
+		for _, event := range events {
+			for _, webhook := range webhooks {
+				if webhook.CompanyID == event.CompanyID && webhook.Subscribes(event.Type) {
+					deliveries = append(deliveries, model.NewWebhookDelivery(webhook.ID, event.ID, false, now))
+				}
+			}
+		}
+		if len(deliveries) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&deliveries).Error; err != nil {
+				slog.Info(fmt.Sprintf("error DispatchWebhookEvents: %v", err))
+				return myErrors.ErrDb
+			}
+		}
+		if err := tx.Model(&model.WebhookEvent{}).Where("id IN ?", eventIds).Update("dispatched_at", now).Error; err != nil {
+			slog.Info(fmt.Sprintf("error DispatchWebhookEvents: %v", err))
+			return myErrors.ErrDb
+		}
+		count = len(events)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetDueWebhookDeliveries は、送信の日時を過ぎた送信待ちの配信を、Webhook とイベントと合わせて古い順に取得する。
+// 無効にした Webhook の配信は、有効に戻すまで送信しない。
+func (r *webhookRepository) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+	result := r.db.WithContext(ctx).Preload("Webhook").Preload("WebhookEvent").
+		Joins("INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id AND webhooks.active = ?", true).
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", model.WEBHOOK_DELIVERY_STATUS_PENDING, now).
+		Order("webhook_deliveries.next_attempt_at").Order("webhook_deliveries.id").
+		Limit(limit).Find(&deliveries)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error GetDueWebhookDeliveries: %v", result.Error))
+		return nil, myErrors.ErrDb
+	}
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery は、送信する前に次の送信の日時を leaseUntil まで進め、他のプロセスが同じ配信を送信しないようにする。
+// 送信中に停止した場合は、leaseUntil を過ぎてから再び送信される。
+// 既に他のプロセスが送信を始めていた場合は ErrConflict を返す。
+func (r *webhookRepository) ClaimWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery, now, leaseUntil time.Time) error {
+	result := r.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_attempt_at <= ?", delivery.ID, model.WEBHOOK_DELIVERY_STATUS_PENDING, delivery.Attempts, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		slog.Info(fmt.Sprintf("error ClaimWebhookDelivery: %v", result.Error))
+		return myErrors.ErrDb
+	}
+	if result.RowsAffected == 0 {
+		return myErrors.ErrConflict
+	}
+	return nil
+}
+
+// SaveWebhookDeliveryAttempt は、送信の結果を記録する。
+func (r *webhookRepository) SaveWebhookDeliveryAttempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	err := r.db.WithContext(ctx).Model(delivery).
+		Omit(clause.Associations).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "error").
+		Updates(delivery).Error
+	if err != nil {
+		slog.Info(fmt.Sprintf("error SaveWebhookDeliveryAttempt: %v", err))
+		return myErrors.ErrDb
+	}
+	return nil
+}
+
+// createWebhookEvents は、Webhook で送信するイベントを変更と同じトランザクション内で記録する。
+// 変更が確定したイベントは、プロセスが停止しても失われない。
+func createWebhookEvents(tx *gorm.DB, webhookEvents []*model.WebhookEvent) error {
+	if len(webhookEvents) == 0 {
+		return nil
+	}
+	if err := tx.Create(webhookEvents).Error; err != nil {
+		slog.Info(fmt.Sprintf("error createWebhookEvents: %v", err))
+		return myErrors.ErrDb
+	}
+	return nil
+}
+
+// createNewTaskWebhookEvent は、作成したタスクから newWebhookEvent で Webhook で送信するイベントを作成し、記録する。
+// newWebhookEvent が nil を返した場合は記録しない。
+func createNewTaskWebhookEvent(tx *gorm.DB, task *model.Task, newWebhookEvent func(task *model.Task) (*model.WebhookEvent, error)) error {
+	webhookEvent, err := newWebhookEvent(task)
+	if err != nil {
+		return err
+	}
+	if webhookEvent == nil {
+		return nil
+	}
+	return createWebhookEvents(tx, []*model.WebhookEvent{webhookEvent})
+}
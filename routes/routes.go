@@ -1,49 +1,153 @@
 package routes
 
 import (
+	"time"
 	controller "todo-api/controller"
 	"todo-api/middleware"
+	"todo-api/model"
+	"todo-api/realtime"
 	"todo-api/repository"
+	"todo-api/storage"
 	"todo-api/usecase"
+	"todo-api/webhook"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
 
-func RegisterRoutes(e *echo.Echo, db *gorm.DB) {
-	var validate = validator.New()
+func RegisterRoutes(e *echo.Echo, db *gorm.DB, attachmentStorage storage.Storage, webhookSender webhook.Sender, broker realtime.Broker, requestTimeout time.Duration, requireIfMatch bool) {
+	// ハンドラとミドルウェアが返したエラーは application/problem+json で返す
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
+
+	var validate = controller.NewValidator()
 	taskRepository := repository.NewTaskRepository(db)
+	taskSearchRepository := repository.NewTaskSearchRepository(db)
+	taskEventRepository := repository.NewTaskEventRepository(db)
+	taskCommentRepository := repository.NewTaskCommentRepository(db)
+	taskAttachmentRepository := repository.NewTaskAttachmentRepository(db)
+	taskChecklistItemRepository := repository.NewTaskChecklistItemRepository(db)
+	taskDependencyRepository := repository.NewTaskDependencyRepository(db)
+	labelRepository := repository.NewLabelRepository(db)
 	companyRepository := repository.NewCompanyRepository(db)
 	companyUserRepository := repository.NewCompanyUserRepository(db)
 	userRepository := repository.NewUserRepository(db)
-	taskUseCase := usecase.NewTaskUseCase(taskRepository, companyRepository, companyUserRepository)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	webhookRepository := repository.NewWebhookRepository(db)
+	taskUseCase := usecase.NewTaskUseCase(taskRepository, taskSearchRepository, taskEventRepository, labelRepository, taskDependencyRepository, companyRepository, companyUserRepository, notificationRepository, broker, attachmentStorage)
+	taskCommentUseCase := usecase.NewTaskCommentUseCase(taskRepository, taskCommentRepository, companyUserRepository, notificationRepository, broker)
+	taskAttachmentUseCase := usecase.NewTaskAttachmentUseCase(taskRepository, taskAttachmentRepository, companyRepository, companyUserRepository, attachmentStorage)
+	taskChecklistItemUseCase := usecase.NewTaskChecklistItemUseCase(taskRepository, taskChecklistItemRepository)
+	taskDependencyUseCase := usecase.NewTaskDependencyUseCase(taskRepository, taskDependencyRepository)
+	labelUseCase := usecase.NewLabelUseCase(labelRepository)
 	userUseCase := usecase.NewUserUseCase(db, userRepository, companyUserRepository)
-	authUseCase := usecase.NewAuthUseCase(userRepository)
-	taskController := controller.NewTaskController(validate, taskUseCase)
+	authUseCase := usecase.NewAuthUseCase(userRepository, refreshTokenRepository)
+	companyUseCase := usecase.NewCompanyUseCase(companyRepository, attachmentStorage)
+	companyUserUseCase := usecase.NewCompanyUserUseCase(companyUserRepository, userRepository)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepository)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepository, webhookSender)
+	realtimeUseCase := usecase.NewRealtimeUseCase(taskRepository, broker)
+	taskController := controller.NewTaskController(validate, taskUseCase, requireIfMatch)
+	taskCommentController := controller.NewTaskCommentController(validate, taskCommentUseCase)
+	taskAttachmentController := controller.NewTaskAttachmentController(taskAttachmentUseCase)
+	taskChecklistItemController := controller.NewTaskChecklistItemController(validate, taskChecklistItemUseCase)
+	taskDependencyController := controller.NewTaskDependencyController(validate, taskDependencyUseCase)
+	labelController := controller.NewLabelController(validate, labelUseCase)
 	userController := controller.NewUserController(validate, userUseCase)
 	authController := controller.NewAuthController(validate, authUseCase)
+	companyController := controller.NewCompanyController(validate, companyUseCase)
+	companyUserController := controller.NewCompanyUserController(validate, companyUserUseCase)
+	notificationController := controller.NewNotificationController(validate, notificationUseCase)
+	webhookController := controller.NewWebhookController(validate, webhookUseCase)
+	realtimeController := controller.NewRealtimeController(validate, realtimeUseCase)
 
 	apiV1 := e.Group("/api/v1")
 	apiV1.Use(middleware.Logging())
+	// 処理時間の上限。イベントの配信 (SSE と WebSocket) は接続を保ち続けるため上限を設けない
+	apiV1.Use(middleware.Timeout(middleware.TimeoutConfig{
+		Default: requestTimeout,
+		Routes: map[string]time.Duration{
+			"GET /api/v1/companies/:company_id/ws":     0,
+			"GET /api/v1/companies/:company_id/events": 0,
+			// 全文検索は通常のクエリより時間がかかる
+			"GET /api/v1/companies/:company_id/tasks/search": 3 * requestTimeout,
+			// 添付ファイルの送受信は大きさに応じて時間がかかる
+			"GET /api/v1/companies/:company_id/tasks/:task_id/attachments/:attachment_id": 5 * time.Minute,
+			"POST /api/v1/companies/:company_id/tasks/:task_id/attachments":               5 * time.Minute,
+			// 完全削除は添付ファイルと子孫のタスクをまとめて削除する
+			"DELETE /api/v1/admin/tasks/:task_id/purge": 3 * requestTimeout,
+		},
+	}))
 	apiV1.POST("/users", userController.CreateUser)
 	apiV1.POST("/login", authController.Login)
+	apiV1.POST("/token/refresh", authController.RefreshToken)
+	// WebSocket はブラウザから Authorization ヘッダを送れないため、個別の認証ミドルウェアを使う
+	apiV1.GET("/companies/:company_id/ws", realtimeController.ConnectWebSocket, middleware.WebSocketAuth(userRepository, refreshTokenRepository), middleware.CompanyAuth(companyUserRepository), middleware.CompanyPermission(model.ACTION_READ_TASK))
 
 	// 下記は認証が必要なAPI
-	apiV1.Use(middleware.Auth(userRepository))
+	apiV1.Use(middleware.Auth(userRepository, refreshTokenRepository))
+	apiV1.POST("/logout", authController.Logout)
+	apiV1.GET("/companies", companyController.GetCompanies)
+	apiV1.GET("/notifications", notificationController.GetNotifications)
+	apiV1.POST("/notifications/read-all", notificationController.MarkAllNotificationsRead)
+	apiV1.GET("/notifications/preferences", notificationController.GetNotificationPreferences)
+	apiV1.PUT("/notifications/preferences", notificationController.UpdateNotificationPreferences)
+	apiV1.POST("/notifications/:notification_id/read", notificationController.MarkNotificationRead)
 	apiV1Company := apiV1.Group("/companies/:company_id")
 	apiV1Company.Use(middleware.CompanyAuth(companyUserRepository))
-	apiV1Company.GET("/tasks", taskController.GetTasks)
-	apiV1Company.GET("/tasks/:task_id", taskController.GetTask)
-	apiV1Company.POST("/tasks", taskController.CreateTask)
-	apiV1Company.PUT("/tasks/:task_id", taskController.UpdateTask)
-	apiV1Company.DELETE("/tasks/:task_id", taskController.DeleteTask)
+	apiV1Company.GET("/events", realtimeController.StreamEvents, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks", taskController.GetTasks, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/trash", taskController.GetTrashedTasks, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/search", taskController.SearchTasks, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/dependency-graph", taskDependencyController.GetTaskDependencyGraph, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/:task_id", taskController.GetTask, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/tasks", taskController.CreateTask, middleware.CompanyPermission(model.ACTION_CREATE_TASK))
+	apiV1Company.PUT("/tasks/:task_id", taskController.UpdateTask, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.PATCH("/tasks/:task_id", taskController.PatchTask, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.DELETE("/tasks/:task_id", taskController.DeleteTask, middleware.CompanyPermission(model.ACTION_DELETE_TASK))
+	apiV1Company.GET("/tasks/:task_id/history", taskController.GetTaskHistory, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/tasks/:task_id/restore", taskController.RestoreTask, middleware.CompanyPermission(model.ACTION_DELETE_TASK))
+	apiV1Company.GET("/tasks/:task_id/subtasks", taskController.GetSubtasks, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/:task_id/checklist", taskChecklistItemController.GetTaskChecklistItems, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/tasks/:task_id/checklist", taskChecklistItemController.CreateTaskChecklistItem, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.PUT("/tasks/:task_id/checklist/:item_id", taskChecklistItemController.UpdateTaskChecklistItem, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.DELETE("/tasks/:task_id/checklist/:item_id", taskChecklistItemController.DeleteTaskChecklistItem, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.POST("/tasks/:task_id/dependencies", taskDependencyController.CreateTaskDependency, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.DELETE("/tasks/:task_id/dependencies/:blocked_by_task_id", taskDependencyController.DeleteTaskDependency, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.GET("/tasks/:task_id/comments", taskCommentController.GetTaskComments, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/tasks/:task_id/comments", taskCommentController.CreateTaskComment, middleware.CompanyPermission(model.ACTION_COMMENT_TASK))
+	apiV1Company.PUT("/tasks/:task_id/comments/:comment_id", taskCommentController.UpdateTaskComment, middleware.CompanyPermission(model.ACTION_COMMENT_TASK))
+	apiV1Company.DELETE("/tasks/:task_id/comments/:comment_id", taskCommentController.DeleteTaskComment, middleware.CompanyPermission(model.ACTION_COMMENT_TASK))
+	apiV1Company.GET("/tasks/:task_id/attachments", taskAttachmentController.GetTaskAttachments, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.GET("/tasks/:task_id/attachments/:attachment_id", taskAttachmentController.GetTaskAttachment, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/tasks/:task_id/attachments", taskAttachmentController.CreateTaskAttachment, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.DELETE("/tasks/:task_id/attachments/:attachment_id", taskAttachmentController.DeleteTaskAttachment, middleware.CompanyPermission(model.ACTION_UPDATE_TASK))
+	apiV1Company.GET("/labels", labelController.GetLabels, middleware.CompanyPermission(model.ACTION_READ_TASK))
+	apiV1Company.POST("/labels", labelController.CreateLabel, middleware.CompanyPermission(model.ACTION_MANAGE_LABEL))
+	apiV1Company.PUT("/labels/:label_id", labelController.UpdateLabel, middleware.CompanyPermission(model.ACTION_MANAGE_LABEL))
+	apiV1Company.DELETE("/labels/:label_id", labelController.DeleteLabel, middleware.CompanyPermission(model.ACTION_MANAGE_LABEL))
+	apiV1Company.GET("/members", companyUserController.GetCompanyUsers, middleware.CompanyPermission(model.ACTION_READ_MEMBER))
+	apiV1Company.POST("/members", companyUserController.CreateCompanyUser, middleware.CompanyPermission(model.ACTION_MANAGE_MEMBER))
+	apiV1Company.PUT("/members/:user_id", companyUserController.UpdateCompanyUser, middleware.CompanyPermission(model.ACTION_MANAGE_MEMBER))
+	apiV1Company.DELETE("/members/:user_id", companyUserController.DeleteCompanyUser, middleware.CompanyPermission(model.ACTION_MANAGE_MEMBER))
+	apiV1Company.GET("/webhooks", webhookController.GetWebhooks, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
+	apiV1Company.POST("/webhooks", webhookController.CreateWebhook, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
+	apiV1Company.PUT("/webhooks/:webhook_id", webhookController.UpdateWebhook, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
+	apiV1Company.DELETE("/webhooks/:webhook_id", webhookController.DeleteWebhook, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
+	apiV1Company.GET("/webhooks/:webhook_id/deliveries", webhookController.GetWebhookDeliveries, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
+	apiV1Company.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", webhookController.RedeliverWebhookDelivery, middleware.CompanyPermission(model.ACTION_MANAGE_WEBHOOK))
 
-	// 下記はAdminユーザ専用のAPI
+	// 下記はシステムオペレーター専用のAPI
 	apiV1Admin := apiV1.Group("/admin")
-	apiV1Admin.Use(middleware.AdminAuth())
+	apiV1Admin.Use(middleware.SystemOperatorAuth())
+	apiV1Admin.POST("/users", userController.CreateUserByAdmin)
 	apiV1Admin.GET("/tasks", taskController.GetTasksByAdmin)
 	apiV1Admin.POST("/tasks", taskController.CreateTaskByAdmin)
 	apiV1Admin.PUT("/tasks/:task_id", taskController.UpdateTaskByAdmin)
 	apiV1Admin.DELETE("/tasks/:task_id", taskController.DeleteTaskByAdmin)
+	apiV1Admin.DELETE("/tasks/:task_id/purge", taskController.PurgeTaskByAdmin)
+	apiV1Admin.GET("/companies", companyController.GetCompaniesByAdmin)
+	apiV1Admin.POST("/companies", companyController.CreateCompanyByAdmin)
+	apiV1Admin.PUT("/companies/:company_id", companyController.UpdateCompanyByAdmin)
+	apiV1Admin.DELETE("/companies/:company_id", companyController.DeleteCompanyByAdmin)
 }
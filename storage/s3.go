@@ -0,0 +1,267 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	myErrors "todo-api/errors"
+)
+
+const (
+	// ペイロードの署名を省略する場合の x-amz-content-sha256 の値
+	S3_UNSIGNED_PAYLOAD = "UNSIGNED-PAYLOAD"
+
+	// 空のペイロードの SHA-256
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Config は S3 互換ストレージの接続設定
+type S3Config struct {
+	// 例: https://s3.ap-northeast-1.amazonaws.com, http://minio:9000
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// s3Storage は S3 互換ストレージ(AWS S3, MinIO など)に保存する実装。
+// パス形式の URL と署名バージョン4で API を呼び出す。
+type s3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Storage(config S3Config) Storage {
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &s3Storage{
+		config: config,
+		client: http.DefaultClient,
+		now:    time.Now,
+	}
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, io.LimitReader(r, size), S3_UNSIGNED_PAYLOAD)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return newS3Error("put object", res)
+	}
+	return nil
+}
+
+func (s *s3Storage) Open(ctx context.Context, key string, size int64) (io.ReadSeekCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to head object: %w", err)
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, myErrors.ErrNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newS3Error("head object", res)
+	}
+
+	return &s3Object{storage: s, ctx: ctx, key: key, size: size}, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return newS3Error("delete object", res)
+	}
+	return nil
+}
+
+// newRequest は、オブジェクトに対する署名済みのリクエストを作成する。
+func (s *s3Storage) newRequest(ctx context.Context, method, key string, body io.Reader, payloadHash string) (*http.Request, error) {
+	path := "/" + s3URIEncode(s.config.Bucket, true) + "/" + s3URIEncode(key, false)
+	req, err := http.NewRequestWithContext(ctx, method, s.config.Endpoint+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	s.sign(req, path, payloadHash)
+	return req, nil
+}
+
+// sign は、署名バージョン4の Authorization ヘッダーを設定する。
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *s3Storage) sign(req *http.Request, canonicalURI, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"",
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// s3Object は、Range リクエストでオブジェクトを読み込む io.ReadSeekCloser。
+// Seek で位置を変えた場合は、次の Read でその位置から取得し直す。
+type s3Object struct {
+	storage *s3Storage
+	ctx     context.Context
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (o *s3Object) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	if o.body == nil {
+		body, err := o.get()
+		if err != nil {
+			return 0, err
+		}
+		o.body = body
+	}
+
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	return n, err
+}
+
+func (o *s3Object) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = o.offset + offset
+	case io.SeekEnd:
+		next = o.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if next != o.offset && o.body != nil {
+		o.body.Close()
+		o.body = nil
+	}
+	o.offset = next
+	return next, nil
+}
+
+func (o *s3Object) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
+
+// get は、現在の位置から末尾までを取得する。
+func (o *s3Object) get() (io.ReadCloser, error) {
+	req, err := o.storage.newRequest(o.ctx, http.MethodGet, o.key, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", o.offset))
+
+	res, err := o.storage.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, myErrors.ErrNotFound
+	}
+	if res.StatusCode != http.StatusPartialContent && !(res.StatusCode == http.StatusOK && o.offset == 0) {
+		defer res.Body.Close()
+		return nil, newS3Error("get object", res)
+	}
+	return res.Body, nil
+}
+
+func newS3Error(operation string, res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("failed to %s: status %d: %s", operation, res.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3URIEncode は、署名バージョン4の規則でパスをエンコードする。
+// 非予約文字(A-Z a-z 0-9 - . _ ~)以外をエンコードし、encodeSlash が false の場合は "/" をそのまま残す。
+func s3URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage は添付ファイルの実体を保存するストレージ。
+// 保存先を差し替える場合は、このインターフェースを実装する。
+type Storage interface {
+	// Put は、r から size バイトを読み込み key に保存する。
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Open は、key に保存されたファイルを開く。size はファイルのサイズで、Seek の終端に使用する。
+	// 存在しない場合は ErrNotFound を返す。
+	Open(ctx context.Context, key string, size int64) (io.ReadSeekCloser, error)
+
+	// Delete は、key に保存されたファイルを削除する。存在しない場合もエラーにしない。
+	Delete(ctx context.Context, key string) error
+}
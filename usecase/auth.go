@@ -1,32 +1,183 @@
 package usecase
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"os"
+	"time"
+	myErrors "todo-api/errors"
 	"todo-api/model"
 	"todo-api/repository"
 
+	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// アクセストークンの有効期限
+	ACCESS_TOKEN_EXPIRATION = 15 * time.Minute
+
+	// リフレッシュトークンの有効期限
+	REFRESH_TOKEN_EXPIRATION = 30 * 24 * time.Hour
+)
+
+// AuthToken はログインおよびトークン更新時に発行するトークンの組
+type AuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
 type AuthUseCase interface {
-	Login(username, password string) (*model.User, error)
+	Login(ctx context.Context, username, password string) (*model.User, error)
+	CreateToken(ctx context.Context, user *model.User) (*AuthToken, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*AuthToken, error)
+	Logout(ctx context.Context, sessionId string) error
 }
 
 type authUseCase struct {
-	userRepository repository.UserRepository
+	userRepository         repository.UserRepository
+	refreshTokenRepository repository.RefreshTokenRepository
 }
 
-func NewAuthUseCase(userRepository repository.UserRepository) AuthUseCase {
-	return &authUseCase{userRepository: userRepository}
+func NewAuthUseCase(
+	userRepository repository.UserRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+) AuthUseCase {
+	return &authUseCase{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+	}
 }
 
-func (a *authUseCase) Login(username, password string) (*model.User, error) {
-	user, err := a.userRepository.GetUserByUsername(username)
+func (a *authUseCase) Login(ctx context.Context, username, password string) (*model.User, error) {
+	user, err := a.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
+		// ユーザが存在するかどうかを区別できないよう、パスワードの誤りと同じエラーにする
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return nil, myErrors.ErrInvalidCredentials
+		}
 		return nil, err
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, myErrors.ErrInvalidCredentials
 	}
 	return user, nil
 }
+
+// CreateToken は、新しいログインセッション(リフレッシュトークンのファミリー)を開始し、
+// アクセストークンとリフレッシュトークンを発行する。
+func (a *authUseCase) CreateToken(ctx context.Context, user *model.User) (*AuthToken, error) {
+	familyId, err := generateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	authToken, newRefreshToken, err := newAuthToken(user.ID, familyId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.refreshTokenRepository.CreateRefreshToken(ctx, newRefreshToken); err != nil {
+		return nil, err
+	}
+	return authToken, nil
+}
+
+// RefreshToken は、リフレッシュトークンをローテーションし、新しいトークンの組を発行する。
+// 失効済みのリフレッシュトークンが再利用された場合は漏洩とみなし、ファミリー全体を失効させる。
+func (a *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*AuthToken, error) {
+	oldToken, err := a.refreshTokenRepository.GetRefreshTokenByTokenHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return nil, myErrors.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if oldToken.RevokedAt != nil {
+		if err := a.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, oldToken.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, myErrors.ErrInvalidToken
+	}
+	if time.Now().After(oldToken.ExpiresAt) {
+		return nil, myErrors.ErrInvalidToken
+	}
+
+	authToken, newRefreshToken, err := newAuthToken(oldToken.UserID, oldToken.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	// 古いトークンの失効と新しいトークンの作成は、どちらか一方だけが反映されないよう同じトランザクションで行う
+	if _, err := a.refreshTokenRepository.RotateRefreshToken(ctx, oldToken.ID, newRefreshToken); err != nil {
+		// 同じトークンで同時に更新された場合も再利用とみなす
+		if errors.Is(err, myErrors.ErrNotFound) {
+			if err := a.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, oldToken.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, myErrors.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return authToken, nil
+}
+
+// Logout は、ログインセッションに属する全てのリフレッシュトークンを失効させる。
+func (a *authUseCase) Logout(ctx context.Context, sessionId string) error {
+	return a.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, sessionId)
+}
+
+// newAuthToken は、ファミリーに属する新しいトークンの組と、DBに保存するリフレッシュトークンを作成する。
+// リフレッシュトークンの保存は呼び出し元で行う。
+func newAuthToken(userId uint, familyId string) (*AuthToken, *model.RefreshToken, error) {
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		return nil, nil, errors.New("JWT Secret not found")
+	}
+
+	refreshToken, err := generateRandomToken(32)
+	if err != nil {
+		return nil, nil, err
+	}
+	newRefreshToken := &model.RefreshToken{
+		UserID:    userId,
+		FamilyID:  familyId,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(REFRESH_TOKEN_EXPIRATION),
+	}
+
+	expiresAt := time.Now().Add(ACCESS_TOKEN_EXPIRATION)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  userId,
+		"sid": familyId,
+		"exp": expiresAt.Unix(),
+	})
+	accessToken, err := token.SignedString([]byte(jwtSecret))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, newRefreshToken, nil
+}
+
+// generateRandomToken は、指定したバイト数の乱数を URL safe な文字列で返す。
+func generateRandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken は、DBに保存するためのトークンのハッシュ値を返す。
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,13 +1,17 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 
+	myErrors "todo-api/errors"
 	mock_repository "todo-api/mock/repository"
 	"todo-api/model"
 )
@@ -17,7 +21,8 @@ func TestAuthUseCase_Login(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
-	authUseCase := NewAuthUseCase(mockUserRepo)
+	mockRefreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	authUseCase := NewAuthUseCase(mockUserRepo, mockRefreshTokenRepo)
 
 	// テスト用ユーザー
 	password := "password"
@@ -42,7 +47,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 			password: "password",
 			mockGetUserByUsername: func() {
 				mockUserRepo.EXPECT().
-					GetUserByUsername("testuser").
+					GetUserByUsername(gomock.Any(), "testuser").
 					Return(user, nil).
 					Times(1)
 			},
@@ -55,12 +60,25 @@ func TestAuthUseCase_Login(t *testing.T) {
 			password: "password",
 			mockGetUserByUsername: func() {
 				mockUserRepo.EXPECT().
-					GetUserByUsername("nonexistent").
-					Return(nil, errors.New("user not found")).
+					GetUserByUsername(gomock.Any(), "nonexistent").
+					Return(nil, myErrors.ErrNotFound).
 					Times(1)
 			},
 			expectedResult: nil,
-			expectedError:  errors.New("user not found"),
+			expectedError:  myErrors.ErrInvalidCredentials,
+		},
+		{
+			name:     "Wrong password",
+			username: "testuser",
+			password: "wrong",
+			mockGetUserByUsername: func() {
+				mockUserRepo.EXPECT().
+					GetUserByUsername(gomock.Any(), "testuser").
+					Return(user, nil).
+					Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidCredentials,
 		},
 		{
 			name:     "Error from GetUserByUsername",
@@ -68,7 +86,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 			password: "password",
 			mockGetUserByUsername: func() {
 				mockUserRepo.EXPECT().
-					GetUserByUsername("testuser").
+					GetUserByUsername(gomock.Any(), "testuser").
 					Return(nil, errors.New("db error")).
 					Times(1)
 			},
@@ -82,10 +100,224 @@ func TestAuthUseCase_Login(t *testing.T) {
 			tc.mockGetUserByUsername()
 
 			// `Login` メソッドの呼び出し
-			result, err := authUseCase.Login(tc.username, tc.password)
+			result, err := authUseCase.Login(context.Background(), tc.username, tc.password)
 
 			assert.Equal(t, tc.expectedResult, result)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
 }
+
+func TestAuthUseCase_CreateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	mockRefreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	authUseCase := NewAuthUseCase(mockUserRepo, mockRefreshTokenRepo)
+
+	user := &model.User{ID: 1}
+
+	testCases := []struct {
+		name          string
+		jwtSecret     string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			jwtSecret: "secret",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					CreateRefreshToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, refreshToken *model.RefreshToken) (*model.RefreshToken, error) {
+						assert.Equal(t, user.ID, refreshToken.UserID)
+						assert.NotEmpty(t, refreshToken.FamilyID)
+						assert.NotEmpty(t, refreshToken.TokenHash)
+						return refreshToken, nil
+					}).
+					Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "JWT Secret Not Found",
+			jwtSecret:     "",
+			mockFunc:      func() {},
+			expectedError: errors.New("JWT Secret not found"),
+		},
+		{
+			name:      "Error from CreateRefreshToken",
+			jwtSecret: "secret",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					CreateRefreshToken(gomock.Any(), gomock.Any()).
+					Return(nil, myErrors.ErrDb).
+					Times(1)
+			},
+			expectedError: myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv("JWT_SECRET", tc.jwtSecret)
+			tc.mockFunc()
+
+			authToken, err := authUseCase.CreateToken(context.Background(), user)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.NotEmpty(t, authToken.AccessToken)
+				assert.NotEmpty(t, authToken.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthUseCase_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	mockRefreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	authUseCase := NewAuthUseCase(mockUserRepo, mockRefreshTokenRepo)
+
+	refreshToken := "refresh-token"
+	tokenHash := hashToken(refreshToken)
+	revokedAt := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(&model.RefreshToken{ID: 1, UserID: 2, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRefreshTokenRepo.EXPECT().
+					RotateRefreshToken(gomock.Any(), uint(1), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id uint, refreshToken *model.RefreshToken) (*model.RefreshToken, error) {
+						assert.Equal(t, uint(2), refreshToken.UserID)
+						assert.Equal(t, "family", refreshToken.FamilyID)
+						return refreshToken, nil
+					}).
+					Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Token not found",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(nil, myErrors.ErrNotFound).
+					Times(1)
+			},
+			expectedError: myErrors.ErrInvalidToken,
+		},
+		{
+			name: "Revoked token reused",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(&model.RefreshToken{ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil).
+					Times(1)
+				mockRefreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidToken,
+		},
+		{
+			name: "Expired token",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(&model.RefreshToken{ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Hour)}, nil).
+					Times(1)
+			},
+			expectedError: myErrors.ErrInvalidToken,
+		},
+		{
+			name: "Concurrent refresh",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(&model.RefreshToken{ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRefreshTokenRepo.EXPECT().RotateRefreshToken(gomock.Any(), uint(1), gomock.Any()).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockRefreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidToken,
+		},
+		{
+			name: "Error from RotateRefreshToken",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().
+					GetRefreshTokenByTokenHash(gomock.Any(), tokenHash).
+					Return(&model.RefreshToken{ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRefreshTokenRepo.EXPECT().RotateRefreshToken(gomock.Any(), uint(1), gomock.Any()).Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedError: myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv("JWT_SECRET", "secret")
+			tc.mockFunc()
+
+			authToken, err := authUseCase.RefreshToken(context.Background(), refreshToken)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.NotEmpty(t, authToken.AccessToken)
+				assert.NotEqual(t, refreshToken, authToken.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	mockRefreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	authUseCase := NewAuthUseCase(mockUserRepo, mockRefreshTokenRepo)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error from RevokeRefreshTokenFamily",
+			mockFunc: func() {
+				mockRefreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(myErrors.ErrDb).Times(1)
+			},
+			expectedError: myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := authUseCase.Logout(context.Background(), "family")
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
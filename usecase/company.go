@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/model"
+	"todo-api/repository"
+	"todo-api/storage"
+)
+
+type CompanyUseCase interface {
+	GetCompanies(ctx context.Context, limit, offset int) ([]*model.Company, error)
+	GetCompaniesByUserId(ctx context.Context, userId uint) ([]*model.Company, error)
+	CreateCompany(ctx context.Context, company *model.Company) (*model.Company, error)
+	UpdateCompany(ctx context.Context, companyId uint, company *model.Company) (*model.Company, error)
+	DeleteCompany(ctx context.Context, companyId uint) error
+}
+
+type companyUseCase struct {
+	companyRepository repository.CompanyRepository
+	storage           storage.Storage
+}
+
+func NewCompanyUseCase(companyRepository repository.CompanyRepository, storage storage.Storage) CompanyUseCase {
+	return &companyUseCase{companyRepository: companyRepository, storage: storage}
+}
+
+func (u *companyUseCase) GetCompanies(ctx context.Context, limit, offset int) ([]*model.Company, error) {
+	companies, err := u.companyRepository.GetCompanies(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (u *companyUseCase) GetCompaniesByUserId(ctx context.Context, userId uint) ([]*model.Company, error) {
+	companies, err := u.companyRepository.GetCompaniesByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (u *companyUseCase) CreateCompany(ctx context.Context, company *model.Company) (*model.Company, error) {
+	company, err := u.companyRepository.CreateCompany(ctx, company)
+	if err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+// UpdateCompany は、会社を更新する。タイムゾーンが空の場合は現在のタイムゾーンを維持する。
+func (u *companyUseCase) UpdateCompany(ctx context.Context, companyId uint, company *model.Company) (*model.Company, error) {
+	oldCompany, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	timeZone := company.TimeZone
+	if timeZone == "" {
+		timeZone = oldCompany.TimeZone
+	}
+
+	resultCompany, err := u.companyRepository.UpdateCompany(ctx, &model.Company{
+		ID:                    oldCompany.ID,
+		Name:                  company.Name,
+		TimeZone:              timeZone,
+		DisableTaskTotalCount: company.DisableTaskTotalCount,
+		TrashRetentionDays:    company.TrashRetentionDays,
+		AttachmentQuotaBytes:  company.AttachmentQuotaBytes,
+		CreatedAt:             oldCompany.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resultCompany, nil
+}
+
+// DeleteCompany は、会社を削除し、会社のタスクの添付ファイルもストレージから削除する。
+func (u *companyUseCase) DeleteCompany(ctx context.Context, companyId uint) error {
+	_, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return err
+	}
+
+	storageKeys, err := u.companyRepository.DeleteCompany(ctx, companyId)
+	if err != nil {
+		return err
+	}
+	deleteStoredAttachments(ctx, u.storage, storageKeys)
+
+	return nil
+}
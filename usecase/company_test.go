@@ -0,0 +1,281 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_repository "todo-api/mock/repository"
+	mock_storage "todo-api/mock/storage"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestCompanyUseCase_GetCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+	companyUseCase := usecase.NewCompanyUseCase(mockCompanyRepo, mockStorage)
+
+	limit := 10
+	offset := 0
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Company
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompanies(gomock.Any(), limit, offset).Return([]*model.Company{{ID: 1}}, nil).Times(1)
+			},
+			expectedResult: []*model.Company{{ID: 1}},
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetCompanies",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompanies(gomock.Any(), limit, offset).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			companies, err := companyUseCase.GetCompanies(context.Background(), limit, offset)
+
+			assert.Equal(t, tc.expectedResult, companies)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUseCase_GetCompaniesByUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+	companyUseCase := usecase.NewCompanyUseCase(mockCompanyRepo, mockStorage)
+
+	userId := uint(2)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Company
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompaniesByUserId(gomock.Any(), userId).Return([]*model.Company{{ID: 1}}, nil).Times(1)
+			},
+			expectedResult: []*model.Company{{ID: 1}},
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetCompaniesByUserId",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompaniesByUserId(gomock.Any(), userId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			companies, err := companyUseCase.GetCompaniesByUserId(context.Background(), userId)
+
+			assert.Equal(t, tc.expectedResult, companies)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUseCase_CreateCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+	companyUseCase := usecase.NewCompanyUseCase(mockCompanyRepo, mockStorage)
+
+	company := &model.Company{Name: "company"}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.Company
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().CreateCompany(gomock.Any(), company).Return(&model.Company{ID: 1, Name: "company"}, nil).Times(1)
+			},
+			expectedResult: &model.Company{ID: 1, Name: "company"},
+			expectedError:  nil,
+		},
+		{
+			name: "Error in CreateCompany",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().CreateCompany(gomock.Any(), company).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := companyUseCase.CreateCompany(context.Background(), company)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUseCase_UpdateCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+	companyUseCase := usecase.NewCompanyUseCase(mockCompanyRepo, mockStorage)
+
+	companyId := uint(1)
+	oldCompany := &model.Company{ID: companyId, Name: "old name", TimeZone: "Asia/Tokyo"}
+
+	testCases := []struct {
+		name           string
+		company        *model.Company
+		mockFunc       func()
+		expectedResult *model.Company
+		expectedError  error
+	}{
+		{
+			name:    "Success",
+			company: &model.Company{ID: companyId, Name: "new name", TimeZone: "America/New_York"},
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(oldCompany, nil).Times(1)
+				mockCompanyRepo.EXPECT().UpdateCompany(gomock.Any(), &model.Company{ID: companyId, Name: "new name", TimeZone: "America/New_York"}).
+					Return(&model.Company{ID: companyId, Name: "new name", TimeZone: "America/New_York"}, nil).Times(1)
+			},
+			expectedResult: &model.Company{ID: companyId, Name: "new name", TimeZone: "America/New_York"},
+			expectedError:  nil,
+		},
+		{
+			// タイムゾーンの指定がない場合は現在のタイムゾーンを維持する
+			name:    "Success - without time zone",
+			company: &model.Company{ID: companyId, Name: "new name"},
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(oldCompany, nil).Times(1)
+				mockCompanyRepo.EXPECT().UpdateCompany(gomock.Any(), &model.Company{ID: companyId, Name: "new name", TimeZone: "Asia/Tokyo"}).
+					Return(&model.Company{ID: companyId, Name: "new name", TimeZone: "Asia/Tokyo"}, nil).Times(1)
+			},
+			expectedResult: &model.Company{ID: companyId, Name: "new name", TimeZone: "Asia/Tokyo"},
+			expectedError:  nil,
+		},
+		{
+			name:    "Company not found",
+			company: &model.Company{ID: companyId, Name: "new name"},
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(nil, errors.New("company not found")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("company not found"),
+		},
+		{
+			name:    "Error in UpdateCompany",
+			company: &model.Company{ID: companyId, Name: "new name"},
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(oldCompany, nil).Times(1)
+				mockCompanyRepo.EXPECT().UpdateCompany(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := companyUseCase.UpdateCompany(context.Background(), companyId, tc.company)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUseCase_DeleteCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+	companyUseCase := usecase.NewCompanyUseCase(mockCompanyRepo, mockStorage)
+
+	companyId := uint(1)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().DeleteCompany(gomock.Any(), companyId).Return([]string{"1/1/a", "1/2/b"}, nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/1/a").Return(nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/2/b").Return(errors.New("some error")).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Company not found",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(nil, errors.New("company not found")).Times(1)
+			},
+			expectedError: errors.New("company not found"),
+		},
+		{
+			name: "Error in DeleteCompany",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().DeleteCompany(gomock.Any(), companyId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := companyUseCase.DeleteCompany(context.Background(), companyId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/repository"
+)
+
+type CompanyUserUseCase interface {
+	GetCompanyUsers(ctx context.Context, companyId uint) ([]*model.CompanyUser, error)
+	CreateCompanyUser(ctx context.Context, companyId, userId uint, role string) (*model.CompanyUser, error)
+	UpdateCompanyUser(ctx context.Context, companyId, userId uint, role string) (*model.CompanyUser, error)
+	DeleteCompanyUser(ctx context.Context, companyId, userId uint, reassigneeId *uint) error
+}
+
+type companyUserUseCase struct {
+	companyUserRepository repository.CompanyUserRepository
+	userRepository        repository.UserRepository
+}
+
+func NewCompanyUserUseCase(
+	companyUserRepository repository.CompanyUserRepository,
+	userRepository repository.UserRepository,
+) CompanyUserUseCase {
+	return &companyUserUseCase{
+		companyUserRepository: companyUserRepository,
+		userRepository:        userRepository,
+	}
+}
+
+func (u *companyUserUseCase) GetCompanyUsers(ctx context.Context, companyId uint) ([]*model.CompanyUser, error) {
+	companyUsers, err := u.companyUserRepository.GetCompanyUsers(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	return companyUsers, nil
+}
+
+// CreateCompanyUser は、ユーザを会社のメンバーに追加する。
+// role を指定しない場合は一般メンバーとして追加する。
+func (u *companyUserUseCase) CreateCompanyUser(ctx context.Context, companyId, userId uint, role string) (*model.CompanyUser, error) {
+	if role == "" {
+		role = model.COMPANY_ROLE_MEMBER
+	}
+
+	user, err := u.userRepository.GetUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+	if err == nil {
+		return nil, myErrors.ErrConflict
+	}
+	if !errors.Is(err, myErrors.ErrNotFound) {
+		return nil, err
+	}
+
+	companyUsers, err := u.companyUserRepository.CreateCompanyUsers(ctx, []*model.CompanyUser{
+		{
+			CompanyID: companyId,
+			UserID:    userId,
+			Role:      role,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	companyUser := companyUsers[0]
+	companyUser.User = user
+	return companyUser, nil
+}
+
+// UpdateCompanyUser は、メンバーのロールを変更する。
+// 会社からオーナーがいなくなる変更は ErrInvalidArgument を返す。
+func (u *companyUserUseCase) UpdateCompanyUser(ctx context.Context, companyId, userId uint, role string) (*model.CompanyUser, error) {
+	companyUser, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if companyUser.Role == model.COMPANY_ROLE_OWNER && role != model.COMPANY_ROLE_OWNER {
+		if err := u.ensureAnotherOwner(ctx, companyId); err != nil {
+			return nil, err
+		}
+	}
+
+	companyUser.Role = role
+	resultCompanyUser, err := u.companyUserRepository.UpdateCompanyUser(ctx, companyUser)
+	if err != nil {
+		return nil, err
+	}
+	return resultCompanyUser, nil
+}
+
+// DeleteCompanyUser は、ユーザを会社から外す。
+// reassigneeId を指定した場合、外すユーザの担当タスクをそのメンバーに付け替え、
+// 指定しない場合は担当者なしにする。
+// 会社からオーナーがいなくなる場合は ErrInvalidArgument を返す。
+func (u *companyUserUseCase) DeleteCompanyUser(ctx context.Context, companyId, userId uint, reassigneeId *uint) error {
+	companyUser, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+	if err != nil {
+		return err
+	}
+	if companyUser.Role == model.COMPANY_ROLE_OWNER {
+		if err := u.ensureAnotherOwner(ctx, companyId); err != nil {
+			return err
+		}
+	}
+
+	if reassigneeId != nil {
+		if *reassigneeId == userId {
+			return myErrors.ErrInvalidArgument.WithMessage("reassign_to must be another member of the company")
+		}
+		_, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, *reassigneeId)
+		if err != nil {
+			if errors.Is(err, myErrors.ErrNotFound) {
+				return myErrors.ErrInvalidArgument.WithMessage("reassign_to must be another member of the company")
+			}
+			return err
+		}
+	}
+
+	err = u.companyUserRepository.DeleteCompanyUser(ctx, companyId, userId, reassigneeId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureAnotherOwner は、オーナーを1人外しても会社にオーナーが残ることを確認する。
+func (u *companyUserUseCase) ensureAnotherOwner(ctx context.Context, companyId uint) error {
+	count, err := u.companyUserRepository.CountCompanyUsersByRole(ctx, companyId, model.COMPANY_ROLE_OWNER)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return myErrors.ErrInvalidArgument.WithMessage("the company must have at least one owner")
+	}
+	return nil
+}
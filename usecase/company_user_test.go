@@ -0,0 +1,306 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestCompanyUserUseCase_GetCompanyUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	companyUserUseCase := usecase.NewCompanyUserUseCase(mockCompanyUserRepo, mockUserRepo)
+
+	companyId := uint(1)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.CompanyUser
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUsers(gomock.Any(), companyId).Return([]*model.CompanyUser{{CompanyID: companyId, UserID: 2}}, nil).Times(1)
+			},
+			expectedResult: []*model.CompanyUser{{CompanyID: companyId, UserID: 2}},
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetCompanyUsers",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUsers(gomock.Any(), companyId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			companyUsers, err := companyUserUseCase.GetCompanyUsers(context.Background(), companyId)
+
+			assert.Equal(t, tc.expectedResult, companyUsers)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUserUseCase_CreateCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	companyUserUseCase := usecase.NewCompanyUserUseCase(mockCompanyUserRepo, mockUserRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+	user := &model.User{ID: userId, Username: "user"}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.CompanyUser
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), userId).Return(user, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockCompanyUserRepo.EXPECT().
+					CreateCompanyUsers(gomock.Any(), []*model.CompanyUser{{CompanyID: companyId, UserID: userId, Role: model.COMPANY_ROLE_MEMBER}}).
+					Return([]*model.CompanyUser{{ID: 3, CompanyID: companyId, UserID: userId, Role: model.COMPANY_ROLE_MEMBER}}, nil).
+					Times(1)
+			},
+			expectedResult: &model.CompanyUser{ID: 3, CompanyID: companyId, UserID: userId, Role: model.COMPANY_ROLE_MEMBER, User: user},
+			expectedError:  nil,
+		},
+		{
+			name: "User not found",
+			mockFunc: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Already a member",
+			mockFunc: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), userId).Return(user, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name: "Error in GetCompanyUser",
+			mockFunc: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), userId).Return(user, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
+		},
+		{
+			name: "Error in CreateCompanyUsers",
+			mockFunc: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), userId).Return(user, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+				mockCompanyUserRepo.EXPECT().CreateCompanyUsers(gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			companyUser, err := companyUserUseCase.CreateCompanyUser(context.Background(), companyId, userId, "")
+
+			assert.Equal(t, tc.expectedResult, companyUser)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompanyUserUseCase_UpdateCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	companyUserUseCase := usecase.NewCompanyUserUseCase(mockCompanyUserRepo, mockUserRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+
+	testCases := []struct {
+		name           string
+		role           string
+		mockFunc       func()
+		expectedResult *model.CompanyUser
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			role: model.COMPANY_ROLE_MANAGER,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MEMBER}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().
+					UpdateCompanyUser(gomock.Any(), &model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER}).
+					Return(&model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER}, nil).
+					Times(1)
+			},
+			expectedResult: &model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER},
+			expectedError:  nil,
+		},
+		{
+			name: "Success - demote owner when another owner exists",
+			role: model.COMPANY_ROLE_MANAGER,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_OWNER}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().CountCompanyUsersByRole(gomock.Any(), companyId, model.COMPANY_ROLE_OWNER).Return(int64(2), nil).Times(1)
+				mockCompanyUserRepo.EXPECT().
+					UpdateCompanyUser(gomock.Any(), &model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER}).
+					Return(&model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER}, nil).
+					Times(1)
+			},
+			expectedResult: &model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_MANAGER},
+			expectedError:  nil,
+		},
+		{
+			name: "Last owner",
+			role: model.COMPANY_ROLE_MANAGER,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{ID: 3, Role: model.COMPANY_ROLE_OWNER}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().CountCompanyUsersByRole(gomock.Any(), companyId, model.COMPANY_ROLE_OWNER).Return(int64(1), nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument,
+		},
+		{
+			name: "Member not found",
+			role: model.COMPANY_ROLE_MANAGER,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			companyUser, err := companyUserUseCase.UpdateCompanyUser(context.Background(), companyId, userId, tc.role)
+
+			assert.Equal(t, tc.expectedResult, companyUser)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCompanyUserUseCase_DeleteCompanyUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	companyUserUseCase := usecase.NewCompanyUserUseCase(mockCompanyUserRepo, mockUserRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+	reassigneeId := uint(3)
+	member := &model.CompanyUser{CompanyID: companyId, UserID: userId, Role: model.COMPANY_ROLE_MEMBER}
+	owner := &model.CompanyUser{CompanyID: companyId, UserID: userId, Role: model.COMPANY_ROLE_OWNER}
+
+	testCases := []struct {
+		name          string
+		reassigneeId  *uint
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name:         "Success without reassignee",
+			reassigneeId: nil,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(member, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().DeleteCompanyUser(gomock.Any(), companyId, userId, nil).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "Success with reassignee",
+			reassigneeId: &reassigneeId,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(member, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, reassigneeId).Return(&model.CompanyUser{}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().DeleteCompanyUser(gomock.Any(), companyId, userId, &reassigneeId).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "Reassignee is the removed user",
+			reassigneeId: &userId,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(member, nil).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidArgument,
+		},
+		{
+			name:         "Reassignee is not a member",
+			reassigneeId: &reassigneeId,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(member, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, reassigneeId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidArgument,
+		},
+		{
+			name:         "Last owner",
+			reassigneeId: nil,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(owner, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().CountCompanyUsersByRole(gomock.Any(), companyId, model.COMPANY_ROLE_OWNER).Return(int64(1), nil).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidArgument,
+		},
+		{
+			name:         "Member not found",
+			reassigneeId: nil,
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := companyUserUseCase.DeleteCompanyUser(context.Background(), companyId, userId, tc.reassigneeId)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
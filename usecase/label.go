@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/repository"
+)
+
+type LabelUseCase interface {
+	GetLabels(ctx context.Context, companyId uint) ([]*model.Label, error)
+	CreateLabel(ctx context.Context, label *model.Label) (*model.Label, error)
+	UpdateLabel(ctx context.Context, label *model.Label) (*model.Label, error)
+	DeleteLabel(ctx context.Context, companyId, labelId uint) error
+}
+
+type labelUseCase struct {
+	labelRepository repository.LabelRepository
+}
+
+func NewLabelUseCase(labelRepository repository.LabelRepository) LabelUseCase {
+	return &labelUseCase{labelRepository: labelRepository}
+}
+
+func (u *labelUseCase) GetLabels(ctx context.Context, companyId uint) ([]*model.Label, error) {
+	labels, err := u.labelRepository.GetLabels(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// CreateLabel は、ラベルを作成する。会社内に同じ名前のラベルがある場合は ErrConflict を返す。
+func (u *labelUseCase) CreateLabel(ctx context.Context, label *model.Label) (*model.Label, error) {
+	if err := u.ensureLabelNameAvailable(ctx, label.CompanyID, 0, label.Name); err != nil {
+		return nil, err
+	}
+
+	label, err := u.labelRepository.CreateLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// UpdateLabel は、ラベルの名前と色を更新する。会社内に同じ名前の他のラベルがある場合は ErrConflict を返す。
+func (u *labelUseCase) UpdateLabel(ctx context.Context, label *model.Label) (*model.Label, error) {
+	oldLabel, err := u.labelRepository.GetLabel(ctx, label.CompanyID, label.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.ensureLabelNameAvailable(ctx, label.CompanyID, label.ID, label.Name); err != nil {
+		return nil, err
+	}
+
+	oldLabel.Name = label.Name
+	oldLabel.Color = label.Color
+	label, err = u.labelRepository.UpdateLabel(ctx, oldLabel)
+	if err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// DeleteLabel は、ラベルを削除する。削除したラベルはタスクからも外れる。
+func (u *labelUseCase) DeleteLabel(ctx context.Context, companyId, labelId uint) error {
+	return u.labelRepository.DeleteLabel(ctx, companyId, labelId)
+}
+
+// ensureLabelNameAvailable は、会社内で labelId 以外のラベルに同じ名前が使われていないかを確認する。
+func (u *labelUseCase) ensureLabelNameAvailable(ctx context.Context, companyId, labelId uint, name string) error {
+	label, err := u.labelRepository.GetLabelByName(ctx, companyId, name)
+	if errors.Is(err, myErrors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if label.ID != labelId {
+		return myErrors.ErrConflict
+	}
+	return nil
+}
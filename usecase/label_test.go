@@ -0,0 +1,224 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestLabelUseCase_GetLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	labelUseCase := usecase.NewLabelUseCase(mockLabelRepo)
+
+	labels := []*model.Label{{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Label
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabels(gomock.Any(), uint(1)).Return(labels, nil).Times(1)
+			},
+			expectedResult: labels,
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetLabels",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabels(gomock.Any(), uint(1)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := labelUseCase.GetLabels(context.Background(), 1)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLabelUseCase_CreateLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	labelUseCase := usecase.NewLabelUseCase(mockLabelRepo)
+
+	label := &model.Label{CompanyID: 1, Name: "bug", Color: "#ff0000"}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.Label
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "bug").Return(nil, myErrors.ErrNotFound).Times(1)
+				mockLabelRepo.EXPECT().CreateLabel(gomock.Any(), label).Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}, nil).Times(1)
+			},
+			expectedResult: &model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"},
+			expectedError:  nil,
+		},
+		{
+			name: "Name already exists",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "bug").Return(&model.Label{ID: 2, CompanyID: 1, Name: "bug"}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name: "Error in GetLabelByName",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "bug").Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := labelUseCase.CreateLabel(context.Background(), label)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLabelUseCase_UpdateLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	labelUseCase := usecase.NewLabelUseCase(mockLabelRepo)
+
+	testCases := []struct {
+		name           string
+		label          *model.Label
+		mockFunc       func()
+		expectedResult *model.Label
+		expectedError  error
+	}{
+		{
+			name:  "Success",
+			label: &model.Label{ID: 1, CompanyID: 1, Name: "defect", Color: "#00ff00"},
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabel(gomock.Any(), uint(1), uint(1)).Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}, nil).Times(1)
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "defect").Return(nil, myErrors.ErrNotFound).Times(1)
+				mockLabelRepo.EXPECT().UpdateLabel(gomock.Any(), &model.Label{ID: 1, CompanyID: 1, Name: "defect", Color: "#00ff00"}).
+					Return(&model.Label{ID: 1, CompanyID: 1, Name: "defect", Color: "#00ff00"}, nil).Times(1)
+			},
+			expectedResult: &model.Label{ID: 1, CompanyID: 1, Name: "defect", Color: "#00ff00"},
+			expectedError:  nil,
+		},
+		{
+			name:  "Success with same name",
+			label: &model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#00ff00"},
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabel(gomock.Any(), uint(1), uint(1)).Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}, nil).Times(1)
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "bug").Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug"}, nil).Times(1)
+				mockLabelRepo.EXPECT().UpdateLabel(gomock.Any(), &model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#00ff00"}).
+					Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#00ff00"}, nil).Times(1)
+			},
+			expectedResult: &model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#00ff00"},
+			expectedError:  nil,
+		},
+		{
+			name:  "Name used by other label",
+			label: &model.Label{ID: 1, CompanyID: 1, Name: "urgent", Color: "#00ff00"},
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabel(gomock.Any(), uint(1), uint(1)).Return(&model.Label{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"}, nil).Times(1)
+				mockLabelRepo.EXPECT().GetLabelByName(gomock.Any(), uint(1), "urgent").Return(&model.Label{ID: 2, CompanyID: 1, Name: "urgent"}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name:  "Label not found",
+			label: &model.Label{ID: 1, CompanyID: 1, Name: "defect", Color: "#00ff00"},
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().GetLabel(gomock.Any(), uint(1), uint(1)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := labelUseCase.UpdateLabel(context.Background(), tc.label)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLabelUseCase_DeleteLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	labelUseCase := usecase.NewLabelUseCase(mockLabelRepo)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().DeleteLabel(gomock.Any(), uint(1), uint(2)).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Label not found",
+			mockFunc: func() {
+				mockLabelRepo.EXPECT().DeleteLabel(gomock.Any(), uint(1), uint(2)).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := labelUseCase.DeleteLabel(context.Background(), 1, 2)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+	"todo-api/model"
+	"todo-api/repository"
+)
+
+type NotificationUseCase interface {
+	GetNotifications(ctx context.Context, userId uint, unreadOnly bool, limit, offset int) (*model.NotificationPage, error)
+	MarkNotificationRead(ctx context.Context, userId, notificationId uint) error
+	MarkAllNotificationsRead(ctx context.Context, userId uint) error
+	GetNotificationPreferences(ctx context.Context, userId uint) ([]*model.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, userId uint, preferences []*model.NotificationPreference) ([]*model.NotificationPreference, error)
+}
+
+type notificationUseCase struct {
+	notificationRepository repository.NotificationRepository
+}
+
+func NewNotificationUseCase(notificationRepository repository.NotificationRepository) NotificationUseCase {
+	return &notificationUseCase{
+		notificationRepository: notificationRepository,
+	}
+}
+
+// GetNotifications は、ユーザの通知を新しい順に取得し、未読の件数と合わせて返す。
+func (u *notificationUseCase) GetNotifications(ctx context.Context, userId uint, unreadOnly bool, limit, offset int) (*model.NotificationPage, error) {
+	notifications, err := u.notificationRepository.GetNotifications(ctx, userId, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	unreadCount, err := u.notificationRepository.CountUnreadNotifications(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return &model.NotificationPage{Notifications: notifications, UnreadCount: unreadCount}, nil
+}
+
+// MarkNotificationRead は、通知を既読にする。他のユーザの通知の場合は ErrNotFound を返す。
+func (u *notificationUseCase) MarkNotificationRead(ctx context.Context, userId, notificationId uint) error {
+	return u.notificationRepository.MarkNotificationRead(ctx, userId, notificationId, time.Now())
+}
+
+// MarkAllNotificationsRead は、ユーザの未読の通知を全て既読にする。
+func (u *notificationUseCase) MarkAllNotificationsRead(ctx context.Context, userId uint) error {
+	return u.notificationRepository.MarkAllNotificationsRead(ctx, userId, time.Now())
+}
+
+// GetNotificationPreferences は、全ての種別の通知の設定を取得する。設定していない種別は受け取る設定として返す。
+func (u *notificationUseCase) GetNotificationPreferences(ctx context.Context, userId uint) ([]*model.NotificationPreference, error) {
+	stored, err := u.notificationRepository.GetNotificationPreferences(ctx, []uint{userId})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewNotificationPreferences(userId, stored), nil
+}
+
+// UpdateNotificationPreferences は、指定された種別の通知の設定を保存し、全ての種別の設定を返す。
+func (u *notificationUseCase) UpdateNotificationPreferences(ctx context.Context, userId uint, preferences []*model.NotificationPreference) ([]*model.NotificationPreference, error) {
+	for _, preference := range preferences {
+		preference.UserID = userId
+	}
+	if err := u.notificationRepository.SaveNotificationPreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return u.GetNotificationPreferences(ctx, userId)
+}
+
+// filterEnabledNotifications は、通知を受け取らない設定にしているユーザへの通知を除く。
+func filterEnabledNotifications(ctx context.Context, notificationRepository repository.NotificationRepository, notifications []*model.Notification) ([]*model.Notification, error) {
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+
+	userIds := []uint{}
+	for _, notification := range notifications {
+		if !slices.Contains(userIds, notification.UserID) {
+			userIds = append(userIds, notification.UserID)
+		}
+	}
+	preferences, err := notificationRepository.GetNotificationPreferences(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := []*model.Notification{}
+	for _, notification := range notifications {
+		if model.IsNotificationEnabled(preferences, notification.UserID, notification.Type) {
+			enabled = append(enabled, notification)
+		}
+	}
+	return enabled, nil
+}
+
+// createNotifications は、設定に従って通知を作成する。
+// 通知は操作に付随するものであり、操作自体は成功しているため、作成に失敗してもログに出力するのみとする。
+// 操作の確定後に呼ばれるため、リクエストが中断された場合も作成する。
+func createNotifications(ctx context.Context, notificationRepository repository.NotificationRepository, notifications []*model.Notification) {
+	ctx = context.WithoutCancel(ctx)
+	notifications, err := filterEnabledNotifications(ctx, notificationRepository, notifications)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error createNotifications: %v", err))
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		slog.Info(fmt.Sprintf("error createNotifications: %v", err))
+	}
+}
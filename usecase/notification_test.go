@@ -0,0 +1,243 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestNotificationUseCase_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+
+	notificationUseCase := usecase.NewNotificationUseCase(mockNotificationRepo)
+
+	userId := uint(1)
+	notifications := []*model.Notification{
+		{ID: 2, UserID: userId, CompanyID: 3, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED, Title: "Task"},
+	}
+
+	testCases := []struct {
+		name           string
+		unreadOnly     bool
+		mockFunc       func()
+		expectedResult *model.NotificationPage
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotifications(gomock.Any(), userId, false, 20, 0).Return(notifications, nil).Times(1)
+				mockNotificationRepo.EXPECT().CountUnreadNotifications(gomock.Any(), userId).Return(int64(5), nil).Times(1)
+			},
+			expectedResult: &model.NotificationPage{Notifications: notifications, UnreadCount: 5},
+			expectedError:  nil,
+		},
+		{
+			name:       "Success - unread only",
+			unreadOnly: true,
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotifications(gomock.Any(), userId, true, 20, 0).Return(notifications, nil).Times(1)
+				mockNotificationRepo.EXPECT().CountUnreadNotifications(gomock.Any(), userId).Return(int64(1), nil).Times(1)
+			},
+			expectedResult: &model.NotificationPage{Notifications: notifications, UnreadCount: 1},
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetNotifications",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotifications(gomock.Any(), userId, false, 20, 0).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+		{
+			name: "Error in CountUnreadNotifications",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotifications(gomock.Any(), userId, false, 20, 0).Return(notifications, nil).Times(1)
+				mockNotificationRepo.EXPECT().CountUnreadNotifications(gomock.Any(), userId).Return(int64(0), errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			page, err := notificationUseCase.GetNotifications(context.Background(), userId, tc.unreadOnly, 20, 0)
+
+			assert.Equal(t, tc.expectedResult, page)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNotificationUseCase_MarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+
+	notificationUseCase := usecase.NewNotificationUseCase(mockNotificationRepo)
+
+	userId := uint(1)
+	notificationId := uint(2)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().MarkNotificationRead(gomock.Any(), userId, notificationId, gomock.Any()).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Notification not found",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().MarkNotificationRead(gomock.Any(), userId, notificationId, gomock.Any()).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := notificationUseCase.MarkNotificationRead(context.Background(), userId, notificationId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNotificationUseCase_GetNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+
+	notificationUseCase := usecase.NewNotificationUseCase(mockNotificationRepo)
+
+	userId := uint(1)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.NotificationPreference
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{userId}).Return([]*model.NotificationPreference{
+					{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: false},
+				}, nil).Times(1)
+			},
+			// 設定していない種別は受け取る設定として返す
+			expectedResult: []*model.NotificationPreference{
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_STATUS_CHANGED, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: false},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Enabled: true},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error in GetNotificationPreferences",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{userId}).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			preferences, err := notificationUseCase.GetNotificationPreferences(context.Background(), userId)
+
+			assert.Equal(t, tc.expectedResult, preferences)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNotificationUseCase_UpdateNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+
+	notificationUseCase := usecase.NewNotificationUseCase(mockNotificationRepo)
+
+	userId := uint(1)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.NotificationPreference
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().SaveNotificationPreferences(gomock.Any(), []*model.NotificationPreference{
+					{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: false},
+				}).Return(nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{userId}).Return([]*model.NotificationPreference{
+					{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: false},
+				}, nil).Times(1)
+			},
+			expectedResult: []*model.NotificationPreference{
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_STATUS_CHANGED, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Enabled: true},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: false},
+				{UserID: userId, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Enabled: true},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error in SaveNotificationPreferences",
+			mockFunc: func() {
+				mockNotificationRepo.EXPECT().SaveNotificationPreferences(gomock.Any(), gomock.Any()).Return(myErrors.ErrDb).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			// ユーザIDはリクエストしたユーザのものに揃える
+			preferences, err := notificationUseCase.UpdateNotificationPreferences(context.Background(), userId, []*model.NotificationPreference{
+				{UserID: 9, Type: model.NOTIFICATION_TYPE_TASK_DUE_SOON, Enabled: false},
+			})
+
+			assert.Equal(t, tc.expectedResult, preferences)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/repository"
+)
+
+type RealtimeUseCase interface {
+	SubscribeEvents(ctx context.Context, companyId, userId uint, lastEventId *uint64) (*realtime.Subscription, error)
+	GetTask(ctx context.Context, companyId, taskId, userId uint) (*model.Task, error)
+	PublishPresence(ctx context.Context, companyId, taskId, userId uint, state string) error
+}
+
+type realtimeUseCase struct {
+	taskRepository repository.TaskRepository
+	broker         realtime.Broker
+}
+
+func NewRealtimeUseCase(taskRepository repository.TaskRepository, broker realtime.Broker) RealtimeUseCase {
+	return &realtimeUseCase{
+		taskRepository: taskRepository,
+		broker:         broker,
+	}
+}
+
+// SubscribeEvents は、会社のタスクとコメントの変更を購読する。非公開のタスクのイベントは作成者のみが受け取る。
+// lastEventId が指定された場合は、その ID より後のイベントを再送する。
+func (u *realtimeUseCase) SubscribeEvents(ctx context.Context, companyId, userId uint, lastEventId *uint64) (*realtime.Subscription, error) {
+	return u.broker.Subscribe(companyId, userId, lastEventId)
+}
+
+// GetTask は、userId のユーザが閲覧できるタスクを取得する。タスクごとの購読を始める前に、閲覧できることを確認するために使う。
+func (u *realtimeUseCase) GetTask(ctx context.Context, companyId, taskId, userId uint) (*model.Task, error) {
+	return u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+}
+
+// PublishPresence は、userId のユーザのタスクに対する状態 (閲覧中、入力中など) を、タスクを閲覧できるユーザに通知する。
+// 通知する間にタスクが非公開になっている場合があるため、公開範囲は毎回取得し直す。
+func (u *realtimeUseCase) PublishPresence(ctx context.Context, companyId, taskId, userId uint, state string) error {
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return err
+	}
+
+	event, err := realtime.NewPresenceEvent(task, userId, state)
+	if err != nil {
+		return err
+	}
+	u.broker.Publish(event)
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_realtime "todo-api/mock/realtime"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/usecase"
+)
+
+func TestRealtimeUseCase_SubscribeEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepository := mock_repository.NewMockTaskRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	realtimeUseCase := usecase.NewRealtimeUseCase(mockTaskRepository, mockBroker)
+
+	lastEventId := uint64(10)
+	subscription := &realtime.Subscription{Replay: []*realtime.Event{}}
+
+	testCases := []struct {
+		name           string
+		lastEventId    *uint64
+		mockFunc       func()
+		expectedResult *realtime.Subscription
+		expectedError  error
+	}{
+		{
+			name:        "Success",
+			lastEventId: &lastEventId,
+			mockFunc: func() {
+				mockBroker.EXPECT().Subscribe(uint(1), uint(2), &lastEventId).Return(subscription, nil).Times(1)
+			},
+			expectedResult: subscription,
+			expectedError:  nil,
+		},
+		{
+			name:        "Broker closed",
+			lastEventId: nil,
+			mockFunc: func() {
+				mockBroker.EXPECT().Subscribe(uint(1), uint(2), nil).Return(nil, realtime.ErrClosed).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  realtime.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := realtimeUseCase.SubscribeEvents(context.Background(), 1, 2, tc.lastEventId)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRealtimeUseCase_PublishPresence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepository := mock_repository.NewMockTaskRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	realtimeUseCase := usecase.NewRealtimeUseCase(mockTaskRepository, mockBroker)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepository.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(&model.Task{ID: 12, CompanyID: 1, CreateUserId: 3, Visibility: "company"}, nil).Times(1)
+				mockBroker.EXPECT().Publish(gomock.Cond(func(x any) bool {
+					event := x.(*realtime.Event)
+					return event.Type == realtime.EVENT_TYPE_PRESENCE_UPDATED &&
+						event.CompanyID == 1 &&
+						event.TaskID == 12 &&
+						event.ActorID == 2 &&
+						event.Transient &&
+						!event.Private &&
+						string(event.Data) == `{"task_id":12,"user_id":2,"state":"typing"}`
+				})).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Task not found",
+			mockFunc: func() {
+				mockTaskRepository.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+		{
+			name: "Failed to get task",
+			mockFunc: func() {
+				mockTaskRepository.EXPECT().GetTask(gomock.Any(), uint(1), uint(12), uint(2)).Return(nil, errors.New("database error")).Times(1)
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := realtimeUseCase.PublishPresence(context.Background(), 1, 12, 2, realtime.PRESENCE_STATE_TYPING)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -1,101 +1,248 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+	myErrors "todo-api/errors"
 	"todo-api/model"
+	"todo-api/realtime"
 	"todo-api/repository"
+	"todo-api/storage"
+
+	"gorm.io/gorm"
 )
 
 type TaskUseCase interface {
-	GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error)
-	GetTasks(limit, offset int) ([]*model.Task, error)
-	GetTask(companyId, taskId, createUserId uint) (*model.Task, error)
-	CreateTaskByAdmin(task *model.Task) (*model.Task, error)
-	CreateTask(task *model.Task) (*model.Task, error)
-	UpdateTaskByAdmin(taskId uint, task *model.Task) (*model.Task, error)
-	UpdateTask(companyId, taskId, createUserId uint, task *model.Task) (*model.Task, error)
-	DeleteTaskByAdmin(taskId uint) error
-	DeleteTask(companyId, taskId, createUserId uint) error
+	GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error)
+	GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error)
+	GetTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error)
+	GetSubtasks(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.Task, error)
+	SearchTasks(ctx context.Context, companyId, createUserId uint, query string, limit, offset int) ([]*model.Task, error)
+	CreateTaskByAdmin(ctx context.Context, task *model.Task) (*model.Task, error)
+	CreateTask(ctx context.Context, task *model.Task) (*model.Task, error)
+	UpdateTaskByAdmin(ctx context.Context, taskId, actorId uint, task *model.Task, version *uint, force bool) (*model.Task, error)
+	UpdateTask(ctx context.Context, companyId, taskId, createUserId uint, task *model.Task, version *uint, force bool) (*model.Task, error)
+	PatchTask(ctx context.Context, companyId, taskId, createUserId uint, patch *model.TaskPatch, version *uint, force bool) (*model.Task, error)
+	DeleteTaskByAdmin(ctx context.Context, taskId, actorId uint, subtaskPolicy string) error
+	DeleteTask(ctx context.Context, companyId, taskId, createUserId uint, subtaskPolicy string) error
+	GetTrashedTasks(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error)
+	RestoreTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error)
+	PurgeTaskByAdmin(ctx context.Context, taskId uint) error
+	PurgeExpiredTasks(ctx context.Context) (int64, error)
+	GetTaskHistory(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.TaskEvent, error)
+	MaterializeTaskOccurrences(ctx context.Context, now, until time.Time) (int, error)
 }
 
+const (
+	// 繰り返しのタスクの次のタスクを作成する際に、一度に取得するタスクの件数
+	TASK_RECURRENCE_BATCH_SIZE = 100
+)
+
 type taskUseCase struct {
-	taskRepository        repository.TaskRepository
-	companyRepository     repository.CompanyRepository
-	companyUserRepository repository.CompanyUserRepository
+	taskRepository           repository.TaskRepository
+	taskSearchRepository     repository.TaskSearchRepository
+	taskEventRepository      repository.TaskEventRepository
+	labelRepository          repository.LabelRepository
+	taskDependencyRepository repository.TaskDependencyRepository
+	companyRepository        repository.CompanyRepository
+	companyUserRepository    repository.CompanyUserRepository
+	notificationRepository   repository.NotificationRepository
+	broker                   realtime.Broker
+	storage                  storage.Storage
 }
 
 func NewTaskUseCase(
 	taskRepository repository.TaskRepository,
+	taskSearchRepository repository.TaskSearchRepository,
+	taskEventRepository repository.TaskEventRepository,
+	labelRepository repository.LabelRepository,
+	taskDependencyRepository repository.TaskDependencyRepository,
 	companyRepository repository.CompanyRepository,
 	companyUserRepository repository.CompanyUserRepository,
+	notificationRepository repository.NotificationRepository,
+	broker realtime.Broker,
+	storage storage.Storage,
 ) TaskUseCase {
 	return &taskUseCase{
-		taskRepository:        taskRepository,
-		companyRepository:     companyRepository,
-		companyUserRepository: companyUserRepository,
+		taskRepository:           taskRepository,
+		taskSearchRepository:     taskSearchRepository,
+		taskEventRepository:      taskEventRepository,
+		labelRepository:          labelRepository,
+		taskDependencyRepository: taskDependencyRepository,
+		companyRepository:        companyRepository,
+		companyUserRepository:    companyUserRepository,
+		notificationRepository:   notificationRepository,
+		broker:                   broker,
+		storage:                  storage,
 	}
 }
 
-func (u *taskUseCase) GetTasksByCompanyId(companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
-	_, err := u.companyRepository.GetCompany(companyId)
+func (u *taskUseCase) GetTasksByCompanyId(ctx context.Context, companyId, createUserId uint, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error) {
+	company, err := u.companyRepository.GetCompany(ctx, companyId)
 	if err != nil {
 		return nil, err
 	}
 
-	tasks, err := u.taskRepository.GetTasksByCompanyId(companyId, createUserId, limit, offset)
+	tasks, err := u.taskRepository.GetTasksByCompanyId(ctx, companyId, createUserId, filter, pagination)
 	if err != nil {
 		return nil, err
 	}
-	return tasks, nil
+	page := newTaskPage(tasks, filter, pagination)
+
+	// 件数の集計が重い会社では、総件数の取得を無効にできる
+	if pagination.WithTotalCount && !company.DisableTaskTotalCount {
+		totalCount, err := u.taskRepository.CountTasksByCompanyId(ctx, companyId, createUserId, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.TotalCount = &totalCount
+	}
+	return page, nil
 }
 
-func (u *taskUseCase) GetTasks(limit, offset int) ([]*model.Task, error) {
-	tasks, err := u.taskRepository.GetTasks(limit, offset)
+func (u *taskUseCase) GetTasks(ctx context.Context, filter *model.TaskFilter, pagination *model.TaskPagination) (*model.TaskPage, error) {
+	tasks, err := u.taskRepository.GetTasks(ctx, filter, pagination)
 	if err != nil {
 		return nil, err
 	}
-	return tasks, nil
+	page := newTaskPage(tasks, filter, pagination)
+
+	if pagination.WithTotalCount {
+		totalCount, err := u.taskRepository.CountTasks(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.TotalCount = &totalCount
+	}
+	return page, nil
 }
 
-func (u *taskUseCase) GetTask(companyId, taskId, createUserId uint) (*model.Task, error) {
-	task, err := u.taskRepository.GetTask(companyId, taskId, createUserId)
+// GetTask は、タスクを取得する。依存関係にあるタスクのうち、閲覧できるものも合わせて取得する。
+func (u *taskUseCase) GetTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error) {
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Blockers, err = u.taskDependencyRepository.GetBlockers(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+	task.Dependents, err = u.taskDependencyRepository.GetDependents(ctx, companyId, taskId, createUserId)
 	if err != nil {
 		return nil, err
 	}
 	return task, nil
 }
 
-func (u *taskUseCase) CreateTaskByAdmin(task *model.Task) (*model.Task, error) {
-	task, err := u.taskRepository.CreateTask(task)
+// GetSubtasks は、直下のサブタスクを取得する。親のタスクを閲覧できるユーザのみが取得できる。
+func (u *taskUseCase) GetSubtasks(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskRepository.GetSubtasks(ctx, companyId, taskId, createUserId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (u *taskUseCase) SearchTasks(ctx context.Context, companyId, createUserId uint, query string, limit, offset int) ([]*model.Task, error) {
+	_, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskSearchRepository.SearchTasks(ctx, companyId, createUserId, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (u *taskUseCase) CreateTaskByAdmin(ctx context.Context, task *model.Task) (*model.Task, error) {
+	if task.ParentTaskID != nil {
+		if err := u.ensureValidTaskParent(ctx, task.CompanyID, 0, *task.ParentTaskID, task.CreateUserId, true); err != nil {
+			return nil, err
+		}
+	}
+	labels, err := u.resolveTaskLabels(ctx, task.CompanyID, task.Labels)
+	if err != nil {
+		return nil, err
+	}
+	task.Labels = labels
+	rule := task.RecurrenceRule
+	task.RecurrenceRule = nil
+	if err := u.setTaskRecurrence(ctx, task, rule); err != nil {
+		return nil, err
+	}
+
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_CREATE, task.CreateUserId, true, nil, task)
+	task, err = u.taskRepository.CreateTask(ctx, task, event, newCreatedTaskWebhookEvent(event))
 	if err != nil {
 		return nil, err
 	}
+	u.publishTaskEvent(event, nil, task)
+	u.notifyTaskChanged(ctx, nil, task, task.CreateUserId)
 	return task, nil
 }
 
-func (u *taskUseCase) CreateTask(task *model.Task) (*model.Task, error) {
+func (u *taskUseCase) CreateTask(ctx context.Context, task *model.Task) (*model.Task, error) {
 	if task.AssigneeID != nil {
-		_, err := u.companyUserRepository.GetCompanyUser(task.CompanyID, *task.AssigneeID)
+		_, err := u.companyUserRepository.GetCompanyUser(ctx, task.CompanyID, *task.AssigneeID)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if task.ParentTaskID != nil {
+		if err := u.ensureValidTaskParent(ctx, task.CompanyID, 0, *task.ParentTaskID, task.CreateUserId, false); err != nil {
+			return nil, err
+		}
+	}
+	labels, err := u.resolveTaskLabels(ctx, task.CompanyID, task.Labels)
+	if err != nil {
+		return nil, err
+	}
+	task.Labels = labels
+	rule := task.RecurrenceRule
+	task.RecurrenceRule = nil
+	if err := u.setTaskRecurrence(ctx, task, rule); err != nil {
+		return nil, err
+	}
 
-	task, err := u.taskRepository.CreateTask(task)
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_CREATE, task.CreateUserId, false, nil, task)
+	task, err = u.taskRepository.CreateTask(ctx, task, event, newCreatedTaskWebhookEvent(event))
 	if err != nil {
 		return nil, err
 	}
+	u.publishTaskEvent(event, nil, task)
+	u.notifyTaskChanged(ctx, nil, task, task.CreateUserId)
 	return task, nil
 }
 
-func (u *taskUseCase) UpdateTaskByAdmin(taskId uint, task *model.Task) (*model.Task, error) {
-	oldTask, err := u.taskRepository.GetTaskById(taskId)
+// UpdateTaskByAdmin は、タスクを更新する。変更履歴には管理APIによる操作として記録する。
+// version が指定された場合は、現在のバージョンと一致する場合のみ更新する。
+// force が false の場合、未完了のブロッカーがあるタスクを完了にしようとすると ErrTaskBlocked を返す。
+// 繰り返しのタスクを完了にした場合は、次のタスクを作成する。
+func (u *taskUseCase) UpdateTaskByAdmin(ctx context.Context, taskId, actorId uint, task *model.Task, version *uint, force bool) (*model.Task, error) {
+	oldTask, err := u.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
 		return nil, err
 	}
+	if version != nil && *version != oldTask.Version {
+		return nil, myErrors.ErrPreconditionFailed
+	}
 
-	resultTask, err := u.taskRepository.UpdateTask(taskId, &model.Task{
+	newTask := &model.Task{
 		ID:           oldTask.ID,
 		CompanyID:    oldTask.CompanyID,
+		ParentTaskID: oldTask.ParentTaskID,
 		CreateUserId: oldTask.CreateUserId,
 		Title:        task.Title,
 		Description:  task.Description,
@@ -103,30 +250,82 @@ func (u *taskUseCase) UpdateTaskByAdmin(taskId uint, task *model.Task) (*model.T
 		AssigneeID:   task.AssigneeID,
 		Visibility:   task.Visibility,
 		Status:       task.Status,
+		Version:      oldTask.Version,
 		CreatedAt:    oldTask.CreatedAt,
-	})
+		Labels:       oldTask.Labels,
+		Progress:     oldTask.Progress,
+
+		RecurrenceRule:      oldTask.RecurrenceRule,
+		RecurrenceStartAt:   oldTask.RecurrenceStartAt,
+		RecurrenceIndex:     oldTask.RecurrenceIndex,
+		RecurrenceNextDueAt: oldTask.RecurrenceNextDueAt,
+	}
+	// 親タスクが指定されない場合は、現在の親タスクを維持する
+	if task.ParentTaskID != nil && !equalUint(oldTask.ParentTaskID, task.ParentTaskID) {
+		if err := u.ensureValidTaskParent(ctx, oldTask.CompanyID, oldTask.ID, *task.ParentTaskID, actorId, true); err != nil {
+			return nil, err
+		}
+		newTask.ParentTaskID = task.ParentTaskID
+	}
+	if !force {
+		if err := u.ensureTaskNotBlocked(ctx, oldTask, newTask.Status); err != nil {
+			return nil, err
+		}
+	}
+	// ラベルが指定されない場合は、現在のラベルを維持する
+	if task.Labels != nil {
+		newTask.Labels, err = u.resolveTaskLabels(ctx, oldTask.CompanyID, task.Labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 繰り返しのルールが指定されない場合は、現在のルールを維持する。空文字列の場合は解除する
+	if task.RecurrenceRule != nil {
+		if err := u.setTaskRecurrence(ctx, newTask, task.RecurrenceRule); err != nil {
+			return nil, err
+		}
+	}
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_UPDATE, actorId, true, oldTask, newTask)
+	webhookEvents, err := newTaskWebhookEvents(event, updatedTask(newTask, time.Now()), nil)
 	if err != nil {
 		return nil, err
 	}
+	resultTask, err := u.taskRepository.UpdateTask(ctx, taskId, newTask, event, webhookEvents)
+	if err != nil {
+		return nil, versionConflictError(err, version)
+	}
+	u.publishTaskEvent(event, oldTask, resultTask)
+	u.notifyTaskChanged(ctx, oldTask, resultTask, actorId)
+	if u.completeTaskOccurrence(ctx, oldTask, resultTask, actorId, true) {
+		return u.taskRepository.GetTaskById(ctx, taskId)
+	}
 	return resultTask, nil
 }
 
-func (u *taskUseCase) UpdateTask(companyId, taskId, createUserId uint, task *model.Task) (*model.Task, error) {
+// UpdateTask は、タスクを更新する。
+// version が指定された場合は、現在のバージョンと一致する場合のみ更新する。
+// force が false の場合、未完了のブロッカーがあるタスクを完了にしようとすると ErrTaskBlocked を返す。
+// 繰り返しのタスクを完了にした場合は、次のタスクを作成する。
+func (u *taskUseCase) UpdateTask(ctx context.Context, companyId, taskId, createUserId uint, task *model.Task, version *uint, force bool) (*model.Task, error) {
 	if task.AssigneeID != nil {
-		_, err := u.companyUserRepository.GetCompanyUser(task.CompanyID, *task.AssigneeID)
+		_, err := u.companyUserRepository.GetCompanyUser(ctx, task.CompanyID, *task.AssigneeID)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	oldTask, err := u.taskRepository.GetTask(companyId, taskId, createUserId)
+	oldTask, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
 	if err != nil {
 		return nil, err
 	}
+	if version != nil && *version != oldTask.Version {
+		return nil, myErrors.ErrPreconditionFailed
+	}
 
-	resultTask, err := u.taskRepository.UpdateTask(taskId, &model.Task{
+	newTask := &model.Task{
 		ID:           oldTask.ID,
 		CompanyID:    oldTask.CompanyID,
+		ParentTaskID: oldTask.ParentTaskID,
 		CreateUserId: oldTask.CreateUserId,
 		Title:        task.Title,
 		Description:  task.Description,
@@ -134,38 +333,679 @@ func (u *taskUseCase) UpdateTask(companyId, taskId, createUserId uint, task *mod
 		AssigneeID:   task.AssigneeID,
 		Visibility:   task.Visibility,
 		Status:       task.Status,
+		Version:      oldTask.Version,
 		CreatedAt:    oldTask.CreatedAt,
-	})
+		Labels:       oldTask.Labels,
+		Progress:     oldTask.Progress,
+
+		RecurrenceRule:      oldTask.RecurrenceRule,
+		RecurrenceStartAt:   oldTask.RecurrenceStartAt,
+		RecurrenceIndex:     oldTask.RecurrenceIndex,
+		RecurrenceNextDueAt: oldTask.RecurrenceNextDueAt,
+	}
+	// 親タスクが指定されない場合は、現在の親タスクを維持する
+	if task.ParentTaskID != nil && !equalUint(oldTask.ParentTaskID, task.ParentTaskID) {
+		if err := u.ensureValidTaskParent(ctx, oldTask.CompanyID, oldTask.ID, *task.ParentTaskID, createUserId, false); err != nil {
+			return nil, err
+		}
+		newTask.ParentTaskID = task.ParentTaskID
+	}
+	if !force {
+		if err := u.ensureTaskNotBlocked(ctx, oldTask, newTask.Status); err != nil {
+			return nil, err
+		}
+	}
+	// ラベルが指定されない場合は、現在のラベルを維持する
+	if task.Labels != nil {
+		newTask.Labels, err = u.resolveTaskLabels(ctx, oldTask.CompanyID, task.Labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 繰り返しのルールが指定されない場合は、現在のルールを維持する。空文字列の場合は解除する
+	if task.RecurrenceRule != nil {
+		if err := u.setTaskRecurrence(ctx, newTask, task.RecurrenceRule); err != nil {
+			return nil, err
+		}
+	}
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_UPDATE, createUserId, false, oldTask, newTask)
+	webhookEvents, err := newTaskWebhookEvents(event, updatedTask(newTask, time.Now()), nil)
 	if err != nil {
 		return nil, err
 	}
+	resultTask, err := u.taskRepository.UpdateTask(ctx, taskId, newTask, event, webhookEvents)
+	if err != nil {
+		return nil, versionConflictError(err, version)
+	}
+	u.publishTaskEvent(event, oldTask, resultTask)
+	u.notifyTaskChanged(ctx, oldTask, resultTask, createUserId)
+	if u.completeTaskOccurrence(ctx, oldTask, resultTask, createUserId, false) {
+		return u.taskRepository.GetTaskById(ctx, taskId)
+	}
 	return resultTask, nil
 }
 
-func (u *taskUseCase) DeleteTaskByAdmin(taskId uint) error {
-	_, err := u.taskRepository.GetTaskById(taskId)
+// PatchTask は、指定されたフィールドのみを更新し、更新後のタスクを返す。
+// version が指定された場合は、現在のバージョンと一致する場合のみ更新する。
+// force が false の場合、未完了のブロッカーがあるタスクを完了にしようとすると ErrTaskBlocked を返す。
+// 繰り返しのタスクを完了にした場合は、次のタスクを作成する。
+func (u *taskUseCase) PatchTask(ctx context.Context, companyId, taskId, createUserId uint, patch *model.TaskPatch, version *uint, force bool) (*model.Task, error) {
+	if patch.AssigneeID.Set && patch.AssigneeID.Value != nil {
+		_, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, *patch.AssigneeID.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+	if version != nil && *version != task.Version {
+		return nil, myErrors.ErrPreconditionFailed
+	}
+	if patch.IsEmpty() {
+		return task, nil
+	}
+	if patch.ParentTaskID.Set && patch.ParentTaskID.Value != nil && !equalUint(task.ParentTaskID, patch.ParentTaskID.Value) {
+		if err := u.ensureValidTaskParent(ctx, companyId, task.ID, *patch.ParentTaskID.Value, createUserId, false); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Status.Set && !force {
+		if err := u.ensureTaskNotBlocked(ctx, task, patch.Status.Value); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Labels.Set {
+		patch.Labels.Value, err = u.resolveTaskLabels(ctx, companyId, patch.Labels.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if patch.RecurrenceRule.Set {
+		patched := patch.Apply(task)
+		patched.RecurrenceRule = task.RecurrenceRule
+		if err := u.setTaskRecurrence(ctx, patched, patch.RecurrenceRule.Value); err != nil {
+			return nil, err
+		}
+		patch.RecurrenceRule.Value = patched.RecurrenceRule
+		patch.RecurrenceStartAt = model.Optional[*time.Time]{Set: true, Value: patched.RecurrenceStartAt}
+		patch.RecurrenceIndex = model.Optional[int]{Set: true, Value: patched.RecurrenceIndex}
+		patch.RecurrenceNextDueAt = model.Optional[*time.Time]{Set: true, Value: patched.RecurrenceNextDueAt}
+	}
+
+	patched := patch.Apply(task)
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_UPDATE, createUserId, false, task, patched)
+	webhookEvents, err := newTaskWebhookEvents(event, updatedTask(patched, time.Now()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.taskRepository.PatchTask(ctx, task.ID, task.Version, patch, event, webhookEvents); err != nil {
+		return nil, versionConflictError(err, version)
+	}
+	u.notifyTaskChanged(ctx, task, patched, createUserId)
+	u.completeTaskOccurrence(ctx, task, patched, createUserId, false)
+	result, err := u.taskRepository.GetTaskById(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	u.publishTaskEvent(event, task, result)
+	return result, nil
+}
+
+// DeleteTaskByAdmin は、タスクをゴミ箱に移動する。
+// subtaskPolicy が cascade の場合はサブタスクもゴミ箱に移動し、block の場合はサブタスクがあれば ErrConflict を返す。
+func (u *taskUseCase) DeleteTaskByAdmin(ctx context.Context, taskId, actorId uint, subtaskPolicy string) error {
+	task, err := u.taskRepository.GetTaskById(ctx, taskId)
 	if err != nil {
 		return err
 	}
+	if subtaskPolicy != model.SUBTASK_DELETE_POLICY_CASCADE && task.Progress.SubtasksTotal > 0 {
+		return myErrors.ErrConflict
+	}
+	subtasks := []*model.Task{}
+	if task.Progress.SubtasksTotal > 0 {
+		subtasks, err = u.taskRepository.GetTaskDescendants(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+	}
 
-	err = u.taskRepository.DeleteTaskById(taskId)
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_DELETE, actorId, true, task, nil)
+	now := time.Now()
+	webhookEvents, err := newTaskWebhookEvents(event, deletedTask(task, now), deletedTasks(subtasks, now))
+	if err != nil {
+		return err
+	}
+	err = u.taskRepository.DeleteTaskById(ctx, taskId, event, webhookEvents)
 	if err != nil {
 		return err
 	}
+	u.publishTaskEvent(event, nil, task)
 
 	return nil
 }
 
-func (u *taskUseCase) DeleteTask(companyId, taskId, createUserId uint) error {
-	_, err := u.taskRepository.GetTask(companyId, taskId, createUserId)
+// DeleteTask は、タスクをゴミ箱に移動する。
+// subtaskPolicy が cascade の場合はサブタスクもゴミ箱に移動し、block の場合はサブタスクがあれば ErrConflict を返す。
+// cascade の場合は、子孫のタスクも削除できるかを確認する。
+func (u *taskUseCase) DeleteTask(ctx context.Context, companyId, taskId, createUserId uint, subtaskPolicy string) error {
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
 	if err != nil {
 		return err
 	}
 
-	err = u.taskRepository.DeleteTask(companyId, taskId)
+	if err := u.ensureCanDeleteTask(ctx, companyId, createUserId, task); err != nil {
+		return err
+	}
+	if subtaskPolicy != model.SUBTASK_DELETE_POLICY_CASCADE && task.Progress.SubtasksTotal > 0 {
+		return myErrors.ErrConflict
+	}
+	subtasks := []*model.Task{}
+	if task.Progress.SubtasksTotal > 0 {
+		subtasks, err = u.taskRepository.GetTaskDescendants(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := u.ensureCanDeleteSubtasks(ctx, companyId, createUserId, subtasks); err != nil {
+			return err
+		}
+	}
+
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_DELETE, createUserId, false, task, nil)
+	now := time.Now()
+	webhookEvents, err := newTaskWebhookEvents(event, deletedTask(task, now), deletedTasks(subtasks, now))
+	if err != nil {
+		return err
+	}
+	err = u.taskRepository.DeleteTask(ctx, companyId, taskId, event, webhookEvents)
 	if err != nil {
 		return err
 	}
+	u.publishTaskEvent(event, nil, task)
 
 	return nil
 }
+
+func (u *taskUseCase) GetTrashedTasks(ctx context.Context, companyId, createUserId uint, limit, offset int) ([]*model.Task, error) {
+	_, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskRepository.GetTrashedTasksByCompanyId(ctx, companyId, createUserId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// RestoreTask は、ゴミ箱のタスクを元に戻す。削除できるユーザのみが元に戻せる。
+// 親のタスクがゴミ箱にある場合は ErrConflict を返す。
+func (u *taskUseCase) RestoreTask(ctx context.Context, companyId, taskId, createUserId uint) (*model.Task, error) {
+	task, err := u.taskRepository.GetTrashedTask(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.ensureCanDeleteTask(ctx, companyId, createUserId, task); err != nil {
+		return nil, err
+	}
+	if task.ParentTaskID != nil {
+		_, err := u.taskRepository.GetTaskById(ctx, *task.ParentTaskID)
+		if errors.Is(err, myErrors.ErrNotFound) {
+			return nil, myErrors.ErrConflict
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subtasks, err := u.taskRepository.GetTrashedSubtasks(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	event := model.NewTaskEvent(model.TASK_EVENT_ACTION_RESTORE, createUserId, false, task, nil)
+	now := time.Now()
+	webhookEvents, err := newTaskWebhookEvents(event, restoredTask(task, now), restoredTasks(subtasks, now))
+	if err != nil {
+		return nil, err
+	}
+	if err := u.taskRepository.RestoreTask(ctx, taskId, event, webhookEvents); err != nil {
+		return nil, err
+	}
+	result, err := u.taskRepository.GetTaskById(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	u.publishTaskEvent(event, nil, result)
+	return result, nil
+}
+
+// PurgeTaskByAdmin は、ゴミ箱のタスクを完全に削除し、添付ファイルもストレージから削除する。
+func (u *taskUseCase) PurgeTaskByAdmin(ctx context.Context, taskId uint) error {
+	storageKeys, err := u.taskRepository.PurgeTask(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	deleteStoredAttachments(ctx, u.storage, storageKeys)
+	return nil
+}
+
+// PurgeExpiredTasks は、会社ごとの保持期間を過ぎたゴミ箱のタスクを完全に削除し、添付ファイルもストレージから削除する。
+// 削除したタスクの件数を返す。
+func (u *taskUseCase) PurgeExpiredTasks(ctx context.Context) (int64, error) {
+	count, storageKeys, err := u.taskRepository.PurgeExpiredTasks(ctx)
+	if err != nil {
+		return 0, err
+	}
+	deleteStoredAttachments(ctx, u.storage, storageKeys)
+	return count, nil
+}
+
+// GetTaskHistory は、タスクの変更履歴を新しい順に取得する。タスクを閲覧できるユーザのみが取得できる。
+func (u *taskUseCase) GetTaskHistory(ctx context.Context, companyId, taskId, createUserId uint, limit, offset int) ([]*model.TaskEvent, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, createUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.taskEventRepository.GetTaskEvents(ctx, taskId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MaterializeTaskOccurrences は、次の繰り返しの期限が until 以前のタスクについて次のタスクを作成し、作成した件数を返す。
+// 作成したタスクの次の繰り返しも until 以前であれば続けて作成する。now より前の繰り返しは作成せずに飛ばす。
+// 変更履歴には、繰り返しのタスクの作成者による操作として記録する。
+func (u *taskUseCase) MaterializeTaskOccurrences(ctx context.Context, now, until time.Time) (int, error) {
+	locations := map[uint]*time.Location{}
+	count := 0
+	for {
+		tasks, err := u.taskRepository.GetTasksWithDueRecurrence(ctx, until, TASK_RECURRENCE_BATCH_SIZE)
+		if err != nil {
+			return count, err
+		}
+		if len(tasks) == 0 {
+			return count, nil
+		}
+
+		for _, task := range tasks {
+			loc, ok := locations[task.CompanyID]
+			if !ok {
+				company, err := u.companyRepository.GetCompany(ctx, task.CompanyID)
+				if err != nil {
+					return count, err
+				}
+				loc = company.Location()
+				locations[task.CompanyID] = loc
+			}
+
+			next, err := u.createNextTaskOccurrence(ctx, task, task.CreateUserId, false, loc, now)
+			// 完了による作成と同時に行われた場合は、既に作成されている
+			if errors.Is(err, myErrors.ErrConflict) {
+				continue
+			}
+			if err != nil {
+				return count, err
+			}
+			if next != nil {
+				count++
+			}
+		}
+	}
+}
+
+// setTaskRecurrence は、繰り返しのルールが現在と異なる場合に、タスクの期限を起点として繰り返しを設定し直す。
+// value が nil または空文字列の場合は繰り返しを解除する。ルールが不正な場合や期限がない場合は ErrInvalidRecurrence を返す。
+func (u *taskUseCase) setTaskRecurrence(ctx context.Context, task *model.Task, value *string) error {
+	var rule *model.RecurrenceRule
+	if value != nil && *value != "" {
+		var err error
+		rule, err = model.ParseRecurrenceRule(*value)
+		if err != nil {
+			return myErrors.ErrInvalidRecurrence
+		}
+	}
+	if rule == nil && task.RecurrenceRule == nil {
+		return nil
+	}
+	if rule != nil && task.RecurrenceRule != nil && rule.String() == *task.RecurrenceRule {
+		return nil
+	}
+
+	loc := time.UTC
+	if rule != nil {
+		company, err := u.companyRepository.GetCompany(ctx, task.CompanyID)
+		if err != nil {
+			return err
+		}
+		loc = company.Location()
+	}
+	return task.StartRecurrence(rule, loc)
+}
+
+// completeTaskOccurrence は、繰り返しのタスクが完了になった場合に次のタスクを作成し、作成したかどうかを返す。
+// 更新自体は成功しているため、作成に失敗してもエラーは返さない。残った期限はスケジューラが作成する。
+func (u *taskUseCase) completeTaskOccurrence(ctx context.Context, oldTask, newTask *model.Task, actorId uint, byAdmin bool) bool {
+	if oldTask.Status == model.TASK_STATUS_DONE || newTask.Status != model.TASK_STATUS_DONE || newTask.RecurrenceNextDueAt == nil {
+		return false
+	}
+
+	company, err := u.companyRepository.GetCompany(ctx, newTask.CompanyID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error completeTaskOccurrence: %v", err))
+		return false
+	}
+	_, err = u.createNextTaskOccurrence(ctx, newTask, actorId, byAdmin, company.Location(), time.Now())
+	// 既にスケジューラが作成している場合は何もしない
+	if errors.Is(err, myErrors.ErrConflict) {
+		return false
+	}
+	if err != nil {
+		slog.Info(fmt.Sprintf("error completeTaskOccurrence: %v", err))
+		return false
+	}
+	return true
+}
+
+// createNextTaskOccurrence は、繰り返しのタスクの次のタスクを作成する。繰り返しが終了した場合は nil を返す。
+// 次のタスクが既に作成されている場合は ErrConflict を返す。
+func (u *taskUseCase) createNextTaskOccurrence(ctx context.Context, task *model.Task, actorId uint, byAdmin bool, loc *time.Location, now time.Time) (*model.Task, error) {
+	next, err := task.NextOccurrence(loc, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *model.TaskEvent
+	if next != nil {
+		event = model.NewTaskEvent(model.TASK_EVENT_ACTION_CREATE, actorId, byAdmin, nil, next)
+	}
+	next, err = u.taskRepository.CreateTaskOccurrence(ctx, task.ID, next, event, newCreatedTaskWebhookEvent(event))
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		u.publishTaskEvent(event, nil, next)
+	}
+	return next, nil
+}
+
+// resolveTaskLabels は、ID のみが指定されたラベルを会社のラベルから取得する。
+// labels が nil の場合は nil を返す。会社に存在しないラベルが含まれる場合は ErrInvalidArgument を返す。
+func (u *taskUseCase) resolveTaskLabels(ctx context.Context, companyId uint, labels []*model.Label) ([]*model.Label, error) {
+	if labels == nil {
+		return nil, nil
+	}
+
+	ids := []uint{}
+	for _, label := range labels {
+		if !slices.Contains(ids, label.ID) {
+			ids = append(ids, label.ID)
+		}
+	}
+	resolved, err := u.labelRepository.GetLabelsByIds(ctx, companyId, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(resolved) != len(ids) {
+		return nil, myErrors.ErrInvalidArgument
+	}
+	return resolved, nil
+}
+
+// ensureValidTaskParent は、parentId のタスクを taskId のタスクの親に指定できるかを確認する。作成時は taskId に0を指定する。
+// 親タスクは同じ会社のタスクで、byAdmin でない場合はユーザが閲覧できるタスクに限る。
+// 親タスクに指定できない場合や自身または子孫を親に指定した場合は ErrInvalidParentTask、
+// 階層の深さが上限を超える場合は ErrTaskDepthExceeded を返す。
+func (u *taskUseCase) ensureValidTaskParent(ctx context.Context, companyId, taskId, parentId, userId uint, byAdmin bool) error {
+	var parent *model.Task
+	var err error
+	if byAdmin {
+		parent, err = u.taskRepository.GetTaskById(ctx, parentId)
+	} else {
+		parent, err = u.taskRepository.GetTask(ctx, companyId, parentId, userId)
+	}
+	if errors.Is(err, myErrors.ErrNotFound) {
+		return myErrors.ErrInvalidParentTask
+	}
+	if err != nil {
+		return err
+	}
+	if parent.CompanyID != companyId {
+		return myErrors.ErrInvalidParentTask
+	}
+
+	ancestorIds, err := u.taskRepository.GetTaskAncestorIds(ctx, parentId)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(ancestorIds, taskId) {
+		return myErrors.ErrInvalidParentTask
+	}
+	height := 1
+	if taskId != 0 {
+		height, err = u.taskRepository.GetTaskSubtreeHeight(ctx, taskId)
+		if err != nil {
+			return err
+		}
+	}
+	if len(ancestorIds)+height > model.MAX_TASK_DEPTH {
+		return myErrors.ErrTaskDepthExceeded
+	}
+	return nil
+}
+
+// ensureTaskNotBlocked は、未完了のタスクに妨げられているタスクを完了にしようとしていないかを確認する。
+// 既に完了しているタスクや、完了以外のステータスへの変更は確認しない。
+func (u *taskUseCase) ensureTaskNotBlocked(ctx context.Context, task *model.Task, status string) error {
+	if status != model.TASK_STATUS_DONE || task.Status == model.TASK_STATUS_DONE {
+		return nil
+	}
+
+	count, err := u.taskDependencyRepository.CountUnfinishedBlockers(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return myErrors.ErrTaskBlocked
+	}
+	return nil
+}
+
+// equalUint は、親タスクや担当者のIDが同じかどうかを返す。
+func equalUint(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// notifyTaskChanged は、タスクの担当者の指定とステータスの変更を関係するユーザに通知する。作成時は oldTask に nil を指定する。
+func (u *taskUseCase) notifyTaskChanged(ctx context.Context, oldTask, newTask *model.Task, actorId uint) {
+	notifications := []*model.Notification{}
+	if newTask.AssigneeID != nil && (oldTask == nil || !equalUint(oldTask.AssigneeID, newTask.AssigneeID)) {
+		notifications = append(notifications, model.NewTaskNotifications(model.NOTIFICATION_TYPE_TASK_ASSIGNED, newTask, &actorId, []uint{*newTask.AssigneeID})...)
+	}
+	if oldTask != nil && oldTask.Status != newTask.Status {
+		notifications = append(notifications, model.NewTaskNotifications(model.NOTIFICATION_TYPE_TASK_STATUS_CHANGED, newTask, &actorId, model.TaskWatcherIds(newTask))...)
+	}
+	createNotifications(ctx, u.notificationRepository, notifications)
+}
+
+// newCreatedTaskWebhookEvent は、作成したタスクから Webhook で送信するイベントを作成する関数を返す。
+// 作成するタスクの ID は書き込むまで決まらないため、リポジトリが作成したタスクを渡して呼び出す。
+func newCreatedTaskWebhookEvent(event *model.TaskEvent) func(task *model.Task) (*model.WebhookEvent, error) {
+	return func(task *model.Task) (*model.WebhookEvent, error) {
+		webhookEvents, err := newTaskWebhookEvents(event, task, nil)
+		if err != nil || len(webhookEvents) == 0 {
+			return nil, err
+		}
+		return webhookEvents[0], nil
+	}
+}
+
+// newTaskWebhookEvents は、変更後のタスクと、同じ操作で変更した子孫のタスク subtasks から、Webhook で送信するイベントを作成する。
+// 子孫のタスクのイベントには、変更履歴と同様に変更内容を含めない。非公開のタスクのイベントは作成しない。
+func newTaskWebhookEvents(event *model.TaskEvent, task *model.Task, subtasks []*model.Task) ([]*model.WebhookEvent, error) {
+	webhookEvents := []*model.WebhookEvent{}
+	subtaskEvent := *event
+	subtaskEvent.Changes = map[string]model.TaskFieldChange{}
+	for i, t := range append([]*model.Task{task}, subtasks...) {
+		e := event
+		if i > 0 {
+			e = &subtaskEvent
+		}
+		webhookEvent, err := model.NewTaskWebhookEvent(e, t)
+		if err != nil {
+			slog.Info(fmt.Sprintf("error newTaskWebhookEvents: %v", err))
+			return nil, myErrors.ErrInternal
+		}
+		if webhookEvent != nil {
+			webhookEvents = append(webhookEvents, webhookEvent)
+		}
+	}
+	return webhookEvents, nil
+}
+
+// updatedTask は、リポジトリが更新を書き込んだ後のタスクの内容を返す。バージョンは1つ進む。
+func updatedTask(task *model.Task, now time.Time) *model.Task {
+	updated := *task
+	updated.Version = task.Version + 1
+	updated.UpdatedAt = &now
+	return &updated
+}
+
+// deletedTask は、ゴミ箱に移動した後のタスクの内容を返す。
+func deletedTask(task *model.Task, now time.Time) *model.Task {
+	deleted := *task
+	deleted.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+	return &deleted
+}
+
+func deletedTasks(tasks []*model.Task, now time.Time) []*model.Task {
+	deleted := []*model.Task{}
+	for _, task := range tasks {
+		deleted = append(deleted, deletedTask(task, now))
+	}
+	return deleted
+}
+
+// restoredTask は、ゴミ箱から元に戻した後のタスクの内容を返す。バージョンは1つ進む。
+func restoredTask(task *model.Task, now time.Time) *model.Task {
+	restored := updatedTask(task, now)
+	restored.DeletedAt = gorm.DeletedAt{}
+	return restored
+}
+
+func restoredTasks(tasks []*model.Task, now time.Time) []*model.Task {
+	restored := []*model.Task{}
+	for _, task := range tasks {
+		restored = append(restored, restoredTask(task, now))
+	}
+	return restored
+}
+
+// versionConflictError は、読み込んだ後に他の更新でタスクのバージョンが変わり、更新できなかった場合のエラーを返す。
+// If-Match でバージョンが指定されていた場合は前提条件の不一致として ErrPreconditionFailed、そうでなければ ErrConflict とする。
+func versionConflictError(err error, version *uint) error {
+	if version != nil && errors.Is(err, myErrors.ErrConflict) {
+		return myErrors.ErrPreconditionFailed
+	}
+	return err
+}
+
+// publishTaskEvent は、タスクの変更を購読している接続に配信する。作成、削除、復元の場合は oldTask に nil を指定する。
+// タスクが非公開になった場合は、作成者以外に閲覧できなくなったことを配信する。
+// 変更自体は成功しているため、配信に失敗してもエラーは返さない。
+func (u *taskUseCase) publishTaskEvent(event *model.TaskEvent, oldTask, task *model.Task) {
+	realtimeEvent, err := realtime.NewTaskEvent(event, task)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error publishTaskEvent: %v", err))
+		return
+	}
+	if realtimeEvent != nil {
+		u.broker.Publish(realtimeEvent)
+	}
+
+	if oldTask == nil || oldTask.Visibility == "private" || task.Visibility != "private" {
+		return
+	}
+	hiddenEvent, err := realtime.NewTaskHiddenEvent(task)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error publishTaskEvent: %v", err))
+		return
+	}
+	u.broker.Publish(hiddenEvent)
+}
+
+// ensureCanDeleteTask は、他のユーザが作成したタスクの場合、ロールで削除が許可されているかを確認する。
+func (u *taskUseCase) ensureCanDeleteTask(ctx context.Context, companyId, userId uint, task *model.Task) error {
+	if task.CreateUserId == userId {
+		return nil
+	}
+
+	companyUser, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+	if err != nil {
+		return err
+	}
+	if !companyUser.Can(model.ACTION_DELETE_OTHERS_TASK) {
+		return myErrors.ErrForbidden
+	}
+	return nil
+}
+
+// ensureCanDeleteSubtasks は、子孫のタスク subtasks も一緒にゴミ箱に移動できるかを確認する。
+// 子孫に見ることのできない他のユーザの非公開のタスクがある場合は ErrConflict、
+// 他のユーザのタスクがあり、ロールで削除が許可されていない場合は ErrForbidden を返す。
+func (u *taskUseCase) ensureCanDeleteSubtasks(ctx context.Context, companyId, userId uint, subtasks []*model.Task) error {
+	var othersSubtask *model.Task
+	for _, subtask := range subtasks {
+		if subtask.CreateUserId == userId {
+			continue
+		}
+		if subtask.Visibility == "private" {
+			return myErrors.ErrConflict.WithMessage("subtasks include private tasks of other users")
+		}
+		othersSubtask = subtask
+	}
+	if othersSubtask == nil {
+		return nil
+	}
+	return u.ensureCanDeleteTask(ctx, companyId, userId, othersSubtask)
+}
+
+// newTaskPage は、リポジトリから Limit より1件多く取得したタスクから、ページング結果を組み立てる。
+func newTaskPage(tasks []*model.Task, filter *model.TaskFilter, pagination *model.TaskPagination) *model.TaskPage {
+	hasMore := len(tasks) > pagination.Limit
+	if hasMore {
+		tasks = tasks[:pagination.Limit]
+	}
+
+	page := &model.TaskPage{Tasks: tasks}
+	if len(tasks) == 0 {
+		return page
+	}
+
+	// prev のカーソルでは逆順に取得しているため、元の並び順に戻す
+	if pagination.Cursor != nil && pagination.Cursor.Direction == model.CURSOR_DIRECTION_PREV {
+		slices.Reverse(tasks)
+		if hasMore {
+			page.PrevCursor = model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, filter, tasks[0])
+		}
+		page.NextCursor = model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, filter, tasks[len(tasks)-1])
+		return page
+	}
+
+	if hasMore {
+		page.NextCursor = model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, filter, tasks[len(tasks)-1])
+	}
+	if pagination.Cursor != nil || pagination.Offset > 0 {
+		page.PrevCursor = model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, filter, tasks[0])
+	}
+	return page
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/repository"
+	"todo-api/storage"
+	"unicode/utf8"
+)
+
+// allowedAttachmentContentTypes は添付を許可するファイル形式。内容から判定した形式で確認する。
+var allowedAttachmentContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+type TaskAttachmentUseCase interface {
+	GetTaskAttachments(ctx context.Context, companyId, taskId, userId uint) ([]*model.TaskAttachment, error)
+	UploadTaskAttachment(ctx context.Context, companyId, taskId, userId uint, fileName string, size int64, r io.Reader) (*model.TaskAttachment, error)
+	OpenTaskAttachment(ctx context.Context, companyId, taskId, attachmentId, userId uint) (*model.TaskAttachment, io.ReadSeekCloser, error)
+	DeleteTaskAttachment(ctx context.Context, companyId, taskId, attachmentId, userId uint) error
+}
+
+type taskAttachmentUseCase struct {
+	taskRepository           repository.TaskRepository
+	taskAttachmentRepository repository.TaskAttachmentRepository
+	companyRepository        repository.CompanyRepository
+	companyUserRepository    repository.CompanyUserRepository
+	storage                  storage.Storage
+}
+
+func NewTaskAttachmentUseCase(
+	taskRepository repository.TaskRepository,
+	taskAttachmentRepository repository.TaskAttachmentRepository,
+	companyRepository repository.CompanyRepository,
+	companyUserRepository repository.CompanyUserRepository,
+	storage storage.Storage,
+) TaskAttachmentUseCase {
+	return &taskAttachmentUseCase{
+		taskRepository:           taskRepository,
+		taskAttachmentRepository: taskAttachmentRepository,
+		companyRepository:        companyRepository,
+		companyUserRepository:    companyUserRepository,
+		storage:                  storage,
+	}
+}
+
+// GetTaskAttachments は、タスクの添付ファイルの一覧を取得する。閲覧できないタスクの場合は ErrNotFound を返す。
+func (u *taskAttachmentUseCase) GetTaskAttachments(ctx context.Context, companyId, taskId, userId uint) ([]*model.TaskAttachment, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := u.taskAttachmentRepository.GetTaskAttachments(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// UploadTaskAttachment は、ファイルをストレージに保存し、添付ファイルとして登録する。
+// ファイル形式は拡張子や申告された Content-Type ではなく、先頭のバイト列から判定する。
+// 会社の容量の上限を超える場合は ErrQuotaExceeded を返す。
+func (u *taskAttachmentUseCase) UploadTaskAttachment(ctx context.Context, companyId, taskId, userId uint, fileName string, size int64, r io.Reader) (*model.TaskAttachment, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	// 明らかに上限を超える場合は、ストレージに保存する前に拒否する
+	usage, err := u.taskAttachmentRepository.GetAttachmentUsage(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	if usage+size > company.AttachmentQuotaBytes {
+		return nil, myErrors.ErrQuotaExceeded
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !allowedAttachmentContentTypes[contentType] {
+		return nil, myErrors.ErrUnsupportedMediaType.WithMessage(fmt.Sprintf("unsupported media type: %s", contentType))
+	}
+
+	key, err := newAttachmentStorageKey(companyId, taskId)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.storage.Put(ctx, key, io.MultiReader(bytes.NewReader(head), r), size, contentType); err != nil {
+		return nil, err
+	}
+
+	attachment, err := u.taskAttachmentRepository.CreateTaskAttachment(ctx, &model.TaskAttachment{
+		TaskID:      taskId,
+		CompanyID:   companyId,
+		UserID:      userId,
+		FileName:    sanitizeAttachmentFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}, company.AttachmentQuotaBytes)
+	if err != nil {
+		// 登録できなかったファイルはストレージから削除する。リクエストが中断された場合も削除する
+		if deleteErr := u.storage.Delete(context.WithoutCancel(ctx), key); deleteErr != nil {
+			slog.Info(fmt.Sprintf("error UploadTaskAttachment: failed to delete %s: %v", key, deleteErr))
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenTaskAttachment は、添付ファイルのメタデータと内容を返す。呼び出し側で内容を Close する必要がある。
+func (u *taskAttachmentUseCase) OpenTaskAttachment(ctx context.Context, companyId, taskId, attachmentId, userId uint) (*model.TaskAttachment, io.ReadSeekCloser, error) {
+	attachment, err := u.getTaskAttachment(ctx, companyId, taskId, attachmentId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := u.storage.Open(ctx, attachment.StorageKey, attachment.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, file, nil
+}
+
+// DeleteTaskAttachment は、添付ファイルを削除する。
+// アップロードしたユーザ以外は、他のユーザのタスクを削除できるロールのみが削除できる。
+func (u *taskAttachmentUseCase) DeleteTaskAttachment(ctx context.Context, companyId, taskId, attachmentId, userId uint) error {
+	attachment, err := u.getTaskAttachment(ctx, companyId, taskId, attachmentId, userId)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UserID != userId {
+		companyUser, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+		if err != nil {
+			return err
+		}
+		if !companyUser.Can(model.ACTION_DELETE_OTHERS_TASK) {
+			return myErrors.ErrForbidden
+		}
+	}
+
+	if err := u.taskAttachmentRepository.DeleteTaskAttachment(ctx, taskId, attachmentId); err != nil {
+		return err
+	}
+	deleteStoredAttachments(ctx, u.storage, []string{attachment.StorageKey})
+	return nil
+}
+
+// deleteStoredAttachments は、メタデータを削除した添付ファイルの実体をストレージから削除する。
+// メタデータは削除済みのため、ストレージからの削除に失敗してもエラーにしない。リクエストが中断された場合も削除する
+func deleteStoredAttachments(ctx context.Context, attachmentStorage storage.Storage, storageKeys []string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, storageKey := range storageKeys {
+		if err := attachmentStorage.Delete(ctx, storageKey); err != nil {
+			slog.Info(fmt.Sprintf("error deleteStoredAttachments: failed to delete %s: %v", storageKey, err))
+		}
+	}
+}
+
+// getTaskAttachment は、タスクの閲覧権限を確認した上で添付ファイルを取得する。
+func (u *taskAttachmentUseCase) getTaskAttachment(ctx context.Context, companyId, taskId, attachmentId, userId uint) (*model.TaskAttachment, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return u.taskAttachmentRepository.GetTaskAttachment(ctx, taskId, attachmentId)
+}
+
+// newAttachmentStorageKey は、推測できないストレージのキーを生成する。
+func newAttachmentStorageKey(companyId, taskId uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("companies/%d/tasks/%d/%s", companyId, taskId, hex.EncodeToString(b)), nil
+}
+
+// sanitizeAttachmentFileName は、ファイル名からディレクトリ部分を取り除き、255バイト以内に切り詰める。
+func sanitizeAttachmentFileName(fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "file"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
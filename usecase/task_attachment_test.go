@@ -0,0 +1,348 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	mock_storage "todo-api/mock/storage"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+// nopReadSeekCloser はテスト用の io.ReadSeekCloser
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func TestTaskAttachmentUseCase_GetTaskAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskAttachmentRepo := mock_repository.NewMockTaskAttachmentRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskAttachmentUseCase := usecase.NewTaskAttachmentUseCase(mockTaskRepo, mockTaskAttachmentRepo, mockCompanyRepo, mockCompanyUserRepo, mockStorage)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	attachments := []*model.TaskAttachment{{ID: 4, TaskID: taskId, UserID: userId, FileName: "a.png"}}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.TaskAttachment
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachments(gomock.Any(), taskId).Return(attachments, nil).Times(1)
+			},
+			expectedResult: attachments,
+			expectedError:  nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskAttachmentUseCase.GetTaskAttachments(context.Background(), companyId, taskId, userId)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskAttachmentUseCase_UploadTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskAttachmentRepo := mock_repository.NewMockTaskAttachmentRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskAttachmentUseCase := usecase.NewTaskAttachmentUseCase(mockTaskRepo, mockTaskAttachmentRepo, mockCompanyRepo, mockCompanyUserRepo, mockStorage)
+
+	ctx := context.Background()
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	company := &model.Company{ID: companyId, AttachmentQuotaBytes: 1000}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 100)...)
+	size := int64(len(png))
+
+	testCases := []struct {
+		name          string
+		fileName      string
+		content       []byte
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			fileName: "../dir/screenshot.png",
+			content:  png,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetAttachmentUsage(gomock.Any(), companyId).Return(int64(0), nil).Times(1)
+				mockStorage.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), size, "image/png").
+					DoAndReturn(func(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+						assert.True(t, strings.HasPrefix(key, "companies/1/tasks/2/"))
+						body, _ := io.ReadAll(r)
+						assert.Equal(t, png, body)
+						return nil
+					}).Times(1)
+				mockTaskAttachmentRepo.EXPECT().CreateTaskAttachment(gomock.Any(), gomock.Any(), company.AttachmentQuotaBytes).
+					DoAndReturn(func(_ context.Context, attachment *model.TaskAttachment, _ int64) (*model.TaskAttachment, error) {
+						assert.Equal(t, "screenshot.png", attachment.FileName)
+						assert.Equal(t, "image/png", attachment.ContentType)
+						assert.Equal(t, size, attachment.Size)
+						return attachment, nil
+					}).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Task not visible",
+			fileName: "screenshot.png",
+			content:  png,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+		{
+			name:     "Quota exceeded",
+			fileName: "screenshot.png",
+			content:  png,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetAttachmentUsage(gomock.Any(), companyId).Return(int64(950), nil).Times(1)
+			},
+			expectedError: myErrors.ErrQuotaExceeded,
+		},
+		{
+			name:     "Unsupported media type",
+			fileName: "screenshot.png",
+			content:  []byte("<html><body>not an image</body></html>"),
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetAttachmentUsage(gomock.Any(), companyId).Return(int64(0), nil).Times(1)
+			},
+			expectedError: myErrors.ErrUnsupportedMediaType,
+		},
+		{
+			name:     "Error in CreateTaskAttachment deletes stored file",
+			fileName: "screenshot.png",
+			content:  png,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetAttachmentUsage(gomock.Any(), companyId).Return(int64(0), nil).Times(1)
+				mockStorage.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), size, "image/png").Return(nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().CreateTaskAttachment(gomock.Any(), gomock.Any(), company.AttachmentQuotaBytes).
+					Return(nil, myErrors.ErrQuotaExceeded).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedError: myErrors.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskAttachmentUseCase.UploadTaskAttachment(ctx, companyId, taskId, userId, tc.fileName, int64(len(tc.content)), bytes.NewReader(tc.content))
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestTaskAttachmentUseCase_OpenTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskAttachmentRepo := mock_repository.NewMockTaskAttachmentRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskAttachmentUseCase := usecase.NewTaskAttachmentUseCase(mockTaskRepo, mockTaskAttachmentRepo, mockCompanyRepo, mockCompanyUserRepo, mockStorage)
+
+	ctx := context.Background()
+	companyId := uint(1)
+	taskId := uint(2)
+	attachmentId := uint(4)
+	userId := uint(3)
+	attachment := &model.TaskAttachment{ID: attachmentId, TaskID: taskId, UserID: userId, Size: 5, StorageKey: "key"}
+	file := nopReadSeekCloser{bytes.NewReader([]byte("hello"))}
+
+	testCases := []struct {
+		name               string
+		mockFunc           func()
+		expectedAttachment *model.TaskAttachment
+		expectedFile       io.ReadSeekCloser
+		expectedError      error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).Return(attachment, nil).Times(1)
+				mockStorage.EXPECT().Open(ctx, "key", int64(5)).Return(file, nil).Times(1)
+			},
+			expectedAttachment: attachment,
+			expectedFile:       file,
+			expectedError:      nil,
+		},
+		{
+			name: "Attachment not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedAttachment: nil,
+			expectedFile:       nil,
+			expectedError:      myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in Open",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).Return(attachment, nil).Times(1)
+				mockStorage.EXPECT().Open(ctx, "key", int64(5)).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedAttachment: nil,
+			expectedFile:       nil,
+			expectedError:      errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			resultAttachment, resultFile, err := taskAttachmentUseCase.OpenTaskAttachment(ctx, companyId, taskId, attachmentId, userId)
+
+			assert.Equal(t, tc.expectedAttachment, resultAttachment)
+			assert.Equal(t, tc.expectedFile, resultFile)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskAttachmentUseCase_DeleteTaskAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskAttachmentRepo := mock_repository.NewMockTaskAttachmentRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskAttachmentUseCase := usecase.NewTaskAttachmentUseCase(mockTaskRepo, mockTaskAttachmentRepo, mockCompanyRepo, mockCompanyUserRepo, mockStorage)
+
+	ctx := context.Background()
+	companyId := uint(1)
+	taskId := uint(2)
+	attachmentId := uint(4)
+	userId := uint(3)
+	otherUserId := uint(5)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success by uploader",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).
+					Return(&model.TaskAttachment{ID: attachmentId, TaskID: taskId, UserID: userId, StorageKey: "key"}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().DeleteTaskAttachment(gomock.Any(), taskId, attachmentId).Return(nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "key").Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success by manager even if storage deletion fails",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).
+					Return(&model.TaskAttachment{ID: attachmentId, TaskID: taskId, UserID: otherUserId, StorageKey: "key"}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_MANAGER}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().DeleteTaskAttachment(gomock.Any(), taskId, attachmentId).Return(nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "key").Return(errors.New("some error")).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Forbidden for member deleting others attachment",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskAttachmentRepo.EXPECT().GetTaskAttachment(gomock.Any(), taskId, attachmentId).
+					Return(&model.TaskAttachment{ID: attachmentId, TaskID: taskId, UserID: otherUserId, StorageKey: "key"}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_MEMBER}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrForbidden,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskAttachmentUseCase.DeleteTaskAttachment(ctx, companyId, taskId, attachmentId, userId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/model"
+	"todo-api/repository"
+)
+
+type TaskChecklistItemUseCase interface {
+	GetTaskChecklistItems(ctx context.Context, companyId, taskId, userId uint) ([]*model.TaskChecklistItem, error)
+	CreateTaskChecklistItem(ctx context.Context, companyId, taskId, userId uint, title string) (*model.TaskChecklistItem, error)
+	UpdateTaskChecklistItem(ctx context.Context, companyId, taskId, itemId, userId uint, title string, done bool) (*model.TaskChecklistItem, error)
+	DeleteTaskChecklistItem(ctx context.Context, companyId, taskId, itemId, userId uint) error
+}
+
+type taskChecklistItemUseCase struct {
+	taskRepository              repository.TaskRepository
+	taskChecklistItemRepository repository.TaskChecklistItemRepository
+}
+
+func NewTaskChecklistItemUseCase(
+	taskRepository repository.TaskRepository,
+	taskChecklistItemRepository repository.TaskChecklistItemRepository,
+) TaskChecklistItemUseCase {
+	return &taskChecklistItemUseCase{
+		taskRepository:              taskRepository,
+		taskChecklistItemRepository: taskChecklistItemRepository,
+	}
+}
+
+// GetTaskChecklistItems は、タスクのチェックリストを取得する。閲覧できないタスクの場合は ErrNotFound を返す。
+func (u *taskChecklistItemUseCase) GetTaskChecklistItems(ctx context.Context, companyId, taskId, userId uint) ([]*model.TaskChecklistItem, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.taskChecklistItemRepository.GetTaskChecklistItems(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CreateTaskChecklistItem は、チェックリストの末尾に未完了の項目を追加する。
+// 項目数が上限に達している場合は ErrQuotaExceeded を返す。
+func (u *taskChecklistItemUseCase) CreateTaskChecklistItem(ctx context.Context, companyId, taskId, userId uint, title string) (*model.TaskChecklistItem, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.taskChecklistItemRepository.CreateTaskChecklistItem(ctx, &model.TaskChecklistItem{
+		TaskID: taskId,
+		Title:  title,
+	}, model.MAX_TASK_CHECKLIST_ITEMS)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateTaskChecklistItem は、項目の内容と完了状態を更新する。
+func (u *taskChecklistItemUseCase) UpdateTaskChecklistItem(ctx context.Context, companyId, taskId, itemId, userId uint, title string, done bool) (*model.TaskChecklistItem, error) {
+	item, err := u.getTaskChecklistItem(ctx, companyId, taskId, itemId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Title = title
+	item.Done = done
+	item, err = u.taskChecklistItemRepository.UpdateTaskChecklistItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *taskChecklistItemUseCase) DeleteTaskChecklistItem(ctx context.Context, companyId, taskId, itemId, userId uint) error {
+	_, err := u.getTaskChecklistItem(ctx, companyId, taskId, itemId, userId)
+	if err != nil {
+		return err
+	}
+
+	return u.taskChecklistItemRepository.DeleteTaskChecklistItem(ctx, taskId, itemId)
+}
+
+// getTaskChecklistItem は、タスクの閲覧権限を確認した上でチェックリストの項目を取得する。
+func (u *taskChecklistItemUseCase) getTaskChecklistItem(ctx context.Context, companyId, taskId, itemId, userId uint) (*model.TaskChecklistItem, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return u.taskChecklistItemRepository.GetTaskChecklistItem(ctx, taskId, itemId)
+}
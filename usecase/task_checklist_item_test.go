@@ -0,0 +1,240 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestTaskChecklistItemUseCase_GetTaskChecklistItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskChecklistItemRepo := mock_repository.NewMockTaskChecklistItemRepository(ctrl)
+
+	taskChecklistItemUseCase := usecase.NewTaskChecklistItemUseCase(mockTaskRepo, mockTaskChecklistItemRepo)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	items := []*model.TaskChecklistItem{{ID: 4, TaskID: taskId, Title: "item", Position: 1}}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.TaskChecklistItem
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().GetTaskChecklistItems(gomock.Any(), taskId).Return(items, nil).Times(1)
+			},
+			expectedResult: items,
+			expectedError:  nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in GetTaskChecklistItems",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().GetTaskChecklistItems(gomock.Any(), taskId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskChecklistItemUseCase.GetTaskChecklistItems(context.Background(), companyId, taskId, userId)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskChecklistItemUseCase_CreateTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskChecklistItemRepo := mock_repository.NewMockTaskChecklistItemRepository(ctrl)
+
+	taskChecklistItemUseCase := usecase.NewTaskChecklistItemUseCase(mockTaskRepo, mockTaskChecklistItemRepo)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.TaskChecklistItem
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().CreateTaskChecklistItem(gomock.Any(), &model.TaskChecklistItem{TaskID: taskId, Title: "item"}, model.MAX_TASK_CHECKLIST_ITEMS).
+					Return(&model.TaskChecklistItem{ID: 4, TaskID: taskId, Title: "item", Position: 3}, nil).Times(1)
+			},
+			expectedResult: &model.TaskChecklistItem{ID: 4, TaskID: taskId, Title: "item", Position: 3},
+			expectedError:  nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Limit reached",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().CreateTaskChecklistItem(gomock.Any(), gomock.Any(), model.MAX_TASK_CHECKLIST_ITEMS).Return(nil, myErrors.ErrQuotaExceeded).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskChecklistItemUseCase.CreateTaskChecklistItem(context.Background(), companyId, taskId, userId, "item")
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskChecklistItemUseCase_UpdateTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskChecklistItemRepo := mock_repository.NewMockTaskChecklistItemRepository(ctrl)
+
+	taskChecklistItemUseCase := usecase.NewTaskChecklistItemUseCase(mockTaskRepo, mockTaskChecklistItemRepo)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	itemId := uint(4)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.TaskChecklistItem
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().GetTaskChecklistItem(gomock.Any(), taskId, itemId).
+					Return(&model.TaskChecklistItem{ID: itemId, TaskID: taskId, Title: "old", Position: 1}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().UpdateTaskChecklistItem(gomock.Any(), &model.TaskChecklistItem{ID: itemId, TaskID: taskId, Title: "new", Done: true, Position: 1}).
+					DoAndReturn(func(_ context.Context, item *model.TaskChecklistItem) (*model.TaskChecklistItem, error) {
+						return item, nil
+					}).Times(1)
+			},
+			expectedResult: &model.TaskChecklistItem{ID: itemId, TaskID: taskId, Title: "new", Done: true, Position: 1},
+			expectedError:  nil,
+		},
+		{
+			name: "Item not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().GetTaskChecklistItem(gomock.Any(), taskId, itemId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskChecklistItemUseCase.UpdateTaskChecklistItem(context.Background(), companyId, taskId, itemId, userId, "new", true)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskChecklistItemUseCase_DeleteTaskChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskChecklistItemRepo := mock_repository.NewMockTaskChecklistItemRepository(ctrl)
+
+	taskChecklistItemUseCase := usecase.NewTaskChecklistItemUseCase(mockTaskRepo, mockTaskChecklistItemRepo)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	itemId := uint(4)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().GetTaskChecklistItem(gomock.Any(), taskId, itemId).Return(&model.TaskChecklistItem{ID: itemId, TaskID: taskId}, nil).Times(1)
+				mockTaskChecklistItemRepo.EXPECT().DeleteTaskChecklistItem(gomock.Any(), taskId, itemId).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskChecklistItemUseCase.DeleteTaskChecklistItem(context.Background(), companyId, taskId, itemId, userId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
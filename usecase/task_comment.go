@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/repository"
+)
+
+type TaskCommentUseCase interface {
+	GetTaskComments(ctx context.Context, companyId, taskId, userId uint, limit, offset int) ([]*model.TaskComment, error)
+	CreateTaskComment(ctx context.Context, companyId, taskId, userId uint, body string) (*model.TaskComment, error)
+	UpdateTaskComment(ctx context.Context, companyId, taskId, commentId, userId uint, body string) (*model.TaskComment, error)
+	DeleteTaskComment(ctx context.Context, companyId, taskId, commentId, userId uint) error
+}
+
+type taskCommentUseCase struct {
+	taskRepository         repository.TaskRepository
+	taskCommentRepository  repository.TaskCommentRepository
+	companyUserRepository  repository.CompanyUserRepository
+	notificationRepository repository.NotificationRepository
+	broker                 realtime.Broker
+}
+
+func NewTaskCommentUseCase(
+	taskRepository repository.TaskRepository,
+	taskCommentRepository repository.TaskCommentRepository,
+	companyUserRepository repository.CompanyUserRepository,
+	notificationRepository repository.NotificationRepository,
+	broker realtime.Broker,
+) TaskCommentUseCase {
+	return &taskCommentUseCase{
+		taskRepository:         taskRepository,
+		taskCommentRepository:  taskCommentRepository,
+		companyUserRepository:  companyUserRepository,
+		notificationRepository: notificationRepository,
+		broker:                 broker,
+	}
+}
+
+// GetTaskComments は、タスクのコメント一覧を取得する。
+// タスクを閲覧できないユーザには、タスクが存在しない場合と同様に ErrNotFound を返す。
+func (u *taskCommentUseCase) GetTaskComments(ctx context.Context, companyId, taskId, userId uint, limit, offset int) ([]*model.TaskComment, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := u.taskCommentRepository.GetTaskComments(ctx, taskId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// CreateTaskComment は、タスクにコメントを投稿し、タスクの作成者と担当者に通知する。閲覧できないタスクにはコメントできない。
+func (u *taskCommentUseCase) CreateTaskComment(ctx context.Context, companyId, taskId, userId uint, body string) (*model.TaskComment, error) {
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := u.taskCommentRepository.CreateTaskComment(ctx, &model.TaskComment{
+		TaskID: taskId,
+		UserID: userId,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.publishCommentEvent(realtime.EVENT_TYPE_COMMENT_CREATED, task, comment)
+	createNotifications(ctx, u.notificationRepository, model.NewTaskNotifications(model.NOTIFICATION_TYPE_TASK_COMMENTED, task, &userId, model.TaskWatcherIds(task)))
+	return comment, nil
+}
+
+// UpdateTaskComment は、コメントを編集する。投稿者以外はモデレーターのみが編集できる。
+func (u *taskCommentUseCase) UpdateTaskComment(ctx context.Context, companyId, taskId, commentId, userId uint, body string) (*model.TaskComment, error) {
+	task, comment, err := u.getTaskComment(ctx, companyId, taskId, commentId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.ensureCanModifyTaskComment(ctx, companyId, userId, comment); err != nil {
+		return nil, err
+	}
+
+	comment.Body = body
+	comment, err = u.taskCommentRepository.UpdateTaskComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	u.publishCommentEvent(realtime.EVENT_TYPE_COMMENT_UPDATED, task, comment)
+	return comment, nil
+}
+
+// DeleteTaskComment は、コメントを削除する。投稿者以外はモデレーターのみが削除できる。
+func (u *taskCommentUseCase) DeleteTaskComment(ctx context.Context, companyId, taskId, commentId, userId uint) error {
+	task, comment, err := u.getTaskComment(ctx, companyId, taskId, commentId, userId)
+	if err != nil {
+		return err
+	}
+	if err := u.ensureCanModifyTaskComment(ctx, companyId, userId, comment); err != nil {
+		return err
+	}
+
+	if err := u.taskCommentRepository.DeleteTaskComment(ctx, taskId, commentId); err != nil {
+		return err
+	}
+	u.publishCommentEvent(realtime.EVENT_TYPE_COMMENT_DELETED, task, comment)
+	return nil
+}
+
+// getTaskComment は、タスクの閲覧権限を確認した上で、タスクとコメントを取得する。
+func (u *taskCommentUseCase) getTaskComment(ctx context.Context, companyId, taskId, commentId, userId uint) (*model.Task, *model.TaskComment, error) {
+	task, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := u.taskCommentRepository.GetTaskComment(ctx, taskId, commentId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, comment, nil
+}
+
+// publishCommentEvent は、コメントの変更を購読している接続に配信する。配信に失敗してもエラーは返さない。
+func (u *taskCommentUseCase) publishCommentEvent(eventType string, task *model.Task, comment *model.TaskComment) {
+	event, err := realtime.NewCommentEvent(eventType, task, comment)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error publishCommentEvent: %v", err))
+		return
+	}
+	u.broker.Publish(event)
+}
+
+// ensureCanModifyTaskComment は、他のユーザのコメントの場合、ロールでモデレートが許可されているかを確認する。
+func (u *taskCommentUseCase) ensureCanModifyTaskComment(ctx context.Context, companyId, userId uint, comment *model.TaskComment) error {
+	if comment.UserID == userId {
+		return nil
+	}
+
+	companyUser, err := u.companyUserRepository.GetCompanyUser(ctx, companyId, userId)
+	if err != nil {
+		return err
+	}
+	if !companyUser.Can(model.ACTION_MODERATE_COMMENT) {
+		return myErrors.ErrForbidden
+	}
+	return nil
+}
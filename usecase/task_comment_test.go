@@ -0,0 +1,358 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_realtime "todo-api/mock/realtime"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/realtime"
+	"todo-api/usecase"
+)
+
+func TestTaskCommentUseCase_GetTaskComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskCommentRepo := mock_repository.NewMockTaskCommentRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	taskCommentUseCase := usecase.NewTaskCommentUseCase(mockTaskRepo, mockTaskCommentRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	comments := []*model.TaskComment{{ID: 4, TaskID: taskId, UserID: userId, Body: "comment"}}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.TaskComment
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComments(gomock.Any(), taskId, 20, 0).Return(comments, nil).Times(1)
+			},
+			expectedResult: comments,
+			expectedError:  nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in GetTaskComments",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComments(gomock.Any(), taskId, 20, 0).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskCommentUseCase.GetTaskComments(context.Background(), companyId, taskId, userId, 20, 0)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskCommentUseCase_CreateTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskCommentRepo := mock_repository.NewMockTaskCommentRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	taskCommentUseCase := usecase.NewTaskCommentUseCase(mockTaskRepo, mockTaskCommentRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	body := "comment"
+
+	testCases := []struct {
+		name           string
+		canceled       bool
+		mockFunc       func()
+		expectedResult *model.TaskComment
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, CreateUserId: 5, AssigneeID: &userId, Title: "Task"}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().CreateTaskComment(gomock.Any(), &model.TaskComment{TaskID: taskId, UserID: userId, Body: body}).
+					Return(&model.TaskComment{ID: 4, TaskID: taskId, UserID: userId, Body: body}, nil).Times(1)
+				mockBroker.EXPECT().Publish(gomock.Any()).Do(func(event *realtime.Event) {
+					assert.Equal(t, realtime.EVENT_TYPE_COMMENT_CREATED, event.Type)
+					assert.Equal(t, companyId, event.CompanyID)
+					assert.JSONEq(t, `{"task_id":2,"comment":{"id":4,"user_id":3,"body":"comment"}}`, string(event.Data))
+				}).Times(1)
+				// コメントしたユーザ自身には通知しない
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{5}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), []*model.Notification{
+					{UserID: 5, CompanyID: companyId, TaskID: &taskId, ActorID: &userId, Type: model.NOTIFICATION_TYPE_TASK_COMMENTED, Title: "Task"},
+				}).Return(nil).Times(1)
+			},
+			expectedResult: &model.TaskComment{ID: 4, TaskID: taskId, UserID: userId, Body: body},
+			expectedError:  nil,
+		},
+		{
+			// 通知は操作の確定後に作成するため、リクエストが中断されても作成する
+			name:     "Success - request canceled",
+			canceled: true,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, CreateUserId: 5, Title: "Task"}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().CreateTaskComment(gomock.Any(), &model.TaskComment{TaskID: taskId, UserID: userId, Body: body}).
+					Return(&model.TaskComment{ID: 4, TaskID: taskId, UserID: userId, Body: body}, nil).Times(1)
+				mockBroker.EXPECT().Publish(gomock.Any()).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{5}).
+					DoAndReturn(func(ctx context.Context, _ []uint) ([]*model.NotificationPreference, error) {
+						assert.NoError(t, ctx.Err())
+						return []*model.NotificationPreference{}, nil
+					}).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Len(1)).
+					DoAndReturn(func(ctx context.Context, _ []*model.Notification) error {
+						assert.NoError(t, ctx.Err())
+						return nil
+					}).Times(1)
+			},
+			expectedResult: &model.TaskComment{ID: 4, TaskID: taskId, UserID: userId, Body: body},
+			expectedError:  nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in CreateTaskComment",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().CreateTaskComment(gomock.Any(), &model.TaskComment{TaskID: taskId, UserID: userId, Body: body}).
+					Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			ctx := context.Background()
+			if tc.canceled {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			}
+			result, err := taskCommentUseCase.CreateTaskComment(ctx, companyId, taskId, userId, body)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskCommentUseCase_UpdateTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskCommentRepo := mock_repository.NewMockTaskCommentRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	taskCommentUseCase := usecase.NewTaskCommentUseCase(mockTaskRepo, mockTaskCommentRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	commentId := uint(4)
+	userId := uint(3)
+	otherUserId := uint(5)
+	body := "edited"
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.TaskComment
+		expectedError  error
+	}{
+		{
+			name: "Success - author",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, TaskID: taskId, UserID: userId, Body: "original"}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().UpdateTaskComment(gomock.Any(), &model.TaskComment{ID: commentId, TaskID: taskId, UserID: userId, Body: body}).
+					Return(&model.TaskComment{ID: commentId, TaskID: taskId, UserID: userId, Body: body}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_COMMENT_UPDATED)).Times(1)
+			},
+			expectedResult: &model.TaskComment{ID: commentId, TaskID: taskId, UserID: userId, Body: body},
+			expectedError:  nil,
+		},
+		{
+			name: "Success - moderator",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, TaskID: taskId, UserID: otherUserId, Body: "original"}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_MANAGER}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().UpdateTaskComment(gomock.Any(), &model.TaskComment{ID: commentId, TaskID: taskId, UserID: otherUserId, Body: body}).
+					Return(&model.TaskComment{ID: commentId, TaskID: taskId, UserID: otherUserId, Body: body}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_COMMENT_UPDATED)).Times(1)
+			},
+			expectedResult: &model.TaskComment{ID: commentId, TaskID: taskId, UserID: otherUserId, Body: body},
+			expectedError:  nil,
+		},
+		{
+			name: "Forbidden - others' comment by member",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, TaskID: taskId, UserID: otherUserId}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_MEMBER}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrForbidden,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Comment not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskCommentUseCase.UpdateTaskComment(context.Background(), companyId, taskId, commentId, userId, body)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskCommentUseCase_DeleteTaskComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskCommentRepo := mock_repository.NewMockTaskCommentRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+
+	taskCommentUseCase := usecase.NewTaskCommentUseCase(mockTaskRepo, mockTaskCommentRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	commentId := uint(4)
+	userId := uint(3)
+	otherUserId := uint(5)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success - author",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, UserID: userId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().DeleteTaskComment(gomock.Any(), taskId, commentId).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_COMMENT_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - moderator",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, UserID: otherUserId}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_OWNER}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().DeleteTaskComment(gomock.Any(), taskId, commentId).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_COMMENT_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Forbidden - others' comment by member",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, UserID: otherUserId}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{Role: model.COMPANY_ROLE_MEMBER}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrForbidden,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in DeleteTaskComment",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().GetTaskComment(gomock.Any(), taskId, commentId).Return(&model.TaskComment{ID: commentId, UserID: userId}, nil).Times(1)
+				mockTaskCommentRepo.EXPECT().DeleteTaskComment(gomock.Any(), taskId, commentId).Return(errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskCommentUseCase.DeleteTaskComment(context.Background(), companyId, taskId, commentId, userId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
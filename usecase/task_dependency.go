@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/repository"
+)
+
+type TaskDependencyUseCase interface {
+	GetTaskDependencyGraph(ctx context.Context, companyId, userId uint) (*model.TaskDependencyGraph, error)
+	CreateTaskDependency(ctx context.Context, companyId, taskId, blockedByTaskId, userId uint) (*model.TaskDependency, error)
+	DeleteTaskDependency(ctx context.Context, companyId, taskId, blockedByTaskId, userId uint) error
+}
+
+type taskDependencyUseCase struct {
+	taskRepository           repository.TaskRepository
+	taskDependencyRepository repository.TaskDependencyRepository
+}
+
+func NewTaskDependencyUseCase(
+	taskRepository repository.TaskRepository,
+	taskDependencyRepository repository.TaskDependencyRepository,
+) TaskDependencyUseCase {
+	return &taskDependencyUseCase{
+		taskRepository:           taskRepository,
+		taskDependencyRepository: taskDependencyRepository,
+	}
+}
+
+// GetTaskDependencyGraph は、ユーザが閲覧できるタスク間の依存関係のグラフを取得する。
+func (u *taskDependencyUseCase) GetTaskDependencyGraph(ctx context.Context, companyId, userId uint) (*model.TaskDependencyGraph, error) {
+	graph, err := u.taskDependencyRepository.GetTaskDependencyGraph(ctx, companyId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// CreateTaskDependency は、taskId のタスクが blockedByTaskId のタスクの完了を待つように依存関係を追加する。
+// ブロッカーに自身や閲覧できないタスクを指定した場合は ErrInvalidArgument、
+// 既に同じ依存関係がある場合は ErrConflict、依存関係が循環する場合は ErrDependencyCycle を返す。
+func (u *taskDependencyUseCase) CreateTaskDependency(ctx context.Context, companyId, taskId, blockedByTaskId, userId uint) (*model.TaskDependency, error) {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if taskId == blockedByTaskId {
+		return nil, myErrors.ErrInvalidArgument
+	}
+	_, err = u.taskRepository.GetTask(ctx, companyId, blockedByTaskId, userId)
+	if errors.Is(err, myErrors.ErrNotFound) {
+		return nil, myErrors.ErrInvalidArgument
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dependency, err := u.taskDependencyRepository.CreateTaskDependency(ctx, &model.TaskDependency{
+		TaskID:          taskId,
+		BlockedByTaskID: blockedByTaskId,
+		CompanyID:       companyId,
+	}, func(reachableTaskIds []uint) error {
+		// ブロッカーが既に taskId のタスクの完了を待っている場合は、追加すると循環する
+		if slices.Contains(reachableTaskIds, taskId) {
+			return myErrors.ErrDependencyCycle
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dependency, nil
+}
+
+func (u *taskDependencyUseCase) DeleteTaskDependency(ctx context.Context, companyId, taskId, blockedByTaskId, userId uint) error {
+	_, err := u.taskRepository.GetTask(ctx, companyId, taskId, userId)
+	if err != nil {
+		return err
+	}
+
+	return u.taskDependencyRepository.DeleteTaskDependency(ctx, taskId, blockedByTaskId)
+}
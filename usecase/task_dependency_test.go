@@ -0,0 +1,229 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/usecase"
+)
+
+func TestTaskDependencyUseCase_GetTaskDependencyGraph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+
+	taskDependencyUseCase := usecase.NewTaskDependencyUseCase(mockTaskRepo, mockTaskDependencyRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+	graph := &model.TaskDependencyGraph{
+		Tasks:        []*model.Task{{ID: 3}, {ID: 4}},
+		Dependencies: []*model.TaskDependency{{TaskID: 3, BlockedByTaskID: 4, CompanyID: companyId}},
+	}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.TaskDependencyGraph
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskDependencyRepo.EXPECT().GetTaskDependencyGraph(gomock.Any(), companyId, userId).Return(graph, nil).Times(1)
+			},
+			expectedResult: graph,
+			expectedError:  nil,
+		},
+		{
+			name: "Error in GetTaskDependencyGraph",
+			mockFunc: func() {
+				mockTaskDependencyRepo.EXPECT().GetTaskDependencyGraph(gomock.Any(), companyId, userId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskDependencyUseCase.GetTaskDependencyGraph(context.Background(), companyId, userId)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskDependencyUseCase_CreateTaskDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+
+	taskDependencyUseCase := usecase.NewTaskDependencyUseCase(mockTaskRepo, mockTaskDependencyRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	blockedByTaskId := uint(4)
+
+	testCases := []struct {
+		name            string
+		blockedByTaskId uint
+		mockFunc        func()
+		expectedResult  *model.TaskDependency
+		expectedError   error
+	}{
+		{
+			name:            "Success",
+			blockedByTaskId: blockedByTaskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, blockedByTaskId, userId).Return(&model.Task{ID: blockedByTaskId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CreateTaskDependency(gomock.Any(), &model.TaskDependency{TaskID: taskId, BlockedByTaskID: blockedByTaskId, CompanyID: companyId}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, dependency *model.TaskDependency, validate func([]uint) error) (*model.TaskDependency, error) {
+						if err := validate([]uint{5, 6}); err != nil {
+							return nil, err
+						}
+						return dependency, nil
+					}).Times(1)
+			},
+			expectedResult: &model.TaskDependency{TaskID: taskId, BlockedByTaskID: blockedByTaskId, CompanyID: companyId},
+			expectedError:  nil,
+		},
+		{
+			name:            "Task not visible",
+			blockedByTaskId: blockedByTaskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name:            "Blocked by itself",
+			blockedByTaskId: taskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument,
+		},
+		{
+			name:            "Blocker not visible",
+			blockedByTaskId: blockedByTaskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, blockedByTaskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument,
+		},
+		{
+			name:            "Already exists",
+			blockedByTaskId: blockedByTaskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, blockedByTaskId, userId).Return(&model.Task{ID: blockedByTaskId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CreateTaskDependency(gomock.Any(), &model.TaskDependency{TaskID: taskId, BlockedByTaskID: blockedByTaskId, CompanyID: companyId}, gomock.Any()).
+					Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name:            "Cycle",
+			blockedByTaskId: blockedByTaskId,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, blockedByTaskId, userId).Return(&model.Task{ID: blockedByTaskId}, nil).Times(1)
+				// ブロッカーから依存関係を辿って taskId のタスクに到達できる
+				mockTaskDependencyRepo.EXPECT().CreateTaskDependency(gomock.Any(), &model.TaskDependency{TaskID: taskId, BlockedByTaskID: blockedByTaskId, CompanyID: companyId}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, dependency *model.TaskDependency, validate func([]uint) error) (*model.TaskDependency, error) {
+						return nil, validate([]uint{5, taskId})
+					}).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDependencyCycle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskDependencyUseCase.CreateTaskDependency(context.Background(), companyId, taskId, tc.blockedByTaskId, userId)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskDependencyUseCase_DeleteTaskDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+
+	taskDependencyUseCase := usecase.NewTaskDependencyUseCase(mockTaskRepo, mockTaskDependencyRepo)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	blockedByTaskId := uint(4)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().DeleteTaskDependency(gomock.Any(), taskId, blockedByTaskId).Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Task not visible",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+		{
+			name: "Dependency not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().DeleteTaskDependency(gomock.Any(), taskId, blockedByTaskId).Return(myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskDependencyUseCase.DeleteTaskDependency(context.Background(), companyId, taskId, blockedByTaskId, userId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/notifier"
+	"todo-api/repository"
+)
+
+const (
+	// リマインダーを送るタスクを一度に取得する件数
+	TASK_REMINDER_BATCH_SIZE = 100
+)
+
+type TaskReminderUseCase interface {
+	SendTaskReminders(ctx context.Context, now time.Time, windows []time.Duration) (int, error)
+}
+
+type taskReminderUseCase struct {
+	taskReminderRepository repository.TaskReminderRepository
+	companyRepository      repository.CompanyRepository
+	userRepository         repository.UserRepository
+	notificationRepository repository.NotificationRepository
+	notifier               notifier.Notifier
+}
+
+func NewTaskReminderUseCase(
+	taskReminderRepository repository.TaskReminderRepository,
+	companyRepository repository.CompanyRepository,
+	userRepository repository.UserRepository,
+	notificationRepository repository.NotificationRepository,
+	notifier notifier.Notifier,
+) TaskReminderUseCase {
+	return &taskReminderUseCase{
+		taskReminderRepository: taskReminderRepository,
+		companyRepository:      companyRepository,
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		notifier:               notifier,
+	}
+}
+
+// taskReminderRun は、1回の実行の間に取得した会社のタイムゾーンとユーザを保持する。
+type taskReminderRun struct {
+	locations map[uint]*time.Location
+	users     map[uint]*model.User
+}
+
+// SendTaskReminders は、期限の windows 前を過ぎたタスクと、期限を過ぎたタスクについて、担当者と作成者にアプリ内の通知とメールを送り、通知したタスクの件数を返す。
+// 複数の windows を過ぎている場合は、最も期限に近いものだけを送る。同じ期限に対して同じリマインダーは再送しない。
+// 送信前にリマインダーを記録するため、同時に実行された場合や再起動した場合も重複して送らない。全ての通知に失敗した場合は、次の実行で再送する。
+// 通知を受け取らない設定にしているユーザには送らない。
+func (u *taskReminderUseCase) SendTaskReminders(ctx context.Context, now time.Time, windows []time.Duration) (int, error) {
+	windows = slices.Clone(windows)
+	slices.Sort(windows)
+	// 期限に近いものから送ることで、より前のリマインダーを送らないようにする
+	windows = append([]time.Duration{model.TASK_REMINDER_OVERDUE}, windows...)
+
+	run := &taskReminderRun{
+		locations: map[uint]*time.Location{},
+		users:     map[uint]*model.User{},
+	}
+	count := 0
+	for _, window := range slices.Compact(windows) {
+		for {
+			tasks, err := u.taskReminderRepository.GetTasksToRemind(ctx, now, window, TASK_REMINDER_BATCH_SIZE)
+			if err != nil {
+				return count, err
+			}
+
+			skipped := false
+			for _, task := range tasks {
+				sent, err := u.sendTaskReminder(ctx, run, task, window)
+				if err != nil {
+					return count, err
+				}
+				if sent {
+					count++
+				} else {
+					skipped = true
+				}
+			}
+			// 送信に失敗したタスクは再び取得されるため、残りは次の実行に回す
+			if len(tasks) < TASK_REMINDER_BATCH_SIZE || skipped {
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+// sendTaskReminder は、リマインダーを記録してから担当者と作成者に送り、送ったかどうかを返す。
+// 既に記録されている場合や、送る相手がいない場合は送らない。全ての通知に失敗した場合は、記録を削除する。
+func (u *taskReminderUseCase) sendTaskReminder(ctx context.Context, run *taskReminderRun, task *model.Task, window time.Duration) (bool, error) {
+	loc, err := u.getLocation(ctx, run, task.CompanyID)
+	if err != nil {
+		return false, err
+	}
+
+	reminder := model.NewTaskReminder(task, window)
+	notificationType := model.NOTIFICATION_TYPE_TASK_DUE_SOON
+	if reminder.IsOverdue() {
+		notificationType = model.NOTIFICATION_TYPE_TASK_OVERDUE
+	}
+	notifications, err := filterEnabledNotifications(ctx, u.notificationRepository, model.NewTaskNotifications(notificationType, task, nil, model.TaskWatcherIds(task)))
+	if err != nil {
+		return false, err
+	}
+	if task.Assignee != nil {
+		run.users[task.Assignee.ID] = task.Assignee
+	}
+	recipients, err := u.getRecipients(ctx, run, notifications)
+	if err != nil {
+		return false, err
+	}
+
+	err = u.taskReminderRepository.CreateTaskReminder(ctx, reminder)
+	if errors.Is(err, myErrors.ErrConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// 全員が通知を受け取らない設定の場合も、再び取得されないよう記録は残す
+	if len(notifications) == 0 {
+		return false, nil
+	}
+
+	// アプリ内の通知とメールのいずれかを送れた場合は、送ったものとする
+	sent := true
+	if err := u.notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		slog.Info(fmt.Sprintf("error SendTaskReminders: failed to create notifications of task %d: %v", task.ID, err))
+		sent = false
+	}
+	message := newTaskReminderMessage(task, reminder, loc)
+	for _, recipient := range recipients {
+		if err := u.notifier.Notify(ctx, recipient, message); err != nil {
+			slog.Info(fmt.Sprintf("error SendTaskReminders: failed to notify user %d of task %d: %v", recipient.ID, task.ID, err))
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		if err := u.taskReminderRepository.DeleteTaskReminder(ctx, reminder); err != nil {
+			return false, err
+		}
+	}
+	return sent, nil
+}
+
+// getLocation は、会社のタイムゾーンを取得する。
+func (u *taskReminderUseCase) getLocation(ctx context.Context, run *taskReminderRun, companyId uint) (*time.Location, error) {
+	if loc, ok := run.locations[companyId]; ok {
+		return loc, nil
+	}
+	company, err := u.companyRepository.GetCompany(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	run.locations[companyId] = company.Location()
+	return run.locations[companyId], nil
+}
+
+// getRecipients は、通知の宛先のユーザを取得する。
+func (u *taskReminderUseCase) getRecipients(ctx context.Context, run *taskReminderRun, notifications []*model.Notification) ([]*model.User, error) {
+	recipients := []*model.User{}
+	for _, notification := range notifications {
+		user, ok := run.users[notification.UserID]
+		if !ok {
+			var err error
+			user, err = u.userRepository.GetUser(ctx, notification.UserID)
+			if err != nil {
+				return nil, err
+			}
+			run.users[notification.UserID] = user
+		}
+		recipients = append(recipients, user)
+	}
+	return recipients, nil
+}
+
+// newTaskReminderMessage は、期限を会社のタイムゾーンで表したリマインダーの内容を作成する。
+func newTaskReminderMessage(task *model.Task, reminder *model.TaskReminder, loc *time.Location) *notifier.Message {
+	dueDate := reminder.DueDate.In(loc).Format("2006-01-02 15:04 MST")
+	if reminder.IsOverdue() {
+		return &notifier.Message{
+			Subject: fmt.Sprintf("Task %q is overdue", task.Title),
+			Body:    fmt.Sprintf("Task %q was due at %s and is not done yet.\n", task.Title, dueDate),
+		}
+	}
+	return &notifier.Message{
+		Subject: fmt.Sprintf("Task %q is due soon", task.Title),
+		Body:    fmt.Sprintf("Task %q is due at %s.\n", task.Title, dueDate),
+	}
+}
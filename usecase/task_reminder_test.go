@@ -0,0 +1,160 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_notifier "todo-api/mock/notifier"
+	mock_repository "todo-api/mock/repository"
+	"todo-api/model"
+	"todo-api/notifier"
+	"todo-api/usecase"
+)
+
+func TestTaskReminderUseCase_SendTaskReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskReminderRepo := mock_repository.NewMockTaskReminderRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockNotifier := mock_notifier.NewMockNotifier(ctrl)
+
+	taskReminderUseCase := usecase.NewTaskReminderUseCase(mockTaskReminderRepo, mockCompanyRepo, mockUserRepo, mockNotificationRepo, mockNotifier)
+
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	windows := []time.Duration{24 * time.Hour, time.Hour}
+	dueDate := time.Date(2024, time.January, 4, 9, 0, 0, 0, time.UTC)
+	creator := &model.User{ID: 2, Email: "creator@example.com"}
+	assignee := &model.User{ID: 3, Email: "assignee@example.com"}
+	company := &model.Company{ID: 1, TimeZone: "Asia/Tokyo"}
+	task := &model.Task{ID: 4, CompanyID: 1, CreateUserId: 2, Title: "Task", DueDate: &dueDate, AssigneeID: &assignee.ID, Assignee: assignee, Status: "pending"}
+	reminder := &model.TaskReminder{TaskID: 4, DueDate: dueDate, WindowSeconds: 0}
+	notifications := []*model.Notification{
+		{UserID: 2, CompanyID: 1, TaskID: &task.ID, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Title: "Task"},
+		{UserID: 3, CompanyID: 1, TaskID: &task.ID, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Title: "Task"},
+	}
+	message := &notifier.Message{
+		Subject: `Task "Task" is overdue`,
+		Body:    "Task \"Task\" was due at 2024-01-04 18:00 JST and is not done yet.\n",
+	}
+
+	// 期限を過ぎたタスク、期限に近いものから順に確認する
+	expectRemainingWindowsEmpty := func() {
+		mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, time.Hour, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{}, nil).Times(1)
+		mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, 24*time.Hour, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{}, nil).Times(1)
+	}
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{task}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(company, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{2, 3}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), uint(2)).Return(creator, nil).Times(1)
+				mockTaskReminderRepo.EXPECT().CreateTaskReminder(gomock.Any(), reminder).Return(nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), notifications).Return(nil).Times(1)
+				mockNotifier.EXPECT().Notify(ctx, assignee, message).Return(nil).Times(1)
+				mockNotifier.EXPECT().Notify(ctx, creator, message).Return(nil).Times(1)
+				expectRemainingWindowsEmpty()
+			},
+			expectedCount: 1,
+			expectedError: nil,
+		},
+		{
+			name: "Already reminded",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{task}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(company, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{2, 3}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), uint(2)).Return(creator, nil).Times(1)
+				mockTaskReminderRepo.EXPECT().CreateTaskReminder(gomock.Any(), reminder).Return(myErrors.ErrConflict).Times(1)
+				expectRemainingWindowsEmpty()
+			},
+			expectedCount: 0,
+			expectedError: nil,
+		},
+		{
+			name: "Partially failed",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{task}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(company, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{2, 3}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), uint(2)).Return(creator, nil).Times(1)
+				mockTaskReminderRepo.EXPECT().CreateTaskReminder(gomock.Any(), reminder).Return(nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), notifications).Return(nil).Times(1)
+				mockNotifier.EXPECT().Notify(ctx, assignee, message).Return(errors.New("some error")).Times(1)
+				mockNotifier.EXPECT().Notify(ctx, creator, message).Return(nil).Times(1)
+				expectRemainingWindowsEmpty()
+			},
+			expectedCount: 1,
+			expectedError: nil,
+		},
+		{
+			name: "All failed",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{task}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(company, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{2, 3}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), uint(2)).Return(creator, nil).Times(1)
+				mockTaskReminderRepo.EXPECT().CreateTaskReminder(gomock.Any(), reminder).Return(nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), notifications).Return(myErrors.ErrDb).Times(1)
+				mockNotifier.EXPECT().Notify(ctx, gomock.Any(), message).Return(errors.New("some error")).Times(2)
+				// 次の実行で再送できるよう、記録を削除する
+				mockTaskReminderRepo.EXPECT().DeleteTaskReminder(gomock.Any(), reminder).Return(nil).Times(1)
+				expectRemainingWindowsEmpty()
+			},
+			expectedCount: 0,
+			expectedError: nil,
+		},
+		{
+			name: "Notifications disabled",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return([]*model.Task{task}, nil).Times(1)
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(company, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{2, 3}).Return([]*model.NotificationPreference{
+					{UserID: 2, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Enabled: false},
+					{UserID: 3, Type: model.NOTIFICATION_TYPE_TASK_OVERDUE, Enabled: false},
+				}, nil).Times(1)
+				// 再び取得されないよう、記録は残す
+				mockTaskReminderRepo.EXPECT().CreateTaskReminder(gomock.Any(), reminder).Return(nil).Times(1)
+				expectRemainingWindowsEmpty()
+			},
+			expectedCount: 0,
+			expectedError: nil,
+		},
+		{
+			name: "Error in GetTasksToRemind",
+			mockFunc: func() {
+				mockTaskReminderRepo.EXPECT().GetTasksToRemind(gomock.Any(), now, model.TASK_REMINDER_OVERDUE, usecase.TASK_REMINDER_BATCH_SIZE).Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedCount: 0,
+			expectedError: myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			count, err := taskReminderUseCase.SendTaskReminders(ctx, now, windows)
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
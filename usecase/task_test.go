@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -8,8 +9,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	myErrors "todo-api/errors"
+	mock_realtime "todo-api/mock/realtime"
 	mock_repository "todo-api/mock/repository"
+	mock_storage "todo-api/mock/storage"
 	"todo-api/model"
+	"todo-api/realtime"
 	"todo-api/usecase"
 )
 
@@ -18,44 +23,108 @@ func TestTaskUseCase_GetTasksByCompanyId(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	companyId := uint(1)
 	userId := uint(2)
-	limit := 10
-	offset := 0
+	status := "pending"
+	filter := &model.TaskFilter{Status: &status, Sort: []model.TaskSort{{Field: "title"}}}
+	totalCount := int64(3)
 
 	testCases := []struct {
 		name           string
-		mockFunc       func()
-		expectedResult []*model.Task
+		pagination     *model.TaskPagination
+		mockFunc       func(pagination *model.TaskPagination)
+		expectedResult *model.TaskPage
 		expectedError  error
 	}{
 		{
-			name: "Success",
-			mockFunc: func() {
-				mockCompanyRepo.EXPECT().GetCompany(companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().GetTasksByCompanyId(companyId, userId, limit, offset).Return([]*model.Task{}, nil).Times(1)
+			name:       "Success - first page with next page",
+			pagination: &model.TaskPagination{Limit: 2, WithTotalCount: true},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasksByCompanyId(gomock.Any(), companyId, userId, filter, pagination).
+					Return([]*model.Task{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}, {ID: 3, Title: "c"}}, nil).
+					Times(1)
+				mockTaskRepo.EXPECT().CountTasksByCompanyId(gomock.Any(), companyId, userId, filter).Return(totalCount, nil).Times(1)
 			},
-			expectedResult: []*model.Task{},
-			expectedError:  nil,
+			expectedResult: &model.TaskPage{
+				Tasks:      []*model.Task{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}},
+				NextCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, filter, &model.Task{ID: 2, Title: "b"}),
+				TotalCount: &totalCount,
+			},
+			expectedError: nil,
 		},
 		{
-			name: "Company not found",
-			mockFunc: func() {
-				mockCompanyRepo.EXPECT().GetCompany(companyId).Return(nil, errors.New("company not found")).Times(1)
+			name: "Success - prev cursor",
+			pagination: &model.TaskPagination{
+				Limit:  2,
+				Cursor: model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, filter, &model.Task{ID: 4, Title: "d"}),
+			},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasksByCompanyId(gomock.Any(), companyId, userId, filter, pagination).
+					Return([]*model.Task{{ID: 3, Title: "c"}, {ID: 2, Title: "b"}, {ID: 1, Title: "a"}}, nil).
+					Times(1)
+			},
+			expectedResult: &model.TaskPage{
+				Tasks:      []*model.Task{{ID: 2, Title: "b"}, {ID: 3, Title: "c"}},
+				NextCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_NEXT, filter, &model.Task{ID: 3, Title: "c"}),
+				PrevCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, filter, &model.Task{ID: 2, Title: "b"}),
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Success - total count disabled for company",
+			pagination: &model.TaskPagination{Limit: 2, Offset: 2, WithTotalCount: true},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId, DisableTaskTotalCount: true}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasksByCompanyId(gomock.Any(), companyId, userId, filter, pagination).
+					Return([]*model.Task{{ID: 3, Title: "c"}}, nil).
+					Times(1)
+			},
+			expectedResult: &model.TaskPage{
+				Tasks:      []*model.Task{{ID: 3, Title: "c"}},
+				PrevCursor: model.NewTaskCursor(model.CURSOR_DIRECTION_PREV, filter, &model.Task{ID: 3, Title: "c"}),
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Company not found",
+			pagination: &model.TaskPagination{Limit: 2},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(nil, errors.New("company not found")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("company not found"),
 		},
 		{
-			name: "Error in GetTasksByCompanyId",
-			mockFunc: func() {
-				mockCompanyRepo.EXPECT().GetCompany(companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().GetTasksByCompanyId(companyId, userId, limit, offset).Return(nil, errors.New("some error")).Times(1)
+			name:       "Error in GetTasksByCompanyId",
+			pagination: &model.TaskPagination{Limit: 2},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasksByCompanyId(gomock.Any(), companyId, userId, filter, pagination).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+		{
+			name:       "Error in CountTasksByCompanyId",
+			pagination: &model.TaskPagination{Limit: 2, WithTotalCount: true},
+			mockFunc: func(pagination *model.TaskPagination) {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasksByCompanyId(gomock.Any(), companyId, userId, filter, pagination).Return([]*model.Task{}, nil).Times(1)
+				mockTaskRepo.EXPECT().CountTasksByCompanyId(gomock.Any(), companyId, userId, filter).Return(int64(0), errors.New("some error")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("some error"),
@@ -64,11 +133,11 @@ func TestTaskUseCase_GetTasksByCompanyId(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.mockFunc()
+			tc.mockFunc(tc.pagination)
 
-			tasks, err := taskUseCase.GetTasksByCompanyId(companyId, userId, limit, offset)
+			page, err := taskUseCase.GetTasksByCompanyId(context.Background(), companyId, userId, filter, tc.pagination)
 
-			assert.Equal(t, tc.expectedResult, tasks)
+			assert.Equal(t, tc.expectedResult, page)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
@@ -79,32 +148,50 @@ func TestTaskUseCase_GetTasks(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
-	limit := 10
-	offset := 0
+	filter := &model.TaskFilter{Overdue: true}
+	pagination := &model.TaskPagination{Limit: 10, WithTotalCount: true}
+	totalCount := int64(0)
 
 	testCases := []struct {
 		name           string
 		mockFunc       func()
-		expectedResult []*model.Task
+		expectedResult *model.TaskPage
 		expectedError  error
 	}{
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTasks(limit, offset).Return([]*model.Task{}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTasks(gomock.Any(), filter, pagination).Return([]*model.Task{}, nil).Times(1)
+				mockTaskRepo.EXPECT().CountTasks(gomock.Any(), filter).Return(totalCount, nil).Times(1)
 			},
-			expectedResult: []*model.Task{},
+			expectedResult: &model.TaskPage{Tasks: []*model.Task{}, TotalCount: &totalCount},
 			expectedError:  nil,
 		},
 		{
 			name: "Error in GetTasks",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTasks(limit, offset).Return(nil, errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTasks(gomock.Any(), filter, pagination).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+		{
+			name: "Error in CountTasks",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTasks(gomock.Any(), filter, pagination).Return([]*model.Task{}, nil).Times(1)
+				mockTaskRepo.EXPECT().CountTasks(gomock.Any(), filter).Return(int64(0), errors.New("some error")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("some error"),
@@ -115,9 +202,9 @@ func TestTaskUseCase_GetTasks(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			tasks, err := taskUseCase.GetTasks(limit, offset)
+			page, err := taskUseCase.GetTasks(context.Background(), filter, pagination)
 
-			assert.Equal(t, tc.expectedResult, tasks)
+			assert.Equal(t, tc.expectedResult, page)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
@@ -128,10 +215,17 @@ func TestTaskUseCase_GetTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	companyId := uint(1)
 	userId := uint(2)
@@ -146,26 +240,37 @@ func TestTaskUseCase_GetTask(t *testing.T) {
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().GetBlockers(gomock.Any(), companyId, taskId, userId).Return([]*model.Task{{ID: 4}}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().GetDependents(gomock.Any(), companyId, taskId, userId).Return([]*model.Task{{ID: 5}}, nil).Times(1)
 			},
-			expectedResult: &model.Task{ID: taskId, CompanyID: companyId},
+			expectedResult: &model.Task{ID: taskId, CompanyID: companyId, Blockers: []*model.Task{{ID: 4}}, Dependents: []*model.Task{{ID: 5}}},
 			expectedError:  nil,
 		},
 		{
 			name: "Task not found",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(nil, errors.New("task not found")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, errors.New("task not found")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("task not found"),
 		},
+		{
+			name: "Error in GetBlockers",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().GetBlockers(gomock.Any(), companyId, taskId, userId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			task, err := taskUseCase.GetTask(companyId, taskId, userId)
+			task, err := taskUseCase.GetTask(context.Background(), companyId, taskId, userId)
 
 			assert.Equal(t, tc.expectedResult, task)
 			assert.Equal(t, tc.expectedError, err)
@@ -173,15 +278,91 @@ func TestTaskUseCase_GetTask(t *testing.T) {
 	}
 }
 
+func TestTaskUseCase_SearchTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	query := "議事録"
+	limit := 10
+	offset := 0
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Task
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskSearchRepo.EXPECT().SearchTasks(gomock.Any(), companyId, userId, query, limit, offset).Return([]*model.Task{{ID: 3}}, nil).Times(1)
+			},
+			expectedResult: []*model.Task{{ID: 3}},
+			expectedError:  nil,
+		},
+		{
+			name: "Company not found",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in SearchTasks",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskSearchRepo.EXPECT().SearchTasks(gomock.Any(), companyId, userId, query, limit, offset).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			tasks, err := taskUseCase.SearchTasks(context.Background(), companyId, userId, query, limit, offset)
+
+			assert.Equal(t, tc.expectedResult, tasks)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
 func TestTaskUseCase_CreateTaskByAdmin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	task := &model.Task{
 		ID:          1,
@@ -203,7 +384,10 @@ func TestTaskUseCase_CreateTaskByAdmin(t *testing.T) {
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().CreateTask(task).Return(task, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), task, gomock.Any(), gomock.Any()).Return(task, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			expectedResult: task,
 			expectedError:  nil,
@@ -212,7 +396,7 @@ func TestTaskUseCase_CreateTaskByAdmin(t *testing.T) {
 		{
 			name: "Error in CreateTask",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().CreateTask(task).Return(nil, errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), task, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("some error"),
@@ -223,7 +407,7 @@ func TestTaskUseCase_CreateTaskByAdmin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			createdTask, err := taskUseCase.CreateTaskByAdmin(task)
+			createdTask, err := taskUseCase.CreateTaskByAdmin(context.Background(), task)
 
 			assert.Equal(t, tc.expectedResult, createdTask)
 			assert.Equal(t, tc.expectedError, err)
@@ -236,10 +420,17 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	task := &model.Task{
 		ID:          1,
@@ -261,8 +452,19 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
-				mockTaskRepo.EXPECT().CreateTask(task).Return(task, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				// 作成後に採番された ID で Webhook イベントを組み立てる
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), task, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+						webhookEvent, err := newWebhookEvent(task)
+						assert.NoError(t, err)
+						assert.Equal(t, model.WEBHOOK_EVENT_TYPE_TASK_CREATED, webhookEvent.Type)
+						assert.Contains(t, webhookEvent.Payload, `"id":1`)
+						return task, nil
+					}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			expectedResult: task,
 			expectedError:  nil,
@@ -270,7 +472,7 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 		{
 			name: "Assignee not found",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(nil, errors.New("assignee not found")).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(nil, errors.New("assignee not found")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("assignee not found"),
@@ -278,8 +480,8 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 		{
 			name: "Error in CreateTask",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
-				mockTaskRepo.EXPECT().CreateTask(task).Return(nil, errors.New("some error")).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), task, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("some error"),
@@ -290,7 +492,7 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			createdTask, err := taskUseCase.CreateTask(task)
+			createdTask, err := taskUseCase.CreateTask(context.Background(), task)
 
 			assert.Equal(t, tc.expectedResult, createdTask)
 			assert.Equal(t, tc.expectedError, err)
@@ -298,77 +500,58 @@ func TestTaskUseCase_CreateTask(t *testing.T) {
 	}
 }
 
-func TestTaskUseCase_UpdateTaskByAdmin(t *testing.T) {
+func TestTaskUseCase_CreateTaskWithLabels(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
-	companyId := uint(1)
-	taskId := uint(2)
-	task := &model.Task{
-		CompanyID:   companyId,
-		Title:       "Updated Task Title",
-		Description: "Updated Task Description",
-		DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-		AssigneeID:  &[]uint{1}[0],
-		Visibility:  "public",
-		Status:      "completed",
+	labels := []*model.Label{
+		{ID: 1, CompanyID: 1, Name: "bug", Color: "#ff0000"},
+		{ID: 2, CompanyID: 1, Name: "urgent", Color: "#ffa500"},
 	}
 
 	testCases := []struct {
 		name           string
+		labelIds       []uint
 		mockFunc       func()
-		expectedResult *model.Task
+		expectedLabels []*model.Label
 		expectedError  error
 	}{
 		{
-			name: "Success",
+			name:     "Success",
+			labelIds: []uint{1, 2, 1},
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().UpdateTask(taskId, &model.Task{
-					ID:          taskId,
-					CompanyID:   companyId,
-					Title:       "Updated Task Title",
-					Description: "Updated Task Description",
-					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-					AssigneeID:  &[]uint{1}[0],
-					Visibility:  "public",
-					Status:      "completed",
-				}).Return(task, nil).Times(1)
+				mockLabelRepo.EXPECT().GetLabelsByIds(gomock.Any(), uint(1), []uint{1, 2}).Return(labels, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+						assert.Equal(t, model.TaskFieldChange{From: []string{}, To: []string{"bug", "urgent"}}, event.Changes["labels"])
+						return task, nil
+					}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
 			},
-			expectedResult: task,
+			expectedLabels: labels,
 			expectedError:  nil,
 		},
 		{
-			name: "Task not found",
-			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(nil, errors.New("task not found")).Times(1)
-			},
-			expectedResult: nil,
-			expectedError:  errors.New("task not found"),
-		},
-		{
-			name: "Error in UpdateTask",
+			name:     "Label of other company",
+			labelIds: []uint{1, 3},
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().UpdateTask(taskId, &model.Task{
-					ID:          taskId,
-					CompanyID:   companyId,
-					Title:       "Updated Task Title",
-					Description: "Updated Task Description",
-					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-					AssigneeID:  &[]uint{1}[0],
-					Visibility:  "public",
-					Status:      "completed",
-				}).Return(nil, errors.New("some error")).Times(1)
+				mockLabelRepo.EXPECT().GetLabelsByIds(gomock.Any(), uint(1), []uint{1, 3}).Return(labels[:1], nil).Times(1)
 			},
-			expectedResult: nil,
-			expectedError:  errors.New("some error"),
+			expectedLabels: nil,
+			expectedError:  myErrors.ErrInvalidArgument,
 		},
 	}
 
@@ -376,97 +559,82 @@ func TestTaskUseCase_UpdateTaskByAdmin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			updatedTask, err := taskUseCase.UpdateTaskByAdmin(taskId, task)
+			task := &model.Task{CompanyID: 1, Title: "Task Title", Visibility: "company", Status: "pending"}
+			for _, id := range tc.labelIds {
+				task.Labels = append(task.Labels, &model.Label{ID: id})
+			}
+			createdTask, err := taskUseCase.CreateTask(context.Background(), task)
 
-			assert.Equal(t, tc.expectedResult, updatedTask)
 			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedLabels, createdTask.Labels)
+			}
 		})
 	}
 }
 
-func TestTaskUseCase_UpdateTask(t *testing.T) {
+func TestTaskUseCase_CreateTaskWithParent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	companyId := uint(1)
-	userId := uint(2)
-	taskId := uint(3)
-	task := &model.Task{
-		CompanyID:   companyId,
-		Title:       "Updated Task Title",
-		Description: "Updated Task Description",
-		DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-		AssigneeID:  &[]uint{1}[0],
-		Visibility:  "public",
-		Status:      "completed",
-	}
+	userId := uint(3)
+	parentId := uint(10)
 
 	testCases := []struct {
-		name           string
-		mockFunc       func()
-		expectedResult *model.Task
-		expectedError  error
+		name          string
+		mockFunc      func()
+		expectedError error
 	}{
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().UpdateTask(taskId, &model.Task{
-					ID:          taskId,
-					CompanyID:   companyId,
-					Title:       "Updated Task Title",
-					Description: "Updated Task Description",
-					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-					AssigneeID:  &[]uint{1}[0],
-					Visibility:  "public",
-					Status:      "completed",
-				}).Return(task, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return([]uint{parentId, 5}, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+						assert.Equal(t, model.TaskFieldChange{From: (*uint)(nil), To: &parentId}, event.Changes["parent_task_id"])
+						return task, nil
+					}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
 			},
-			expectedResult: task,
-			expectedError:  nil,
+			expectedError: nil,
 		},
 		{
-			name: "Assignee not found",
+			name: "Parent not visible",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(nil, errors.New("assignee not found")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
-			expectedResult: nil,
-			expectedError:  errors.New("assignee not found"),
+			expectedError: myErrors.ErrInvalidParentTask,
 		},
 		{
-			name: "Task not found",
+			name: "Depth exceeded",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(nil, errors.New("task not found")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return([]uint{parentId, 9, 8, 7, 6}, nil).Times(1)
 			},
-			expectedResult: nil,
-			expectedError:  errors.New("task not found"),
+			expectedError: myErrors.ErrTaskDepthExceeded,
 		},
 		{
-			name: "Error in UpdateTask",
+			name: "Error in GetTaskAncestorIds",
 			mockFunc: func() {
-				mockCompanyUserRepo.EXPECT().GetCompanyUser(task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
-				mockTaskRepo.EXPECT().UpdateTask(taskId, &model.Task{
-					ID:          taskId,
-					CompanyID:   companyId,
-					Title:       "Updated Task Title",
-					Description: "Updated Task Description",
-					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
-					AssigneeID:  &[]uint{1}[0],
-					Visibility:  "public",
-					Status:      "completed",
-				}).Return(nil, errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return(nil, myErrors.ErrDb).Times(1)
 			},
-			expectedResult: nil,
-			expectedError:  errors.New("some error"),
+			expectedError: myErrors.ErrDb,
 		},
 	}
 
@@ -474,25 +642,39 @@ func TestTaskUseCase_UpdateTask(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			updatedTask, err := taskUseCase.UpdateTask(companyId, taskId, userId, task)
+			task := &model.Task{CompanyID: companyId, ParentTaskID: &parentId, CreateUserId: userId, Title: "Subtask", Visibility: "company", Status: "pending"}
+			createdTask, err := taskUseCase.CreateTask(context.Background(), task)
 
-			assert.Equal(t, tc.expectedResult, updatedTask)
 			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, &parentId, createdTask.ParentTaskID)
+			}
 		})
 	}
 }
 
-func TestTaskUseCase_DeleteTaskByAdmin(t *testing.T) {
+func TestTaskUseCase_PatchTaskParent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
-	taskId := uint(1)
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	parentId := uint(10)
+	task := &model.Task{ID: taskId, CompanyID: companyId, Version: 1}
 
 	testCases := []struct {
 		name          string
@@ -502,30 +684,34 @@ func TestTaskUseCase_DeleteTaskByAdmin(t *testing.T) {
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(&model.Task{
-					ID: taskId,
-				}, nil).Times(1)
-				mockTaskRepo.EXPECT().DeleteTaskById(taskId).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(task, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return([]uint{parentId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskSubtreeHeight(gomock.Any(), taskId).Return(2, nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, ParentTaskID: &parentId, Version: 2}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
 			},
 			expectedError: nil,
 		},
 		{
-			name: "Error in GetTaskById",
+			name: "Cycle - parent is a descendant",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(nil,
-					errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(task, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return([]uint{parentId, taskId}, nil).Times(1)
 			},
-			expectedError: errors.New("some error"),
+			expectedError: myErrors.ErrInvalidParentTask,
 		},
 		{
-			name: "Error in DeleteTaskById",
+			name: "Depth exceeded with subtree",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTaskById(taskId).Return(&model.Task{
-					ID: taskId,
-				}, nil).Times(1)
-				mockTaskRepo.EXPECT().DeleteTaskById(taskId).Return(errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(task, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, parentId, userId).Return(&model.Task{ID: parentId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskAncestorIds(gomock.Any(), parentId).Return([]uint{parentId, 9}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskSubtreeHeight(gomock.Any(), taskId).Return(4, nil).Times(1)
 			},
-			expectedError: errors.New("some error"),
+			expectedError: myErrors.ErrTaskDepthExceeded,
 		},
 	}
 
@@ -533,59 +719,70 @@ func TestTaskUseCase_DeleteTaskByAdmin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			err := taskUseCase.DeleteTaskByAdmin(taskId)
+			patch := &model.TaskPatch{ParentTaskID: model.Optional[*uint]{Set: true, Value: &parentId}}
+			_, err := taskUseCase.PatchTask(context.Background(), companyId, taskId, userId, patch, nil, false)
 
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
 }
 
-func TestTaskUseCase_DeleteTask(t *testing.T) {
+func TestTaskUseCase_GetSubtasks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
 	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
 	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
 
-	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockCompanyRepo, mockCompanyUserRepo)
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
 
 	companyId := uint(1)
 	taskId := uint(2)
 	userId := uint(3)
+	subtasks := []*model.Task{
+		{ID: 4, CompanyID: companyId, ParentTaskID: &taskId, Status: "done"},
+		{ID: 5, CompanyID: companyId, ParentTaskID: &taskId, Status: "pending"},
+	}
 
 	testCases := []struct {
-		name          string
-		mockFunc      func()
-		expectedError error
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Task
+		expectedError  error
 	}{
 		{
 			name: "Success",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(&model.Task{
-					ID: taskId,
-				}, nil).Times(1)
-				mockTaskRepo.EXPECT().DeleteTask(companyId, taskId).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetSubtasks(gomock.Any(), companyId, taskId, userId, 10, 0).Return(subtasks, nil).Times(1)
 			},
-			expectedError: nil,
+			expectedResult: subtasks,
+			expectedError:  nil,
 		},
 		{
-			name: "Error in GetTask",
+			name: "Parent not found",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(nil,
-					errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
 			},
-			expectedError: errors.New("some error"),
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
 		},
 		{
-			name: "Error in DeleteTask",
+			name: "Error in GetSubtasks",
 			mockFunc: func() {
-				mockTaskRepo.EXPECT().GetTask(companyId, taskId, userId).Return(&model.Task{
-					ID: taskId,
-				}, nil).Times(1)
-				mockTaskRepo.EXPECT().DeleteTask(companyId, taskId).Return(errors.New("some error")).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetSubtasks(gomock.Any(), companyId, taskId, userId, 10, 0).Return(nil, myErrors.ErrDb).Times(1)
 			},
-			expectedError: errors.New("some error"),
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
 		},
 	}
 
@@ -593,9 +790,1653 @@ func TestTaskUseCase_DeleteTask(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			err := taskUseCase.DeleteTask(companyId, taskId, userId)
+			result, err := taskUseCase.GetSubtasks(context.Background(), companyId, taskId, userId, 10, 0)
 
+			assert.Equal(t, tc.expectedResult, result)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
 }
+
+func TestTaskUseCase_UpdateTaskByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	actorId := uint(9)
+	task := &model.Task{
+		CompanyID:   companyId,
+		Title:       "Updated Task Title",
+		Description: "Updated Task Description",
+		DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+		AssigneeID:  &[]uint{1}[0],
+		Visibility:  "public",
+		Status:      "completed",
+	}
+
+	testCases := []struct {
+		name           string
+		version        *uint
+		mockFunc       func()
+		expectedResult *model.Task
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+				}, &model.TaskEvent{
+					TaskID:    taskId,
+					CompanyID: companyId,
+					ActorID:   actorId,
+					Action:    model.TASK_EVENT_ACTION_UPDATE,
+					ByAdmin:   true,
+					Changes: map[string]model.TaskFieldChange{
+						"title":       {From: "", To: "Updated Task Title"},
+						"description": {From: "", To: "Updated Task Description"},
+						"due_date":    {From: (*time.Time)(nil), To: task.DueDate},
+						"assignee_id": {From: (*uint)(nil), To: task.AssigneeID},
+						"visibility":  {From: "", To: "public"},
+						"status":      {From: "", To: "completed"},
+					},
+				}, gomock.Any()).Return(task, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1, 0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: task,
+			expectedError:  nil,
+		},
+		{
+			name:    "Success with matching version",
+			version: &[]uint{3}[0],
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+					Version:     3,
+				}, gomock.Any(), gomock.Any()).Return(task, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1, 0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: task,
+			expectedError:  nil,
+		},
+		{
+			name:    "Precondition failed",
+			version: &[]uint{2}[0],
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrPreconditionFailed,
+		},
+		{
+			name: "Task not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(nil, errors.New("task not found")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("task not found"),
+		},
+		{
+			name: "Error in UpdateTask",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+				}, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			updatedTask, err := taskUseCase.UpdateTaskByAdmin(context.Background(), taskId, actorId, task, tc.version, false)
+
+			assert.Equal(t, tc.expectedResult, updatedTask)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_UpdateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	task := &model.Task{
+		CompanyID:   companyId,
+		Title:       "Updated Task Title",
+		Description: "Updated Task Description",
+		DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+		AssigneeID:  &[]uint{1}[0],
+		Visibility:  "public",
+		Status:      "completed",
+	}
+
+	testCases := []struct {
+		name           string
+		version        *uint
+		mockFunc       func()
+		expectedResult *model.Task
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+				}, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id uint, _ *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error) {
+						assert.Len(t, webhookEvents, 1)
+						assert.Equal(t, model.WEBHOOK_EVENT_TYPE_TASK_UPDATED, webhookEvents[0].Type)
+						return task, nil
+					}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1, 0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: task,
+			expectedError:  nil,
+		},
+		{
+			// 親タスクが指定されない場合は、サブタスクの親タスクを維持する
+			name: "Success - subtask without parent",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, ParentTaskID: &[]uint{5}[0]}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:           taskId,
+					CompanyID:    companyId,
+					ParentTaskID: &[]uint{5}[0],
+					Title:        "Updated Task Title",
+					Description:  "Updated Task Description",
+					DueDate:      &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:   &[]uint{1}[0],
+					Visibility:   "public",
+					Status:       "completed",
+				}, gomock.Any(), gomock.Any()).Return(task, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1, 0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: task,
+			expectedError:  nil,
+		},
+		{
+			name:    "Success with matching version",
+			version: &[]uint{3}[0],
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+					Version:     3,
+				}, gomock.Any(), gomock.Any()).Return(task, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{1, 0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: task,
+			expectedError:  nil,
+		},
+		{
+			name:    "Precondition failed",
+			version: &[]uint{2}[0],
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrPreconditionFailed,
+		},
+		{
+			name:    "Concurrent update",
+			version: &[]uint{3}[0],
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+					Version:     3,
+				}, gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrPreconditionFailed,
+		},
+		{
+			// If-Match がない場合は、前提条件ではなく競合として扱う
+			name: "Concurrent update without If-Match",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, Version: 3}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+					Version:     3,
+				}, gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name: "Assignee not found",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(nil, errors.New("assignee not found")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("assignee not found"),
+		},
+		{
+			name: "Task not found",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, errors.New("task not found")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("task not found"),
+		},
+		{
+			name: "Error in UpdateTask",
+			mockFunc: func() {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), task.CompanyID, *task.AssigneeID).Return(&model.CompanyUser{ID: *task.AssigneeID}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, &model.Task{
+					ID:          taskId,
+					CompanyID:   companyId,
+					Title:       "Updated Task Title",
+					Description: "Updated Task Description",
+					DueDate:     &[]time.Time{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)}[0],
+					AssigneeID:  &[]uint{1}[0],
+					Visibility:  "public",
+					Status:      "completed",
+				}, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			updatedTask, err := taskUseCase.UpdateTask(context.Background(), companyId, taskId, userId, task, tc.version, false)
+
+			assert.Equal(t, tc.expectedResult, updatedTask)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_UpdateTaskToDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	task := &model.Task{CompanyID: companyId, Title: "Task", Visibility: "company", Status: "done"}
+	updatedTask := &model.Task{ID: taskId, CompanyID: companyId, Title: "Task", Visibility: "company", Status: "done"}
+
+	testCases := []struct {
+		name           string
+		oldStatus      string
+		force          bool
+		mockFunc       func()
+		expectedResult *model.Task
+		expectedError  error
+	}{
+		{
+			name:      "Success - no unfinished blockers",
+			oldStatus: "in_progress",
+			mockFunc: func() {
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).Return(updatedTask, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: updatedTask,
+			expectedError:  nil,
+		},
+		{
+			name:      "Blocked by unfinished tasks",
+			oldStatus: "in_progress",
+			mockFunc: func() {
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(1), nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrTaskBlocked,
+		},
+		{
+			name:      "Success - forced",
+			oldStatus: "in_progress",
+			force:     true,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).Return(updatedTask, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: updatedTask,
+			expectedError:  nil,
+		},
+		{
+			name:      "Success - already done",
+			oldStatus: "done",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).Return(updatedTask, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+			},
+			expectedResult: updatedTask,
+			expectedError:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CompanyID: companyId, Status: tc.oldStatus}, nil).Times(1)
+			tc.mockFunc()
+
+			result, err := taskUseCase.UpdateTask(context.Background(), companyId, taskId, userId, task, nil, tc.force)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_CreateTaskWithRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	// 2099-01-05 は月曜日。東京では 18:00 になる
+	dueDate := time.Date(2099, time.January, 5, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: companyId, TimeZone: "Asia/Tokyo"}
+
+	testCases := []struct {
+		name              string
+		dueDate           *time.Time
+		rule              string
+		mockFunc          func()
+		expectedRule      string
+		expectedNextDueAt time.Time
+		expectedError     error
+	}{
+		{
+			name:    "Success",
+			dueDate: &dueDate,
+			rule:    "RRULE:BYDAY=MO,WE;FREQ=WEEKLY",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+					return task, nil
+				}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
+			},
+			expectedRule:      "FREQ=WEEKLY;BYDAY=MO,WE",
+			expectedNextDueAt: time.Date(2099, time.January, 7, 9, 0, 0, 0, time.UTC),
+			expectedError:     nil,
+		},
+		{
+			name:          "Invalid rule",
+			dueDate:       &dueDate,
+			rule:          "FREQ=YEARLY",
+			mockFunc:      func() {},
+			expectedError: myErrors.ErrInvalidRecurrence,
+		},
+		{
+			name:    "Without due date",
+			dueDate: nil,
+			rule:    "FREQ=DAILY",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(company, nil).Times(1)
+			},
+			expectedError: myErrors.ErrInvalidRecurrence,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			task := &model.Task{CompanyID: companyId, Title: "Task", DueDate: tc.dueDate, Visibility: "company", Status: "pending", RecurrenceRule: &tc.rule}
+			createdTask, err := taskUseCase.CreateTask(context.Background(), task)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedRule, *createdTask.RecurrenceRule)
+				assert.Equal(t, 1, createdTask.RecurrenceIndex)
+				assert.True(t, dueDate.Equal(*createdTask.RecurrenceStartAt))
+				assert.True(t, tc.expectedNextDueAt.Equal(*createdTask.RecurrenceNextDueAt))
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_UpdateRecurringTaskToDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	rule := "FREQ=DAILY;COUNT=3"
+	startAt := time.Date(2099, time.January, 5, 9, 0, 0, 0, time.UTC)
+	nextDueAt := time.Date(2099, time.January, 6, 9, 0, 0, 0, time.UTC)
+	oldTask := &model.Task{
+		ID: taskId, CompanyID: companyId, CreateUserId: userId, Title: "Task", DueDate: &startAt, Visibility: "company", Status: "pending",
+		RecurrenceRule: &rule, RecurrenceStartAt: &startAt, RecurrenceIndex: 1, RecurrenceNextDueAt: &nextDueAt,
+	}
+	task := &model.Task{Title: "Task", DueDate: &startAt, Visibility: "company", Status: "done", RecurrenceRule: &rule}
+	refetchedTask := &model.Task{ID: taskId, CompanyID: companyId, Status: "done", RecurrenceRule: &rule, Version: 3}
+
+	testCases := []struct {
+		name            string
+		mockFunc        func()
+		expectedVersion uint
+		expectedError   error
+	}{
+		{
+			name: "Success - creates next occurrence",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTaskOccurrence(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, next *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+					assert.True(t, nextDueAt.Equal(*next.DueDate))
+					assert.Equal(t, "pending", next.Status)
+					assert.Equal(t, 2, next.RecurrenceIndex)
+					assert.True(t, time.Date(2099, time.January, 7, 9, 0, 0, 0, time.UTC).Equal(*next.RecurrenceNextDueAt))
+					assert.Equal(t, model.TASK_EVENT_ACTION_CREATE, event.Action)
+					return next, nil
+				}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
+				// 次のタスクの作成でバージョンが上がるため、取得し直す
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(refetchedTask, nil).Times(1)
+			},
+			expectedVersion: 3,
+			expectedError:   nil,
+		},
+		{
+			name: "Success - already created by scheduler",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTaskOccurrence(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrConflict).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+			},
+			expectedVersion: 2,
+			expectedError:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(oldTask, nil).Times(1)
+			mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+			mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, task *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error) {
+				// ルールが変わらない場合は、繰り返しの状態を維持する
+				assert.Equal(t, 1, task.RecurrenceIndex)
+				assert.Equal(t, &nextDueAt, task.RecurrenceNextDueAt)
+				updated := *task
+				updated.Version = 2
+				return &updated, nil
+			}).Times(1)
+			tc.mockFunc()
+
+			result, err := taskUseCase.UpdateTask(context.Background(), companyId, taskId, userId, task, nil, false)
+
+			assert.Equal(t, "done", result.Status)
+			assert.Equal(t, tc.expectedVersion, result.Version)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_UpdateTaskRecurrenceRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	rule := "FREQ=DAILY;COUNT=3"
+	startAt := time.Date(2099, time.January, 5, 9, 0, 0, 0, time.UTC)
+	nextDueAt := time.Date(2099, time.January, 6, 9, 0, 0, 0, time.UTC)
+	oldTask := &model.Task{
+		ID: taskId, CompanyID: companyId, CreateUserId: userId, Title: "Task", DueDate: &startAt, Visibility: "company", Status: "pending",
+		RecurrenceRule: &rule, RecurrenceStartAt: &startAt, RecurrenceIndex: 1, RecurrenceNextDueAt: &nextDueAt,
+	}
+
+	testCases := []struct {
+		name              string
+		rule              *string
+		expectedRule      *string
+		expectedNextDueAt *time.Time
+	}{
+		{
+			// 指定されない場合は、現在のルールを維持する
+			name:              "Without rule",
+			rule:              nil,
+			expectedRule:      &rule,
+			expectedNextDueAt: &nextDueAt,
+		},
+		{
+			name:              "Empty rule",
+			rule:              &[]string{""}[0],
+			expectedRule:      nil,
+			expectedNextDueAt: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(oldTask, nil).Times(1)
+			mockTaskRepo.EXPECT().UpdateTask(gomock.Any(), taskId, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, task *model.Task, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) (*model.Task, error) {
+				assert.Equal(t, tc.expectedRule, task.RecurrenceRule)
+				assert.Equal(t, tc.expectedNextDueAt, task.RecurrenceNextDueAt)
+				return task, nil
+			}).Times(1)
+			mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+
+			task := &model.Task{Title: "Task", DueDate: &startAt, Visibility: "company", Status: "pending", RecurrenceRule: tc.rule}
+			_, err := taskUseCase.UpdateTask(context.Background(), companyId, taskId, userId, task, nil, false)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTaskUseCase_PatchTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	taskId := uint(3)
+	assigneeId := uint(4)
+
+	testCases := []struct {
+		name           string
+		patch          *model.TaskPatch
+		version        *uint
+		mockFunc       func(patch *model.TaskPatch)
+		expectedResult *model.Task
+		expectedError  error
+	}{
+		{
+			name:  "Success",
+			patch: &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}, DueDate: model.Optional[*time.Time]{Set: true}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Status: "pending"}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(0), patch, &model.TaskEvent{
+					TaskID:  taskId,
+					ActorID: userId,
+					Action:  model.TASK_EVENT_ACTION_UPDATE,
+					Changes: map[string]model.TaskFieldChange{
+						"status": {From: "pending", To: "done"},
+					},
+				}, gomock.Any()).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, Status: "done"}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{0}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId, Status: "done"},
+			expectedError:  nil,
+		},
+		{
+			name:  "Success - assignee",
+			patch: &model.TaskPatch{AssigneeID: model.Optional[*uint]{Set: true, Value: &assigneeId}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, assigneeId).Return(&model.CompanyUser{}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(0), patch, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, AssigneeID: &assigneeId}, nil).Times(1)
+				mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uint{assigneeId}).Return([]*model.NotificationPreference{}, nil).Times(1)
+				mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), []*model.Notification{
+					{UserID: assigneeId, TaskID: &taskId, ActorID: &userId, Type: model.NOTIFICATION_TYPE_TASK_ASSIGNED},
+				}).Return(nil).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId, AssigneeID: &assigneeId},
+			expectedError:  nil,
+		},
+		{
+			// 非公開のタスクは Webhook で送信しない
+			name:  "Success - private task",
+			patch: &model.TaskPatch{Title: model.Optional[string]{Set: true, Value: "Secret"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Visibility: "private"}, nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(0), patch, gomock.Any(), []*model.WebhookEvent{}).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, Title: "Secret", Visibility: "private"}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId, Title: "Secret", Visibility: "private"},
+			expectedError:  nil,
+		},
+		{
+			name:  "Blocked by unfinished tasks",
+			patch: &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Status: "in_progress"}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(2), nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrTaskBlocked,
+		},
+		{
+			name:  "Success - empty patch",
+			patch: &model.TaskPatch{},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId},
+			expectedError:  nil,
+		},
+		{
+			name:    "Precondition failed",
+			patch:   &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			version: &[]uint{1}[0],
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Version: 2}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrPreconditionFailed,
+		},
+		{
+			name:    "Concurrent update",
+			patch:   &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			version: &[]uint{2}[0],
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Version: 2}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(2), patch, gomock.Any(), gomock.Any()).Return(myErrors.ErrConflict).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrPreconditionFailed,
+		},
+		{
+			// If-Match がない場合は、前提条件ではなく競合として扱う
+			name:  "Concurrent update without If-Match",
+			patch: &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, Version: 2}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(2), patch, gomock.Any(), gomock.Any()).Return(myErrors.ErrConflict).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name:  "Assignee is not a member",
+			patch: &model.TaskPatch{AssigneeID: model.Optional[*uint]{Set: true, Value: &assigneeId}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, assigneeId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name:  "Task not found",
+			patch: &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name:  "Success - made private",
+			patch: &model.TaskPatch{Visibility: model.Optional[string]{Set: true, Value: "private"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId, CreateUserId: userId, Visibility: "company"}, nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(0), patch, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, CreateUserId: userId, Visibility: "private"}, nil).Times(1)
+				// 変更は作成者のみに、非公開になったことは作成者以外のみに配信する
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_UPDATED)).Do(func(event *realtime.Event) {
+					assert.True(t, event.VisibleTo(userId))
+					assert.False(t, event.VisibleTo(userId+1))
+				}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_HIDDEN)).Do(func(event *realtime.Event) {
+					assert.False(t, event.VisibleTo(userId))
+					assert.True(t, event.VisibleTo(userId+1))
+					assert.JSONEq(t, `{"task":{"id":3}}`, string(event.Data))
+				}).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId, CreateUserId: userId, Visibility: "private"},
+			expectedError:  nil,
+		},
+		{
+			name:  "Error in PatchTask",
+			patch: &model.TaskPatch{Status: model.Optional[string]{Set: true, Value: "done"}},
+			mockFunc: func(patch *model.TaskPatch) {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskDependencyRepo.EXPECT().CountUnfinishedBlockers(gomock.Any(), taskId).Return(int64(0), nil).Times(1)
+				mockTaskRepo.EXPECT().PatchTask(gomock.Any(), taskId, uint(0), patch, gomock.Any(), gomock.Any()).Return(myErrors.ErrDb).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc(tc.patch)
+
+			task, err := taskUseCase.PatchTask(context.Background(), companyId, taskId, userId, tc.patch, tc.version, false)
+
+			assert.Equal(t, tc.expectedResult, task)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_DeleteTaskByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	taskId := uint(1)
+	actorId := uint(9)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{
+					ID: taskId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTaskById(gomock.Any(), taskId, &model.TaskEvent{
+					TaskID:  taskId,
+					ActorID: actorId,
+					Action:  model.TASK_EVENT_ACTION_DELETE,
+					ByAdmin: true,
+					Changes: map[string]model.TaskFieldChange{},
+				}, gomock.Any()).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error in GetTaskById",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(nil,
+					errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+		{
+			name: "Conflict - has subtasks",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{
+					ID:       taskId,
+					Progress: model.TaskProgress{SubtasksTotal: 1},
+				}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrConflict,
+		},
+		{
+			name: "Error in DeleteTaskById",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{
+					ID: taskId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTaskById(gomock.Any(), taskId, gomock.Any(), gomock.Any()).Return(errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskUseCase.DeleteTaskByAdmin(context.Background(), taskId, actorId, model.SUBTASK_DELETE_POLICY_BLOCK)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_DeleteTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	otherUserId := uint(4)
+
+	testCases := []struct {
+		name          string
+		subtaskPolicy string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTask(gomock.Any(), companyId, taskId, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - others' task by manager",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: otherUserId,
+				}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{
+					Role: model.COMPANY_ROLE_MANAGER,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTask(gomock.Any(), companyId, taskId, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Forbidden - others' task by member",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: otherUserId,
+				}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{
+					Role: model.COMPANY_ROLE_MEMBER,
+				}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrForbidden,
+		},
+		{
+			name:          "Conflict - has subtasks with block policy",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_BLOCK,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksDone: 1, SubtasksTotal: 2},
+				}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrConflict,
+		},
+		{
+			name:          "Success - has subtasks with cascade policy",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_CASCADE,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksDone: 1, SubtasksTotal: 2},
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskDescendants(gomock.Any(), taskId).Return([]*model.Task{
+					{ID: 5, CreateUserId: userId, Visibility: "private"},
+					{ID: 6, CreateUserId: userId, Visibility: "company"},
+				}, nil).Times(1)
+				// 一緒に削除されるサブタスクのうち、非公開でないものも Webhook で送信する
+				mockTaskRepo.EXPECT().DeleteTask(gomock.Any(), companyId, taskId, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, companyId uint, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+						assert.Len(t, webhookEvents, 2)
+						for _, webhookEvent := range webhookEvents {
+							assert.Equal(t, model.WEBHOOK_EVENT_TYPE_TASK_DELETED, webhookEvent.Type)
+						}
+						assert.Contains(t, webhookEvents[1].Payload, `"id":6`)
+						return nil
+					}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Success - others' subtasks with cascade policy by manager",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_CASCADE,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksTotal: 1},
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskDescendants(gomock.Any(), taskId).Return([]*model.Task{
+					{ID: 5, CreateUserId: otherUserId, Visibility: "company"},
+				}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{
+					Role: model.COMPANY_ROLE_MANAGER,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTask(gomock.Any(), companyId, taskId, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_DELETED)).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Forbidden - others' subtasks with cascade policy by member",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_CASCADE,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksTotal: 2},
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskDescendants(gomock.Any(), taskId).Return([]*model.Task{
+					{ID: 5, CreateUserId: userId, Visibility: "company"},
+					{ID: 6, CreateUserId: otherUserId, Visibility: "company"},
+				}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{
+					Role: model.COMPANY_ROLE_MEMBER,
+				}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrForbidden,
+		},
+		{
+			name:          "Conflict - others' private subtasks with cascade policy",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_CASCADE,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksTotal: 2},
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskDescendants(gomock.Any(), taskId).Return([]*model.Task{
+					{ID: 5, CreateUserId: otherUserId, Visibility: "company"},
+					{ID: 6, CreateUserId: otherUserId, Visibility: "private"},
+				}, nil).Times(1)
+			},
+			expectedError: myErrors.ErrConflict.WithMessage("subtasks include private tasks of other users"),
+		},
+		{
+			name:          "Error in GetTaskDescendants",
+			subtaskPolicy: model.SUBTASK_DELETE_POLICY_CASCADE,
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+					Progress:     model.TaskProgress{SubtasksTotal: 1},
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskDescendants(gomock.Any(), taskId).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+		{
+			name: "Error in GetTask",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil,
+					errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+		{
+			name: "Error in DeleteTask",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().DeleteTask(gomock.Any(), companyId, taskId, gomock.Any(), gomock.Any()).Return(errors.New("some error")).Times(1)
+			},
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskUseCase.DeleteTask(context.Background(), companyId, taskId, userId, tc.subtaskPolicy)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_GetTrashedTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	userId := uint(2)
+	limit := 10
+	offset := 0
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.Task
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTrashedTasksByCompanyId(gomock.Any(), companyId, userId, limit, offset).Return([]*model.Task{{ID: 3}}, nil).Times(1)
+			},
+			expectedResult: []*model.Task{{ID: 3}},
+			expectedError:  nil,
+		},
+		{
+			name: "Company not found",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in GetTrashedTasksByCompanyId",
+			mockFunc: func() {
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), companyId).Return(&model.Company{ID: companyId}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTrashedTasksByCompanyId(gomock.Any(), companyId, userId, limit, offset).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			tasks, err := taskUseCase.GetTrashedTasks(context.Background(), companyId, userId, limit, offset)
+
+			assert.Equal(t, tc.expectedResult, tasks)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_RestoreTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	otherUserId := uint(4)
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult *model.Task
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTrashedTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTrashedSubtasks(gomock.Any(), taskId).Return([]*model.Task{
+					{ID: 5, CompanyID: companyId, CreateUserId: userId, Visibility: "company"},
+					{ID: 6, CompanyID: companyId, CreateUserId: userId, Visibility: "private"},
+				}, nil).Times(1)
+				// 一緒に元に戻るサブタスクのうち、非公開でないものも Webhook で送信する
+				mockTaskRepo.EXPECT().RestoreTask(gomock.Any(), taskId, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id uint, event *model.TaskEvent, webhookEvents []*model.WebhookEvent) error {
+						assert.Len(t, webhookEvents, 2)
+						for _, webhookEvent := range webhookEvents {
+							assert.Equal(t, model.WEBHOOK_EVENT_TYPE_TASK_RESTORED, webhookEvent.Type)
+						}
+						assert.Contains(t, webhookEvents[1].Payload, `"id":5`)
+						return nil
+					}).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), taskId).Return(&model.Task{ID: taskId, Version: 2}, nil).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_RESTORED)).Times(1)
+			},
+			expectedResult: &model.Task{ID: taskId, Version: 2},
+			expectedError:  nil,
+		},
+		{
+			name: "Forbidden - others' task by member",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTrashedTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: otherUserId,
+				}, nil).Times(1)
+				mockCompanyUserRepo.EXPECT().GetCompanyUser(gomock.Any(), companyId, userId).Return(&model.CompanyUser{
+					Role: model.COMPANY_ROLE_MEMBER,
+				}, nil).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrForbidden,
+		},
+		{
+			name: "Conflict - parent task is in trash",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTrashedTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					ParentTaskID: &[]uint{5}[0],
+					CreateUserId: userId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTaskById(gomock.Any(), uint(5)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrConflict,
+		},
+		{
+			name: "Not found in trash",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTrashedTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in RestoreTask",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTrashedTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{
+					ID:           taskId,
+					CreateUserId: userId,
+				}, nil).Times(1)
+				mockTaskRepo.EXPECT().GetTrashedSubtasks(gomock.Any(), taskId).Return([]*model.Task{}, nil).Times(1)
+				mockTaskRepo.EXPECT().RestoreTask(gomock.Any(), taskId, gomock.Any(), gomock.Any()).Return(errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			task, err := taskUseCase.RestoreTask(context.Background(), companyId, taskId, userId)
+
+			assert.Equal(t, tc.expectedResult, task)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_PurgeTaskByAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	taskId := uint(1)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeTask(gomock.Any(), taskId).Return([]string{"1/1/a", "1/2/b"}, nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/1/a").Return(nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/2/b").Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - without attachments",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeTask(gomock.Any(), taskId).Return([]string{}, nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - storage error is ignored",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeTask(gomock.Any(), taskId).Return([]string{"1/1/a", "1/2/b"}, nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/1/a").Return(errors.New("some error")).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/2/b").Return(nil).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Not found in trash",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeTask(gomock.Any(), taskId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			err := taskUseCase.PurgeTaskByAdmin(context.Background(), taskId)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_PurgeExpiredTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeExpiredTasks(gomock.Any()).Return(int64(3), []string{"1/1/a"}, nil).Times(1)
+				mockStorage.EXPECT().Delete(gomock.Any(), "1/1/a").Return(nil).Times(1)
+			},
+			expectedCount: 3,
+			expectedError: nil,
+		},
+		{
+			name: "Error in PurgeExpiredTasks",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().PurgeExpiredTasks(gomock.Any()).Return(int64(0), nil, errors.New("some error")).Times(1)
+			},
+			expectedCount: 0,
+			expectedError: errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			count, err := taskUseCase.PurgeExpiredTasks(context.Background())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_GetTaskHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	companyId := uint(1)
+	taskId := uint(2)
+	userId := uint(3)
+	limit := 10
+	offset := 0
+	events := []*model.TaskEvent{{ID: 5, TaskID: taskId, ActorID: userId, Action: model.TASK_EVENT_ACTION_CREATE}}
+
+	testCases := []struct {
+		name           string
+		mockFunc       func()
+		expectedResult []*model.TaskEvent
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskEventRepo.EXPECT().GetTaskEvents(gomock.Any(), taskId, limit, offset).Return(events, nil).Times(1)
+			},
+			expectedResult: events,
+			expectedError:  nil,
+		},
+		{
+			name: "Task not found",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+		{
+			name: "Error in GetTaskEvents",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTask(gomock.Any(), companyId, taskId, userId).Return(&model.Task{ID: taskId}, nil).Times(1)
+				mockTaskEventRepo.EXPECT().GetTaskEvents(gomock.Any(), taskId, limit, offset).Return(nil, errors.New("some error")).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := taskUseCase.GetTaskHistory(context.Background(), companyId, taskId, userId, limit, offset)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTaskUseCase_MaterializeTaskOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mock_repository.NewMockTaskRepository(ctrl)
+	mockTaskSearchRepo := mock_repository.NewMockTaskSearchRepository(ctrl)
+	mockTaskEventRepo := mock_repository.NewMockTaskEventRepository(ctrl)
+	mockLabelRepo := mock_repository.NewMockLabelRepository(ctrl)
+	mockTaskDependencyRepo := mock_repository.NewMockTaskDependencyRepository(ctrl)
+	mockCompanyRepo := mock_repository.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := mock_repository.NewMockCompanyUserRepository(ctrl)
+	mockNotificationRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	mockStorage := mock_storage.NewMockStorage(ctrl)
+
+	taskUseCase := usecase.NewTaskUseCase(mockTaskRepo, mockTaskSearchRepo, mockTaskEventRepo, mockLabelRepo, mockTaskDependencyRepo, mockCompanyRepo, mockCompanyUserRepo, mockNotificationRepo, mockBroker, mockStorage)
+
+	now := time.Date(2099, time.January, 5, 0, 0, 0, 0, time.UTC)
+	until := now.Add(7 * 24 * time.Hour)
+	rule := "FREQ=DAILY;COUNT=2"
+	startAt := time.Date(2099, time.January, 5, 9, 0, 0, 0, time.UTC)
+	nextDueAt := time.Date(2099, time.January, 6, 9, 0, 0, 0, time.UTC)
+	newDueTask := func(id uint) *model.Task {
+		return &model.Task{
+			ID: id, CompanyID: 1, CreateUserId: 2, DueDate: &startAt, Status: "pending",
+			RecurrenceRule: &rule, RecurrenceStartAt: &startAt, RecurrenceIndex: 1, RecurrenceNextDueAt: &nextDueAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				gomock.InOrder(
+					mockTaskRepo.EXPECT().GetTasksWithDueRecurrence(gomock.Any(), until, usecase.TASK_RECURRENCE_BATCH_SIZE).Return([]*model.Task{newDueTask(3), newDueTask(4)}, nil).Times(1),
+					mockTaskRepo.EXPECT().GetTasksWithDueRecurrence(gomock.Any(), until, usecase.TASK_RECURRENCE_BATCH_SIZE).Return([]*model.Task{}, nil).Times(1),
+				)
+				// 会社のタイムゾーンは1回の実行で1度だけ取得する
+				mockCompanyRepo.EXPECT().GetCompany(gomock.Any(), uint(1)).Return(&model.Company{ID: 1}, nil).Times(1)
+				mockTaskRepo.EXPECT().CreateTaskOccurrence(gomock.Any(), uint(3), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, next *model.Task, event *model.TaskEvent, newWebhookEvent func(*model.Task) (*model.WebhookEvent, error)) (*model.Task, error) {
+					// COUNT に達したため、次の繰り返しはない
+					assert.Nil(t, next.RecurrenceNextDueAt)
+					assert.Equal(t, uint(2), event.ActorID)
+					return next, nil
+				}).Times(1)
+				mockBroker.EXPECT().Publish(realtimeEventOfType(realtime.EVENT_TYPE_TASK_CREATED)).Times(1)
+				// 完了による作成と同時に行われた場合は数えない
+				mockTaskRepo.EXPECT().CreateTaskOccurrence(gomock.Any(), uint(4), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, myErrors.ErrConflict).Times(1)
+			},
+			expectedCount: 1,
+			expectedError: nil,
+		},
+		{
+			name: "Error in GetTasksWithDueRecurrence",
+			mockFunc: func() {
+				mockTaskRepo.EXPECT().GetTasksWithDueRecurrence(gomock.Any(), until, usecase.TASK_RECURRENCE_BATCH_SIZE).Return(nil, myErrors.ErrDb).Times(1)
+			},
+			expectedCount: 0,
+			expectedError: myErrors.ErrDb,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			count, err := taskUseCase.MaterializeTaskOccurrences(context.Background(), now, until)
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+// realtimeEventOfType は、種別が eventType のリアルタイム配信のイベントに一致する。
+func realtimeEventOfType(eventType string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		event, ok := x.(*realtime.Event)
+		return ok && event.Type == eventType
+	})
+}
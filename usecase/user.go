@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"todo-api/model"
 	"todo-api/repository"
 
@@ -9,7 +10,7 @@ import (
 )
 
 type UserUseCase interface {
-	CreateUser(username, email, password, role string, companyIds []uint) (*model.User, error)
+	CreateUser(ctx context.Context, username, email, password, role string, companyIds []uint) (*model.User, error)
 }
 
 type userUseCase struct {
@@ -30,7 +31,7 @@ func NewUserUseCase(
 	}
 }
 
-func (u *userUseCase) CreateUser(username, email, password, role string, companyIds []uint) (*model.User, error) {
+func (u *userUseCase) CreateUser(ctx context.Context, username, email, password, role string, companyIds []uint) (*model.User, error) {
 
 	// パスワードのハッシュ化
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -39,7 +40,7 @@ func (u *userUseCase) CreateUser(username, email, password, role string, company
 	}
 
 	// トランザクションの開始
-	tx := u.db.Begin()
+	tx := u.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -49,23 +50,27 @@ func (u *userUseCase) CreateUser(username, email, password, role string, company
 		PasswordHash: string(hashedPassword),
 		Role:         role,
 	}
-	createdUser, err := u.userRepository.CreateUser(user)
+	createdUser, err := u.userRepository.CreateUser(ctx, user)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
-	companyUsers := []*model.CompanyUser{}
-	for _, companyId := range companyIds {
-		companyUsers = append(companyUsers, &model.CompanyUser{
-			UserID:    createdUser.ID,
-			CompanyID: companyId,
-		})
-	}
-	_, err = u.companyUserRepository.CreateCompanyUsers(companyUsers)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
+	// 所属する会社が指定された場合のみ、メンバーとして追加する
+	if len(companyIds) > 0 {
+		companyUsers := []*model.CompanyUser{}
+		for _, companyId := range companyIds {
+			companyUsers = append(companyUsers, &model.CompanyUser{
+				UserID:    createdUser.ID,
+				CompanyID: companyId,
+				Role:      model.COMPANY_ROLE_MEMBER,
+			})
+		}
+		_, err = u.companyUserRepository.CreateCompanyUsers(ctx, companyUsers)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
 	// トランザクションのコミット
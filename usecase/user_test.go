@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -29,20 +30,22 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 
 	testCases := []struct {
 		name           string
+		companyIds     []uint
 		mock           func(sqlMock sqlmock.Sqlmock)
 		expectedResult *model.User
 		expectedError  error
 	}{
 		{
-			name: "Success",
+			name:       "Success",
+			companyIds: []uint{1},
 			mock: func(sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				mockUserRepo.EXPECT().
-					CreateUser(gomock.Any()).
+					CreateUser(gomock.Any(), gomock.Any()).
 					Return(user, nil).
 					Times(1)
 				mockCompanyUserRepo.EXPECT().
-					CreateCompanyUsers(gomock.Any()).
+					CreateCompanyUsers(gomock.Any(), gomock.Any()).
 					Return([]*model.CompanyUser{}, nil).
 					Times(1)
 				sqlMock.ExpectCommit()
@@ -51,11 +54,29 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 			expectedError:  nil,
 		},
 		{
-			name: "Error in CreateUser",
+			// 会社が指定されない場合は、どの会社にも所属させない
+			name: "Success - without companies",
 			mock: func(sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				mockUserRepo.EXPECT().
-					CreateUser(gomock.Any()).
+					CreateUser(gomock.Any(), gomock.Any()).
+					Return(user, nil).
+					Times(1)
+				mockCompanyUserRepo.EXPECT().
+					CreateCompanyUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+				sqlMock.ExpectCommit()
+			},
+			expectedResult: user,
+			expectedError:  nil,
+		},
+		{
+			name:       "Error in CreateUser",
+			companyIds: []uint{1},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				mockUserRepo.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("some error")).
 					Times(1)
 				sqlMock.ExpectExec("INSERT INTO `users`").
@@ -67,18 +88,19 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 			expectedError:  errors.New("some error"),
 		},
 		{
-			name: "Error in CreateCompanyUsers",
+			name:       "Error in CreateCompanyUsers",
+			companyIds: []uint{1},
 			mock: func(sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				mockUserRepo.EXPECT().
-					CreateUser(gomock.Any()).
+					CreateUser(gomock.Any(), gomock.Any()).
 					Return(user, nil).
 					Times(1)
 				sqlMock.ExpectExec("INSERT INTO `users`").
 					WithArgs(gomock.Any()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mockCompanyUserRepo.EXPECT().
-					CreateCompanyUsers(gomock.Any()).
+					CreateCompanyUsers(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("some error")).
 					Times(1)
 				sqlMock.ExpectExec("INSERT INTO `company_users`").
@@ -100,7 +122,7 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 			tc.mock(sqlMock)
 
 			userUseCase := NewUserUseCase(db, mockUserRepo, mockCompanyUserRepo)
-			createdUser, err := userUseCase.CreateUser("testuser", "test@example.com", "password", "user", []uint{1})
+			createdUser, err := userUseCase.CreateUser(context.Background(), "testuser", "test@example.com", "password", "user", tc.companyIds)
 
 			assert.Equal(t, tc.expectedResult, createdUser)
 			assert.Equal(t, tc.expectedError, err)
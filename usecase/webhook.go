@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	myErrors "todo-api/errors"
+	"todo-api/model"
+	"todo-api/repository"
+	"todo-api/webhook"
+)
+
+const (
+	// 配信に振り分けるイベントと、送信する配信を一度に取得する件数
+	WEBHOOK_BATCH_SIZE = 100
+
+	// 送信を始めた配信を、他のプロセスが送信しないようにする期間
+	WEBHOOK_DELIVERY_LEASE = 5 * time.Minute
+)
+
+type WebhookUseCase interface {
+	GetWebhooks(ctx context.Context, companyId uint) ([]*model.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, companyId, webhookId uint) error
+	GetWebhookDeliveries(ctx context.Context, companyId, webhookId uint, limit, offset int) ([]*model.WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, companyId, webhookId, deliveryId uint) (*model.WebhookDelivery, error)
+	DeliverWebhooks(ctx context.Context, now time.Time) (int, error)
+}
+
+type webhookUseCase struct {
+	webhookRepository repository.WebhookRepository
+	sender            webhook.Sender
+}
+
+func NewWebhookUseCase(webhookRepository repository.WebhookRepository, sender webhook.Sender) WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepository: webhookRepository,
+		sender:            sender,
+	}
+}
+
+func (u *webhookUseCase) GetWebhooks(ctx context.Context, companyId uint) ([]*model.Webhook, error) {
+	webhooks, err := u.webhookRepository.GetWebhooks(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook は、Webhook を作成する。URL が内部のアドレスを指す場合などは ErrInvalidArgument を返す。
+func (u *webhookUseCase) CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	if err := u.sender.CheckURL(ctx, webhook.URL); err != nil {
+		return nil, myErrors.ErrInvalidArgument.Wrap(err)
+	}
+
+	webhook, err := u.webhookRepository.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook は、Webhook の URL、購読するイベントの種別、有効かどうかを更新する。Secret が空の場合は変更しない。
+// URL が内部のアドレスを指す場合などは ErrInvalidArgument を返す。
+func (u *webhookUseCase) UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	oldWebhook, err := u.webhookRepository.GetWebhook(ctx, webhook.CompanyID, webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.sender.CheckURL(ctx, webhook.URL); err != nil {
+		return nil, myErrors.ErrInvalidArgument.Wrap(err)
+	}
+
+	oldWebhook.URL = webhook.URL
+	if webhook.Secret != "" {
+		oldWebhook.Secret = webhook.Secret
+	}
+	oldWebhook.EventTypes = webhook.EventTypes
+	oldWebhook.Active = webhook.Active
+	webhook, err = u.webhookRepository.UpdateWebhook(ctx, oldWebhook)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (u *webhookUseCase) DeleteWebhook(ctx context.Context, companyId, webhookId uint) error {
+	return u.webhookRepository.DeleteWebhook(ctx, companyId, webhookId)
+}
+
+// GetWebhookDeliveries は、会社の Webhook の配信の記録を新しい順に取得する。
+func (u *webhookUseCase) GetWebhookDeliveries(ctx context.Context, companyId, webhookId uint, limit, offset int) ([]*model.WebhookDelivery, error) {
+	if _, err := u.webhookRepository.GetWebhook(ctx, companyId, webhookId); err != nil {
+		return nil, err
+	}
+	deliveries, err := u.webhookRepository.GetWebhookDeliveries(ctx, webhookId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhookDelivery は、配信したイベントを同じ内容で再送する新しい配信を作成する。送信は次の送信処理で行う。
+func (u *webhookUseCase) RedeliverWebhookDelivery(ctx context.Context, companyId, webhookId, deliveryId uint) (*model.WebhookDelivery, error) {
+	if _, err := u.webhookRepository.GetWebhook(ctx, companyId, webhookId); err != nil {
+		return nil, err
+	}
+	delivery, err := u.webhookRepository.GetWebhookDelivery(ctx, webhookId, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	redelivery, err := u.webhookRepository.CreateWebhookDelivery(ctx, model.NewWebhookDelivery(webhookId, delivery.WebhookEventID, true, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	redelivery.WebhookEvent = delivery.WebhookEvent
+	return redelivery, nil
+}
+
+// DeliverWebhooks は、記録されたイベントを購読している Webhook ごとの配信に振り分け、送信の日時を過ぎた配信を送信する。
+// 送信に成功した配信の件数を返す。失敗した配信は、指数バックオフで再試行する。
+func (u *webhookUseCase) DeliverWebhooks(ctx context.Context, now time.Time) (int, error) {
+	for {
+		count, err := u.webhookRepository.DispatchWebhookEvents(ctx, now, WEBHOOK_BATCH_SIZE)
+		if err != nil {
+			return 0, err
+		}
+		if count < WEBHOOK_BATCH_SIZE {
+			break
+		}
+	}
+
+	deliveries, err := u.webhookRepository.GetDueWebhookDeliveries(ctx, now, WEBHOOK_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+	succeeded := 0
+	for _, delivery := range deliveries {
+		sent, err := u.deliverWebhook(ctx, delivery, now)
+		if err != nil {
+			return succeeded, err
+		}
+		if sent {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// deliverWebhook は、他のプロセスが送信していないことを確認してから配信を送信し、結果を記録する。送信に成功したかどうかを返す。
+func (u *webhookUseCase) deliverWebhook(ctx context.Context, delivery *model.WebhookDelivery, now time.Time) (bool, error) {
+	err := u.webhookRepository.ClaimWebhookDelivery(ctx, delivery, now, now.Add(WEBHOOK_DELIVERY_LEASE))
+	if errors.Is(err, myErrors.ErrConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var responseStatus *int
+	body, err := delivery.WebhookEvent.Body()
+	if err == nil {
+		var status int
+		status, err = u.sender.Send(ctx, &webhook.Request{
+			URL:        delivery.Webhook.URL,
+			Secret:     delivery.Webhook.Secret,
+			EventType:  delivery.WebhookEvent.Type,
+			DeliveryID: delivery.ID,
+			Body:       body,
+		})
+		if status != 0 {
+			responseStatus = &status
+		}
+	}
+	if err != nil {
+		slog.Info(fmt.Sprintf("error DeliverWebhooks: failed to deliver %d to webhook %d: %v", delivery.ID, delivery.WebhookID, err))
+	}
+
+	delivery.RecordAttempt(now, responseStatus, err)
+	if err := u.webhookRepository.SaveWebhookDeliveryAttempt(ctx, delivery); err != nil {
+		return false, err
+	}
+	return delivery.Status == model.WEBHOOK_DELIVERY_STATUS_SUCCEEDED, nil
+}
@@ -0,0 +1,362 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	myErrors "todo-api/errors"
+	mock_repository "todo-api/mock/repository"
+	mock_webhook "todo-api/mock/webhook"
+	"todo-api/model"
+	"todo-api/usecase"
+	"todo-api/webhook"
+)
+
+func TestWebhookUseCase_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	mockSender := mock_webhook.NewMockSender(ctrl)
+
+	webhookUseCase := usecase.NewWebhookUseCase(mockWebhookRepo, mockSender)
+
+	testCases := []struct {
+		name           string
+		webhook        *model.Webhook
+		mockFunc       func()
+		expectedResult *model.Webhook
+		expectedError  error
+	}{
+		{
+			name:    "Success",
+			webhook: &model.Webhook{CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", Active: true},
+			mockFunc: func() {
+				mockSender.EXPECT().CheckURL(gomock.Any(), "https://example.com/hook").Return(nil).Times(1)
+				mockWebhookRepo.EXPECT().CreateWebhook(gomock.Any(), &model.Webhook{CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", Active: true}).
+					Return(&model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", Active: true}, nil).Times(1)
+			},
+			expectedResult: &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", Active: true},
+			expectedError:  nil,
+		},
+		{
+			name:    "Destination not allowed",
+			webhook: &model.Webhook{CompanyID: 1, URL: "http://localhost:3306", Secret: "secret-secret-secret", Active: true},
+			mockFunc: func() {
+				mockSender.EXPECT().CheckURL(gomock.Any(), "http://localhost:3306").Return(webhook.ErrDestinationNotAllowed).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument.Wrap(webhook.ErrDestinationNotAllowed),
+		},
+		{
+			name:    "Unresolvable host",
+			webhook: &model.Webhook{CompanyID: 1, URL: "http://db", Secret: "secret-secret-secret", Active: true},
+			mockFunc: func() {
+				mockSender.EXPECT().CheckURL(gomock.Any(), "http://db").Return(webhook.ErrUnresolvableHost).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument.Wrap(webhook.ErrUnresolvableHost),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := webhookUseCase.CreateWebhook(context.Background(), tc.webhook)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestWebhookUseCase_UpdateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	mockSender := mock_webhook.NewMockSender(ctrl)
+
+	webhookUseCase := usecase.NewWebhookUseCase(mockWebhookRepo, mockSender)
+
+	stored := func() *model.Webhook {
+		return &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/old", Secret: "old-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_CREATED}, Active: true}
+	}
+
+	testCases := []struct {
+		name           string
+		webhook        *model.Webhook
+		mockFunc       func()
+		expectedResult *model.Webhook
+		expectedError  error
+	}{
+		{
+			name:    "Success",
+			webhook: &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", Secret: "new-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: false},
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(stored(), nil).Times(1)
+				mockSender.EXPECT().CheckURL(gomock.Any(), "https://example.com/new").Return(nil).Times(1)
+				mockWebhookRepo.EXPECT().UpdateWebhook(gomock.Any(), &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", Secret: "new-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: false}).
+					DoAndReturn(func(_ context.Context, webhook *model.Webhook) (*model.Webhook, error) { return webhook, nil }).Times(1)
+			},
+			expectedResult: &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", Secret: "new-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: false},
+			expectedError:  nil,
+		},
+		{
+			name:    "Success - keep secret",
+			webhook: &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: true},
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(stored(), nil).Times(1)
+				mockSender.EXPECT().CheckURL(gomock.Any(), "https://example.com/new").Return(nil).Times(1)
+				mockWebhookRepo.EXPECT().UpdateWebhook(gomock.Any(), &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", Secret: "old-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: true}).
+					DoAndReturn(func(_ context.Context, webhook *model.Webhook) (*model.Webhook, error) { return webhook, nil }).Times(1)
+			},
+			expectedResult: &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/new", Secret: "old-secret-value", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: true},
+			expectedError:  nil,
+		},
+		{
+			name:    "Destination not allowed",
+			webhook: &model.Webhook{ID: 2, CompanyID: 1, URL: "http://10.0.0.1/hook", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_UPDATED}, Active: true},
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(stored(), nil).Times(1)
+				mockSender.EXPECT().CheckURL(gomock.Any(), "http://10.0.0.1/hook").Return(webhook.ErrDestinationNotAllowed).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrInvalidArgument.Wrap(webhook.ErrDestinationNotAllowed),
+		},
+		{
+			name:    "Webhook not found",
+			webhook: &model.Webhook{ID: 2, CompanyID: 1},
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedResult: nil,
+			expectedError:  myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			result, err := webhookUseCase.UpdateWebhook(context.Background(), tc.webhook)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestWebhookUseCase_RedeliverWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	mockSender := mock_webhook.NewMockSender(ctrl)
+
+	webhookUseCase := usecase.NewWebhookUseCase(mockWebhookRepo, mockSender)
+
+	event := &model.WebhookEvent{ID: 4, CompanyID: 1, Type: model.WEBHOOK_EVENT_TYPE_TASK_CREATED}
+
+	testCases := []struct {
+		name          string
+		mockFunc      func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(&model.Webhook{ID: 2, CompanyID: 1}, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetWebhookDelivery(gomock.Any(), uint(2), uint(3)).Return(&model.WebhookDelivery{ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_FAILED, WebhookEvent: event}, nil).Times(1)
+				mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+					// 同じイベントを送信待ちの新しい配信として作成する
+					assert.Equal(t, uint(2), delivery.WebhookID)
+					assert.Equal(t, uint(4), delivery.WebhookEventID)
+					assert.True(t, delivery.Redelivery)
+					assert.Equal(t, model.WEBHOOK_DELIVERY_STATUS_PENDING, delivery.Status)
+					delivery.ID = 5
+					return delivery, nil
+				}).Times(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Webhook of another company",
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+		{
+			name: "Delivery not found",
+			mockFunc: func() {
+				mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), uint(1), uint(2)).Return(&model.Webhook{ID: 2, CompanyID: 1}, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetWebhookDelivery(gomock.Any(), uint(2), uint(3)).Return(nil, myErrors.ErrNotFound).Times(1)
+			},
+			expectedError: myErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			delivery, err := webhookUseCase.RedeliverWebhookDelivery(context.Background(), 1, 2, 3)
+
+			assert.Equal(t, tc.expectedError, err)
+			if err == nil {
+				assert.Equal(t, uint(5), delivery.ID)
+				assert.Equal(t, event, delivery.WebhookEvent)
+			}
+		})
+	}
+}
+
+func TestWebhookUseCase_DeliverWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	mockSender := mock_webhook.NewMockSender(ctrl)
+
+	webhookUseCase := usecase.NewWebhookUseCase(mockWebhookRepo, mockSender)
+
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2023, time.December, 31, 23, 59, 0, 0, time.UTC)
+	hook := &model.Webhook{ID: 2, CompanyID: 1, URL: "https://example.com/hook", Secret: "secret-secret-secret", EventTypes: []string{model.WEBHOOK_EVENT_TYPE_TASK_CREATED}, Active: true}
+	event := &model.WebhookEvent{ID: 4, CompanyID: 1, Type: model.WEBHOOK_EVENT_TYPE_TASK_CREATED, Payload: `{"task":{"id":5}}`, CreatedAt: &createdAt}
+	request := &webhook.Request{
+		URL:        "https://example.com/hook",
+		Secret:     "secret-secret-secret",
+		EventType:  model.WEBHOOK_EVENT_TYPE_TASK_CREATED,
+		DeliveryID: 3,
+		Body:       []byte(`{"id":4,"type":"task.created","company_id":1,"created_at":"2023-12-31T23:59:00Z","data":{"task":{"id":5}}}`),
+	}
+	newDelivery := func(attempts int) *model.WebhookDelivery {
+		return &model.WebhookDelivery{ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_PENDING, Attempts: attempts, NextAttemptAt: &now, Webhook: hook, WebhookEvent: event}
+	}
+	status := func(code int) *int { return &code }
+	errorMessage := func(message string) *string { return &message }
+	nextAttemptAt := func(d time.Duration) *time.Time {
+		next := now.Add(d)
+		return &next
+	}
+
+	testCases := []struct {
+		name             string
+		delivery         *model.WebhookDelivery
+		mockFunc         func(delivery *model.WebhookDelivery)
+		expectedCount    int
+		expectedError    error
+		expectedDelivery *model.WebhookDelivery
+	}{
+		{
+			name:     "Success",
+			delivery: newDelivery(0),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(1, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return([]*model.WebhookDelivery{delivery}, nil).Times(1)
+				mockWebhookRepo.EXPECT().ClaimWebhookDelivery(gomock.Any(), delivery, now, now.Add(usecase.WEBHOOK_DELIVERY_LEASE)).Return(nil).Times(1)
+				mockSender.EXPECT().Send(ctx, request).Return(200, nil).Times(1)
+				mockWebhookRepo.EXPECT().SaveWebhookDeliveryAttempt(gomock.Any(), delivery).Return(nil).Times(1)
+			},
+			expectedCount: 1,
+			expectedError: nil,
+			expectedDelivery: &model.WebhookDelivery{
+				ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_SUCCEEDED, Attempts: 1,
+				LastAttemptAt: &now, ResponseStatus: status(200), Webhook: hook, WebhookEvent: event,
+			},
+		},
+		{
+			name:     "Failed - retry with backoff",
+			delivery: newDelivery(2),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(0, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return([]*model.WebhookDelivery{delivery}, nil).Times(1)
+				mockWebhookRepo.EXPECT().ClaimWebhookDelivery(gomock.Any(), delivery, now, now.Add(usecase.WEBHOOK_DELIVERY_LEASE)).Return(nil).Times(1)
+				mockSender.EXPECT().Send(ctx, request).Return(503, errors.New("unexpected response status: 503")).Times(1)
+				mockWebhookRepo.EXPECT().SaveWebhookDeliveryAttempt(gomock.Any(), delivery).Return(nil).Times(1)
+			},
+			expectedCount: 0,
+			expectedError: nil,
+			// 3回目の失敗のため、最初の間隔の4倍待つ
+			expectedDelivery: &model.WebhookDelivery{
+				ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_PENDING, Attempts: 3,
+				NextAttemptAt: nextAttemptAt(4 * model.WEBHOOK_RETRY_BASE_INTERVAL), LastAttemptAt: &now,
+				ResponseStatus: status(503), Error: errorMessage("unexpected response status: 503"), Webhook: hook, WebhookEvent: event,
+			},
+		},
+		{
+			name:     "Failed - max attempts",
+			delivery: newDelivery(model.WEBHOOK_MAX_ATTEMPTS - 1),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(0, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return([]*model.WebhookDelivery{delivery}, nil).Times(1)
+				mockWebhookRepo.EXPECT().ClaimWebhookDelivery(gomock.Any(), delivery, now, now.Add(usecase.WEBHOOK_DELIVERY_LEASE)).Return(nil).Times(1)
+				mockSender.EXPECT().Send(ctx, request).Return(0, errors.New("connection refused")).Times(1)
+				mockWebhookRepo.EXPECT().SaveWebhookDeliveryAttempt(gomock.Any(), delivery).Return(nil).Times(1)
+			},
+			expectedCount: 0,
+			expectedError: nil,
+			expectedDelivery: &model.WebhookDelivery{
+				ID: 3, WebhookID: 2, WebhookEventID: 4, Status: model.WEBHOOK_DELIVERY_STATUS_FAILED, Attempts: model.WEBHOOK_MAX_ATTEMPTS,
+				LastAttemptAt: &now, Error: errorMessage("connection refused"), Webhook: hook, WebhookEvent: event,
+			},
+		},
+		{
+			name:     "Claimed by another process",
+			delivery: newDelivery(0),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(0, nil).Times(1)
+				mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return([]*model.WebhookDelivery{delivery}, nil).Times(1)
+				mockWebhookRepo.EXPECT().ClaimWebhookDelivery(gomock.Any(), delivery, now, now.Add(usecase.WEBHOOK_DELIVERY_LEASE)).Return(myErrors.ErrConflict).Times(1)
+			},
+			expectedCount:    0,
+			expectedError:    nil,
+			expectedDelivery: newDelivery(0),
+		},
+		{
+			name:     "Dispatch in batches",
+			delivery: newDelivery(0),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				gomock.InOrder(
+					mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(usecase.WEBHOOK_BATCH_SIZE, nil),
+					mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(3, nil),
+				)
+				mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return([]*model.WebhookDelivery{}, nil).Times(1)
+			},
+			expectedCount:    0,
+			expectedError:    nil,
+			expectedDelivery: newDelivery(0),
+		},
+		{
+			name:     "Error in DispatchWebhookEvents",
+			delivery: newDelivery(0),
+			mockFunc: func(delivery *model.WebhookDelivery) {
+				mockWebhookRepo.EXPECT().DispatchWebhookEvents(gomock.Any(), now, usecase.WEBHOOK_BATCH_SIZE).Return(0, myErrors.ErrDb).Times(1)
+			},
+			expectedCount:    0,
+			expectedError:    myErrors.ErrDb,
+			expectedDelivery: newDelivery(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc(tc.delivery)
+
+			count, err := webhookUseCase.DeliverWebhooks(ctx, now)
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedDelivery, tc.delivery)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// 1回の送信のタイムアウト
+	DEFAULT_HTTP_SENDER_TIMEOUT = 10 * time.Second
+)
+
+// 送信のエラー。配信の記録は会社の管理者が閲覧できるため、内部のネットワークの情報を含まない決まったメッセージとする
+var (
+	// 送信先がループバック、プライベート、リンクローカルなどの内部のアドレスであることを示すエラー
+	ErrDestinationNotAllowed = errors.New("destination address is not allowed")
+	// 送信先のホスト名を解決できなかったことを示すエラー
+	ErrUnresolvableHost = errors.New("destination host cannot be resolved")
+	// 送信がタイムアウトしたことを示すエラー
+	ErrSendTimeout = errors.New("request timed out")
+	// 送信に失敗したことを示すエラー
+	ErrSendFailed = errors.New("failed to send request")
+)
+
+// 送信先として許可しない、グローバルユニキャストのうち内部や特殊な用途のアドレス
+var disallowedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// httpSender は HTTP の POST で送信する実装。リダイレクトには従わない。
+// 内部のネットワークへの送信 (SSRF) を防ぐため、接続する時点で解決したアドレスを確認し、公開されたアドレス以外には接続しない。
+type httpSender struct {
+	client   *http.Client
+	resolver *net.Resolver
+	now      func() time.Time
+}
+
+func NewHTTPSender(timeout time.Duration) Sender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// 名前解決の後に確認するため、DNS の応答を差し替えられても (DNS リバインディング) 内部のアドレスには接続しない
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isAllowedAddress(addrPort.Addr()) {
+				return ErrDestinationNotAllowed
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシを経由すると接続先のアドレスを確認できないため、使わない
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		resolver: net.DefaultResolver,
+		now:      time.Now,
+	}
+}
+
+// CheckURL は、URL のホスト名を解決し、全てのアドレスが公開されたアドレスであることを確認する。
+func (s *httpSender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return ErrUnresolvableHost
+	}
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		slog.Info(fmt.Sprintf("error CheckURL: %v", err))
+		return ErrUnresolvableHost
+	}
+	for _, addr := range addrs {
+		if !isAllowedAddress(addr) {
+			return ErrDestinationNotAllowed
+		}
+	}
+	return nil
+}
+
+func (s *httpSender) Send(ctx context.Context, request *Request) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-api-webhook")
+	req.Header.Set(HEADER_EVENT, request.EventType)
+	req.Header.Set(HEADER_DELIVERY, strconv.FormatUint(uint64(request.DeliveryID), 10))
+	req.Header.Set(HEADER_TIMESTAMP, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HEADER_SIGNATURE, Sign(request.Secret, timestamp, request.Body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		// 詳しい原因はログにのみ出力する
+		slog.Info(fmt.Sprintf("error Send: failed to send delivery %d: %v", request.DeliveryID, err))
+		return 0, sendError(err)
+	}
+	defer res.Body.Close()
+	// 接続を再利用できるよう、レスポンスボディを読み捨てる
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// sendError は、送信のエラーを、内部のネットワークの情報を含まない決まったエラーにする。
+func sendError(err error) error {
+	if errors.Is(err, ErrDestinationNotAllowed) {
+		return ErrDestinationNotAllowed
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrUnresolvableHost
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrSendTimeout
+	}
+	return ErrSendFailed
+}
+
+// isAllowedAddress は、addr が送信先として許可される、公開されたユニキャストのアドレスかを返す。
+// ループバック、プライベート、リンクローカル (169.254.169.254 などのメタデータのアドレスを含む) は許可しない。
+func isAllowedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, prefix := range disallowedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
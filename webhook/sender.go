@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// イベントの種別を示すヘッダ
+	HEADER_EVENT = "X-Webhook-Event"
+	// 配信のIDを示すヘッダ。再送した場合は新しいIDになる。
+	HEADER_DELIVERY = "X-Webhook-Delivery"
+	// 署名した日時 (UNIX 時間の秒) を示すヘッダ
+	HEADER_TIMESTAMP = "X-Webhook-Timestamp"
+	// 署名を示すヘッダ
+	HEADER_SIGNATURE = "X-Webhook-Signature"
+)
+
+// Request は Webhook で送信するリクエストの内容
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID uint
+	Body       []byte
+}
+
+// Sender は Webhook のリクエストを送信する。
+// 送信方法を差し替える場合は、このインターフェースを実装する。
+type Sender interface {
+	// Send は、request を送信し、レスポンスのステータスコードを返す。
+	// レスポンスを受け取れなかった場合は 0 を返す。2xx 以外のステータスコードの場合はエラーを返す。
+	Send(ctx context.Context, request *Request) (int, error)
+	// CheckURL は、url が送信先として許可されるかを確認する。許可されない場合はエラーを返す。
+	CheckURL(ctx context.Context, url string) error
+}
+
+// Sign は、"{timestamp}.{body}" の HMAC-SHA256 を secret で計算し、"sha256=" に続けて16進数で返す。
+// 受信側は同じ計算を行い、X-Webhook-Signature と比較することで、送信元と内容を検証できる。
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"todo-api/usecase"
+)
+
+const (
+	// 繰り返しのタスクを作成するデフォルトの間隔
+	DEFAULT_TASK_RECURRENCE_INTERVAL = 15 * time.Minute
+	// 繰り返しのタスクを事前に作成するデフォルトの期間
+	DEFAULT_TASK_RECURRENCE_HORIZON = 7 * 24 * time.Hour
+)
+
+// TaskRecurrenceScheduler は、期限が近づいた繰り返しのタスクの次のタスクを定期的に作成する
+type TaskRecurrenceScheduler interface {
+	Run(ctx context.Context)
+}
+
+type taskRecurrenceScheduler struct {
+	taskUseCase usecase.TaskUseCase
+	interval    time.Duration
+	horizon     time.Duration
+}
+
+func NewTaskRecurrenceScheduler(taskUseCase usecase.TaskUseCase, interval, horizon time.Duration) TaskRecurrenceScheduler {
+	return &taskRecurrenceScheduler{
+		taskUseCase: taskUseCase,
+		interval:    interval,
+		horizon:     horizon,
+	}
+}
+
+// Run は、ctx がキャンセルされるまで interval ごとに、horizon 以内に期限を迎えるタスクを作成する。
+func (w *taskRecurrenceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.materialize(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *taskRecurrenceScheduler) materialize(ctx context.Context) {
+	now := time.Now()
+	count, err := w.taskUseCase.MaterializeTaskOccurrences(ctx, now, now.Add(w.horizon))
+	if err != nil {
+		slog.Info(fmt.Sprintf("error MaterializeTaskOccurrences: %v", err))
+	}
+	if count > 0 {
+		slog.Info(fmt.Sprintf("created %d recurring task occurrences", count))
+	}
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"todo-api/usecase"
+)
+
+const (
+	// 期限のリマインダーを確認するデフォルトの間隔
+	DEFAULT_TASK_REMINDER_INTERVAL = 5 * time.Minute
+)
+
+// DEFAULT_TASK_REMINDER_WINDOWS は、期限の何時間前にリマインダーを送るかのデフォルト
+var DEFAULT_TASK_REMINDER_WINDOWS = []time.Duration{24 * time.Hour, time.Hour}
+
+// TaskReminderScheduler は、期限が近づいたタスクと期限を過ぎたタスクのリマインダーを定期的に送る
+type TaskReminderScheduler interface {
+	Run(ctx context.Context)
+}
+
+type taskReminderScheduler struct {
+	taskReminderUseCase usecase.TaskReminderUseCase
+	interval            time.Duration
+	windows             []time.Duration
+}
+
+func NewTaskReminderScheduler(taskReminderUseCase usecase.TaskReminderUseCase, interval time.Duration, windows []time.Duration) TaskReminderScheduler {
+	return &taskReminderScheduler{
+		taskReminderUseCase: taskReminderUseCase,
+		interval:            interval,
+		windows:             windows,
+	}
+}
+
+// Run は、ctx がキャンセルされるまで interval ごとにリマインダーを送る。
+func (w *taskReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.remind(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *taskReminderScheduler) remind(ctx context.Context) {
+	count, err := w.taskReminderUseCase.SendTaskReminders(ctx, time.Now(), w.windows)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error SendTaskReminders: %v", err))
+	}
+	if count > 0 {
+		slog.Info(fmt.Sprintf("sent reminders for %d tasks", count))
+	}
+}
+
+// ParseTaskReminderWindows は、"24h,1h" のようなカンマ区切りの時間を解析する。0以下の時間はエラーにする。
+func ParseTaskReminderWindows(value string) ([]time.Duration, error) {
+	windows := []time.Duration{}
+	for _, part := range strings.Split(value, ",") {
+		window, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("reminder window must be positive: %s", part)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
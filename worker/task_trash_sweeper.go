@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"todo-api/usecase"
+)
+
+const (
+	// ゴミ箱のタスクを削除するデフォルトの間隔
+	DEFAULT_TASK_TRASH_SWEEP_INTERVAL = time.Hour
+)
+
+// TaskTrashSweeper は、会社ごとの保持期間を過ぎたゴミ箱のタスクを定期的に完全に削除する
+type TaskTrashSweeper interface {
+	Run(ctx context.Context)
+}
+
+type taskTrashSweeper struct {
+	taskUseCase usecase.TaskUseCase
+	interval    time.Duration
+}
+
+func NewTaskTrashSweeper(taskUseCase usecase.TaskUseCase, interval time.Duration) TaskTrashSweeper {
+	return &taskTrashSweeper{
+		taskUseCase: taskUseCase,
+		interval:    interval,
+	}
+}
+
+// Run は、ctx がキャンセルされるまで interval ごとに削除を行う。
+func (w *taskTrashSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *taskTrashSweeper) sweep(ctx context.Context) {
+	count, err := w.taskUseCase.PurgeExpiredTasks(ctx)
+	if err != nil {
+		slog.Info(fmt.Sprintf("error PurgeExpiredTasks: %v", err))
+		return
+	}
+	if count > 0 {
+		slog.Info(fmt.Sprintf("purged %d expired tasks from trash", count))
+	}
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"todo-api/usecase"
+)
+
+const (
+	// Webhook の配信を確認するデフォルトの間隔
+	DEFAULT_WEBHOOK_DISPATCH_INTERVAL = 10 * time.Second
+)
+
+// WebhookDispatcher は、記録されたイベントを定期的に Webhook に送信し、失敗した配信を再試行する
+type WebhookDispatcher interface {
+	Run(ctx context.Context)
+}
+
+type webhookDispatcher struct {
+	webhookUseCase usecase.WebhookUseCase
+	interval       time.Duration
+}
+
+func NewWebhookDispatcher(webhookUseCase usecase.WebhookUseCase, interval time.Duration) WebhookDispatcher {
+	return &webhookDispatcher{
+		webhookUseCase: webhookUseCase,
+		interval:       interval,
+	}
+}
+
+// Run は、ctx がキャンセルされるまで interval ごとに配信を送信する。
+func (w *webhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *webhookDispatcher) dispatch(ctx context.Context) {
+	count, err := w.webhookUseCase.DeliverWebhooks(ctx, time.Now())
+	if err != nil {
+		slog.Info(fmt.Sprintf("error DeliverWebhooks: %v", err))
+	}
+	if count > 0 {
+		slog.Info(fmt.Sprintf("delivered %d webhooks", count))
+	}
+}